@@ -23,56 +23,6 @@ type BodyBatteryReading struct {
 func ParseBodyBatteryReadings(valuesArray [][]any) []BodyBatteryReading {
 	readings := make([]BodyBatteryReading, 0, len(valuesArray))
 
-	toInt := func(v any) (int, bool) {
-		switch t := v.(type) {
-		case int:
-			return t, true
-		case int32:
-			return int(t), true
-		case int64:
-			return int(t), true
-		case float32:
-			return int(t), true
-		case float64:
-			return int(t), true
-		case json.Number:
-			i, err := t.Int64()
-			if err == nil {
-				return int(i), true
-			}
-			f, err := t.Float64()
-			if err == nil {
-				return int(f), true
-			}
-			return 0, false
-		default:
-			return 0, false
-		}
-	}
-
-	toFloat64 := func(v any) (float64, bool) {
-		switch t := v.(type) {
-		case float32:
-			return float64(t), true
-		case float64:
-			return t, true
-		case int:
-			return float64(t), true
-		case int32:
-			return float64(t), true
-		case int64:
-			return float64(t), true
-		case json.Number:
-			f, err := t.Float64()
-			if err == nil {
-				return f, true
-			}
-			return 0, false
-		default:
-			return 0, false
-		}
-	}
-
 	for _, values := range valuesArray {
 		if len(values) < 4 {
 			continue
@@ -102,6 +52,58 @@ func ParseBodyBatteryReadings(valuesArray [][]any) []BodyBatteryReading {
 	return readings
 }
 
+// toInt converts a decoded JSON number of any numeric type to int
+func toInt(v any) (int, bool) {
+	switch t := v.(type) {
+	case int:
+		return t, true
+	case int32:
+		return int(t), true
+	case int64:
+		return int(t), true
+	case float32:
+		return int(t), true
+	case float64:
+		return int(t), true
+	case json.Number:
+		i, err := t.Int64()
+		if err == nil {
+			return int(i), true
+		}
+		f, err := t.Float64()
+		if err == nil {
+			return int(f), true
+		}
+		return 0, false
+	default:
+		return 0, false
+	}
+}
+
+// toFloat64 converts a decoded JSON number of any numeric type to float64
+func toFloat64(v any) (float64, bool) {
+	switch t := v.(type) {
+	case float32:
+		return float64(t), true
+	case float64:
+		return t, true
+	case int:
+		return float64(t), true
+	case int32:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case json.Number:
+		f, err := t.Float64()
+		if err == nil {
+			return f, true
+		}
+		return 0, false
+	default:
+		return 0, false
+	}
+}
+
 // BodyBatteryDataWithMethods embeds garth.DetailedBodyBatteryData and adds methods
 type BodyBatteryDataWithMethods struct {
 	garth.DetailedBodyBatteryData
@@ -118,7 +120,7 @@ func (d *BodyBatteryDataWithMethods) Get(day time.Time, c shared.APIClient) (int
 	}
 
 	// Get Body Battery events
-	path2 := fmt.Sprintf("/wellness-service/wellness/bodyBattery/%s", dateStr)
+	path2 := fmt.Sprintf("/wellness-service/wellness/bodyBattery/events/%s", dateStr)
 	data2, err := c.ConnectAPI(path2, "GET", nil, nil)
 	if err != nil {
 		// Events might not be available, continue without them
@@ -132,9 +134,8 @@ func (d *BodyBatteryDataWithMethods) Get(day time.Time, c shared.APIClient) (int
 		}
 	}
 
-	var events []garth.BodyBatteryEvent
 	if len(data2) > 0 {
-		if err := json.Unmarshal(data2, &events); err == nil {
+		if events, err := garth.ParseBodyBatteryEvents(data2); err == nil {
 			result.Events = events
 		}
 	}
@@ -187,7 +188,7 @@ func (d *DailyBodyBatteryStress) Get(day time.Time, c shared.APIClient) (interfa
 	}
 
 	// Get Body Battery events
-	path2 := fmt.Sprintf("/wellness-service/wellness/bodyBattery/%s", dateStr)
+	path2 := fmt.Sprintf("/wellness-service/wellness/bodyBattery/events/%s", dateStr)
 	data2, err := c.ConnectAPI(path2, "GET", nil, nil)
 	if err != nil {
 		// Events might not be available, continue without them
@@ -201,9 +202,8 @@ func (d *DailyBodyBatteryStress) Get(day time.Time, c shared.APIClient) (interfa
 		}
 	}
 
-	var events []garth.BodyBatteryEvent
 	if len(data2) > 0 {
-		if err := json.Unmarshal(data2, &events); err == nil {
+		if events, err := garth.ParseBodyBatteryEvents(data2); err == nil {
 			result.Events = events
 		}
 	}
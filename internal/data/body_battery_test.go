@@ -2,6 +2,7 @@ package data
 
 import (
 	"testing"
+	"time"
 
 	garth "github.com/sstent/go-garth/pkg/garth/types"
 	"github.com/stretchr/testify/assert"
@@ -97,3 +98,41 @@ func TestBodyBatteryDataWithMethods(t *testing.T) {
 		assert.Equal(t, 0, singleReadingBb.GetDayChange())
 	})
 }
+
+func TestBodyBatteryTimeline(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) int { return int(start.Add(time.Duration(minutes) * time.Minute).UnixMilli()) }
+	reading := func(minutes, level int) BodyBatteryReading {
+		return BodyBatteryReading{Timestamp: at(minutes), Status: "MEASURED", Level: level, Version: 2}
+	}
+	readings := []BodyBatteryReading{
+		reading(0, 50), reading(3, 55), reading(6, 60), reading(9, 58),
+		reading(12, 50), reading(15, 45), reading(45, 40), reading(48, 42),
+	}
+	events := []garth.BodyBatteryEvent{
+		{EventType: "activity", EventStartTimeGMT: garth.GarminTime{Time: start.Add(10 * time.Minute)}, DurationInMilliseconds: 5 * 60000},
+		{EventType: "sleep", EventStartTimeGMT: garth.GarminTime{Time: start}, DurationInMilliseconds: 7 * 60000},
+	}
+
+	timeline := NewBodyBatteryTimeline(readings, events)
+	assert.Equal(t, 12, timeline.Charged)
+	assert.Equal(t, 15, timeline.Drained, "the change across the gap is not counted")
+	assert.Equal(t, 60, timeline.Peak.Level)
+	assert.Equal(t, start.Add(6*time.Minute), timeline.Peak.Time)
+	assert.Equal(t, 40, timeline.Trough.Level)
+	assert.Equal(t, 10, timeline.SleepCharge)
+	assert.Equal(t, []TimeWindow{{Start: start.Add(15 * time.Minute), End: start.Add(45 * time.Minute)}}, timeline.Gaps)
+
+	impacts := timeline.EventImpacts()
+	assert.Equal(t, "sleep", impacts[0].Event.EventType, "events are ordered by start time")
+	assert.Equal(t, 13, impacts[1].Drained)
+
+	drain := timeline.AttributeDrain([]TimeWindow{{Start: start.Add(8 * time.Minute), End: start.Add(14 * time.Minute)}})
+	assert.Equal(t, DrainAttribution{Activity: 13, Stress: 2, Other: 0}, drain)
+
+	merged := MergeBodyBatteryTimelines(
+		NewBodyBatteryTimeline(readings[:5], events[1:]),
+		NewBodyBatteryTimeline(readings[4:], events),
+	)
+	assert.Equal(t, timeline, merged)
+}
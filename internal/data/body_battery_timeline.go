@@ -0,0 +1,213 @@
+package data
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	garth "github.com/sstent/go-garth/pkg/garth/types"
+)
+
+// bodyBatteryGapThreshold is the longest interval between two readings that
+// is still treated as continuous data
+const bodyBatteryGapThreshold = 15 * time.Minute
+
+// BodyBatteryPoint is a Body Battery level at a point in time
+type BodyBatteryPoint struct {
+	Time   time.Time
+	Level  int
+	Status string
+}
+
+// TimeWindow is a span of time, such as a stressful period
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// contains reports whether a change completed at t happened within the window
+func (w TimeWindow) contains(t time.Time) bool {
+	return t.After(w.Start) && !t.After(w.End)
+}
+
+// BodyBatteryEventImpact is the charge and drain measured during an event
+type BodyBatteryEventImpact struct {
+	Event   garth.BodyBatteryEvent
+	Charged int
+	Drained int
+}
+
+// DrainAttribution splits the drained Body Battery by cause. Drain during
+// an activity event counts as activity, otherwise drain during a stress
+// window counts as stress.
+type DrainAttribution struct {
+	Activity int
+	Stress   int
+	Other    int
+}
+
+// BodyBatteryTimeline is a continuous Body Battery series with its events
+// and derived totals
+type BodyBatteryTimeline struct {
+	Points      []BodyBatteryPoint // Oldest first
+	Events      []garth.BodyBatteryEvent
+	Charged     int // Sum of all increases, excluding gaps
+	Drained     int // Sum of all decreases as a positive number, excluding gaps
+	Peak        BodyBatteryPoint
+	Trough      BodyBatteryPoint
+	SleepCharge int          // Charge gained during sleep events
+	Gaps        []TimeWindow // Intervals without readings
+}
+
+// NewBodyBatteryTimeline builds a timeline from parsed readings, whose
+// timestamps are epoch milliseconds, and the events of the same period
+func NewBodyBatteryTimeline(readings []BodyBatteryReading, events []garth.BodyBatteryEvent) *BodyBatteryTimeline {
+	points := make([]BodyBatteryPoint, 0, len(readings))
+	for _, r := range readings {
+		points = append(points, BodyBatteryPoint{
+			Time:   time.UnixMilli(int64(r.Timestamp)).UTC(),
+			Level:  r.Level,
+			Status: r.Status,
+		})
+	}
+	return newBodyBatteryTimeline(points, events)
+}
+
+// Timeline returns the Body Battery timeline of the day
+func (d *BodyBatteryDataWithMethods) Timeline() *BodyBatteryTimeline {
+	return NewBodyBatteryTimeline(ParseBodyBatteryReadings(d.BodyBatteryValuesArray), d.Events)
+}
+
+// MergeBodyBatteryTimelines joins the timelines of consecutive days into one,
+// dropping readings and events that appear in more than one of them
+func MergeBodyBatteryTimelines(timelines ...*BodyBatteryTimeline) *BodyBatteryTimeline {
+	var points []BodyBatteryPoint
+	var events []garth.BodyBatteryEvent
+	seenPoints := make(map[time.Time]bool)
+	seenEvents := make(map[string]bool)
+	for _, t := range timelines {
+		if t == nil {
+			continue
+		}
+		for _, p := range t.Points {
+			if !seenPoints[p.Time] {
+				seenPoints[p.Time] = true
+				points = append(points, p)
+			}
+		}
+		for _, e := range t.Events {
+			key := e.EventType + e.EventStartTimeGMT.String()
+			if !seenEvents[key] {
+				seenEvents[key] = true
+				events = append(events, e)
+			}
+		}
+	}
+	return newBodyBatteryTimeline(points, events)
+}
+
+func newBodyBatteryTimeline(points []BodyBatteryPoint, events []garth.BodyBatteryEvent) *BodyBatteryTimeline {
+	sort.Slice(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].EventStartTimeGMT.Before(events[j].EventStartTimeGMT.Time)
+	})
+	t := &BodyBatteryTimeline{Points: points, Events: events}
+	if len(points) == 0 {
+		return t
+	}
+
+	t.Peak, t.Trough = points[0], points[0]
+	for i, p := range points {
+		if p.Level > t.Peak.Level {
+			t.Peak = p
+		}
+		if p.Level < t.Trough.Level {
+			t.Trough = p
+		}
+		if i > 0 && p.Time.Sub(points[i-1].Time) > bodyBatteryGapThreshold {
+			t.Gaps = append(t.Gaps, TimeWindow{Start: points[i-1].Time, End: p.Time})
+		}
+	}
+	t.forEachChange(func(_ time.Time, delta int) {
+		if delta > 0 {
+			t.Charged += delta
+		} else {
+			t.Drained -= delta
+		}
+	})
+
+	for _, impact := range t.EventImpacts() {
+		if strings.EqualFold(impact.Event.EventType, "sleep") {
+			t.SleepCharge += impact.Charged
+		}
+	}
+	return t
+}
+
+// forEachChange calls fn with the level change between each pair of
+// consecutive readings and the time the change was completed. Changes
+// across data gaps are skipped.
+func (t *BodyBatteryTimeline) forEachChange(fn func(at time.Time, delta int)) {
+	for i := 1; i < len(t.Points); i++ {
+		prev, p := t.Points[i-1], t.Points[i]
+		if p.Time.Sub(prev.Time) > bodyBatteryGapThreshold {
+			continue
+		}
+		fn(p.Time, p.Level-prev.Level)
+	}
+}
+
+// EventImpacts returns the charge and drain measured during each event
+func (t *BodyBatteryTimeline) EventImpacts() []BodyBatteryEventImpact {
+	impacts := make([]BodyBatteryEventImpact, len(t.Events))
+	for i, e := range t.Events {
+		impacts[i].Event = e
+		window := TimeWindow{Start: e.EventStartTimeGMT.Time, End: e.End()}
+		t.forEachChange(func(at time.Time, delta int) {
+			if !window.contains(at) {
+				return
+			}
+			if delta > 0 {
+				impacts[i].Charged += delta
+			} else {
+				impacts[i].Drained -= delta
+			}
+		})
+	}
+	return impacts
+}
+
+// AttributeDrain splits the drained Body Battery between activity events,
+// the given stress windows and everything else
+func (t *BodyBatteryTimeline) AttributeDrain(stress []TimeWindow) DrainAttribution {
+	var activities []TimeWindow
+	for _, e := range t.Events {
+		if strings.EqualFold(e.EventType, "activity") {
+			activities = append(activities, TimeWindow{Start: e.EventStartTimeGMT.Time, End: e.End()})
+		}
+	}
+	within := func(windows []TimeWindow, at time.Time) bool {
+		for _, w := range windows {
+			if w.contains(at) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var a DrainAttribution
+	t.forEachChange(func(at time.Time, delta int) {
+		if delta >= 0 {
+			return
+		}
+		switch {
+		case within(activities, at):
+			a.Activity -= delta
+		case within(stress, at):
+			a.Stress -= delta
+		default:
+			a.Other -= delta
+		}
+	})
+	return a
+}
@@ -3,6 +3,7 @@ package data
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"sort"
 	"time"
 
@@ -17,11 +18,11 @@ type DailyHRVDataWithMethods struct {
 
 // Get implements the Data interface for DailyHRVData
 func (h *DailyHRVDataWithMethods) Get(day time.Time, c shared.APIClient) (interface{}, error) {
-	dateStr := day.Format("2006-01-02")
-	path := fmt.Sprintf("/wellness-service/wellness/dailyHrvData/%s?date=%s",
-		c.GetUsername(), dateStr)
+	path := fmt.Sprintf("/wellness-service/wellness/dailyHrvData/%s", c.GetUsername())
+	params := url.Values{}
+	params.Set("date", day.Format("2006-01-02"))
 
-	data, err := c.ConnectAPI(path, "GET", nil, nil)
+	data, err := c.ConnectAPI(path, "GET", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get HRV data: %w", err)
 	}
@@ -44,7 +45,10 @@ func (h *DailyHRVDataWithMethods) Get(day time.Time, c shared.APIClient) (interf
 	return &DailyHRVDataWithMethods{DailyHRVData: response.HRVSummary}, nil
 }
 
-// ParseHRVReadings converts body battery values array to structured readings
+// ParseHRVReadings converts HRV values arrays to structured readings.
+// Accepts mixed numeric types (int, int64, float64, json.Number) like
+// ParseBodyBatteryReadings; rows without a timestamp are skipped and other
+// missing values are left zero.
 func ParseHRVReadings(valuesArray [][]any) []garth.HRVReading {
 	readings := make([]garth.HRVReading, 0, len(valuesArray))
 	for _, values := range valuesArray {
@@ -52,13 +56,15 @@ func ParseHRVReadings(valuesArray [][]any) []garth.HRVReading {
 			continue
 		}
 
-		// Extract values with type assertions
-		timestamp, _ := values[0].(int)
-		stressLevel, _ := values[1].(int)
-		heartRate, _ := values[2].(int)
-		rrInterval, _ := values[3].(int)
+		timestamp, ok := toInt(values[0])
+		if !ok {
+			continue
+		}
+		stressLevel, _ := toInt(values[1])
+		heartRate, _ := toInt(values[2])
+		rrInterval, _ := toInt(values[3])
 		status, _ := values[4].(string)
-		signalQuality, _ := values[5].(float64)
+		signalQuality, _ := toFloat64(values[5])
 
 		readings = append(readings, garth.HRVReading{
 			Timestamp:     timestamp,
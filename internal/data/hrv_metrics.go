@@ -0,0 +1,133 @@
+package data
+
+import (
+	"math"
+
+	garth "github.com/sstent/go-garth/pkg/garth/types"
+)
+
+// HRV statuses, as reported by Garmin and computed by HRVStatusFor
+const (
+	HRVStatusBalanced   = "BALANCED"
+	HRVStatusUnbalanced = "UNBALANCED"
+	HRVStatusLow        = "LOW"
+	HRVStatusNone       = "NONE"
+)
+
+// Artifact filter limits. RR intervals outside the physiological range, or
+// differing from the previous accepted interval by more than the relative
+// limit, are treated as artifacts.
+const (
+	minRRInterval      = 300  // Milliseconds, 200 bpm
+	maxRRInterval      = 2000 // Milliseconds, 30 bpm
+	maxRRRelativeDelta = 0.2
+)
+
+// HRVMetrics holds time-domain HRV metrics derived from RR intervals
+type HRVMetrics struct {
+	Intervals    int     // Accepted RR intervals
+	Artifacts    int     // Rejected RR intervals
+	MeanRR       float64 // Milliseconds
+	MeanHR       float64 // Beats per minute
+	RMSSD        float64 // Milliseconds
+	SDNN         float64 // Milliseconds
+	PNN50        float64 // Percent of successive differences above 50 ms
+	Status       string  // From RMSSD and the baseline bands
+	GarminStatus string  // As reported by Garmin
+}
+
+// FilterRRIntervals returns the RR intervals of the readings in milliseconds
+// with artifacts removed, and the number of intervals rejected. A zero entry
+// marks each rejected interval, so differences are never taken across one.
+func FilterRRIntervals(readings []garth.HRVReading) (intervals []float64, artifacts int) {
+	var previous float64
+	for _, r := range readings {
+		rr := float64(r.RRInterval)
+		if rr < minRRInterval || rr > maxRRInterval ||
+			(previous > 0 && math.Abs(rr-previous) > maxRRRelativeDelta*previous) {
+			artifacts++
+			if len(intervals) > 0 && intervals[len(intervals)-1] != 0 {
+				intervals = append(intervals, 0)
+			}
+			previous = 0
+			continue
+		}
+		intervals = append(intervals, rr)
+		previous = rr
+	}
+	if len(intervals) > 0 && intervals[len(intervals)-1] == 0 {
+		intervals = intervals[:len(intervals)-1]
+	}
+	return intervals, artifacts
+}
+
+// ComputeHRVMetrics computes time-domain HRV metrics from the RR intervals of
+// the readings after artifact filtering
+func ComputeHRVMetrics(readings []garth.HRVReading) HRVMetrics {
+	intervals, artifacts := FilterRRIntervals(readings)
+	m := HRVMetrics{Artifacts: artifacts}
+
+	var sum float64
+	for _, rr := range intervals {
+		if rr > 0 {
+			sum += rr
+			m.Intervals++
+		}
+	}
+	if m.Intervals == 0 {
+		return m
+	}
+	m.MeanRR = sum / float64(m.Intervals)
+	m.MeanHR = 60000 / m.MeanRR
+
+	var variance float64
+	for _, rr := range intervals {
+		if rr > 0 {
+			variance += (rr - m.MeanRR) * (rr - m.MeanRR)
+		}
+	}
+	m.SDNN = math.Sqrt(variance / float64(m.Intervals))
+
+	var squares float64
+	var diffs, over50 int
+	for i := 1; i < len(intervals); i++ {
+		if intervals[i] == 0 || intervals[i-1] == 0 {
+			continue
+		}
+		d := intervals[i] - intervals[i-1]
+		squares += d * d
+		diffs++
+		if math.Abs(d) > 50 {
+			over50++
+		}
+	}
+	if diffs > 0 {
+		m.RMSSD = math.Sqrt(squares / float64(diffs))
+		m.PNN50 = 100 * float64(over50) / float64(diffs)
+	}
+	return m
+}
+
+// HRVStatusFor classifies an HRV value in milliseconds against the baseline
+// bands: balanced within the balanced band, low at or below the low band's
+// upper bound, and unbalanced otherwise
+func HRVStatusFor(value float64, baseline garth.HRVBaseline) string {
+	switch {
+	case value <= 0 || baseline.BalancedUpper == 0:
+		return HRVStatusNone
+	case value <= float64(baseline.LowUpper):
+		return HRVStatusLow
+	case value >= float64(baseline.BalancedLow) && value <= float64(baseline.BalancedUpper):
+		return HRVStatusBalanced
+	}
+	return HRVStatusUnbalanced
+}
+
+// Metrics computes HRV metrics from the readings, with a status from the
+// baseline bands alongside Garmin's
+func (h *DailyHRVDataWithMethods) Metrics() HRVMetrics {
+	m := ComputeHRVMetrics(h.HRVReadings)
+	m.Status = HRVStatusFor(m.RMSSD, h.Baseline)
+	m.GarminStatus = h.DailyHRVData.Status
+	return m
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	garth "github.com/sstent/go-garth/pkg/garth/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHRVReadings(t *testing.T) {
+	var values [][]any
+	require.NoError(t, json.Unmarshal([]byte(`[
+		[1740873600000, 20, 62, 968, "MEASURED", 0.9],
+		[1740873000000, 25, 60, 1000, "MEASURED", 1],
+		[null, 25, 60, 1000, "MEASURED", 1],
+		[1740874200000, 25]
+	]`), &values))
+
+	readings := ParseHRVReadings(values)
+	require.Len(t, readings, 2)
+	assert.Equal(t, garth.HRVReading{
+		Timestamp: 1740873000000, StressLevel: 25, HeartRate: 60, RRInterval: 1000,
+		Status: "MEASURED", SignalQuality: 1,
+	}, readings[0])
+	assert.Equal(t, 968, readings[1].RRInterval)
+	assert.InDelta(t, 0.9, readings[1].SignalQuality, 1e-9)
+}
+
+func TestComputeHRVMetrics(t *testing.T) {
+	rr := func(intervals ...int) []garth.HRVReading {
+		readings := make([]garth.HRVReading, len(intervals))
+		for i, v := range intervals {
+			readings[i] = garth.HRVReading{RRInterval: v}
+		}
+		return readings
+	}
+
+	m := ComputeHRVMetrics(rr(1000, 1060, 1000, 1060))
+	assert.Equal(t, 4, m.Intervals)
+	assert.Equal(t, 0, m.Artifacts)
+	assert.InDelta(t, 1030, m.MeanRR, 1e-9)
+	assert.InDelta(t, 60000.0/1030, m.MeanHR, 1e-9)
+	assert.InDelta(t, 60, m.RMSSD, 1e-9)
+	assert.InDelta(t, 30, m.SDNN, 1e-9)
+	assert.InDelta(t, 100, m.PNN50, 1e-9)
+
+	// Out of range and ectopic beats are dropped, and no difference is taken
+	// across them
+	m = ComputeHRVMetrics(rr(1000, 250, 1000, 1040, 1400, 1040, 2500))
+	assert.Equal(t, 4, m.Intervals)
+	assert.Equal(t, 3, m.Artifacts)
+	assert.InDelta(t, 40, m.RMSSD, 1e-9)
+	assert.InDelta(t, 0, m.PNN50, 1e-9)
+
+	assert.Equal(t, HRVMetrics{}, ComputeHRVMetrics(nil))
+}
+
+func TestHRVStatusFor(t *testing.T) {
+	baseline := garth.HRVBaseline{LowUpper: 40, BalancedLow: 50, BalancedUpper: 70}
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0, HRVStatusNone},
+		{35, HRVStatusLow},
+		{40, HRVStatusLow},
+		{45, HRVStatusUnbalanced},
+		{50, HRVStatusBalanced},
+		{70, HRVStatusBalanced},
+		{80, HRVStatusUnbalanced},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, HRVStatusFor(tt.value, baseline), "value %v", tt.value)
+	}
+	assert.Equal(t, HRVStatusNone, HRVStatusFor(60, garth.HRVBaseline{}))
+
+	hrv := &DailyHRVDataWithMethods{DailyHRVData: garth.DailyHRVData{
+		Status:      "BALANCED",
+		Baseline:    baseline,
+		HRVReadings: []garth.HRVReading{{RRInterval: 1000}, {RRInterval: 1060}},
+	}}
+	m := hrv.Metrics()
+	assert.Equal(t, HRVStatusBalanced, m.Status)
+	assert.Equal(t, "BALANCED", m.GarminStatus)
+}
@@ -3,6 +3,7 @@ package data
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
 	garth "github.com/sstent/go-garth/pkg/garth/types"
@@ -15,11 +16,12 @@ type DetailedSleepDataWithMethods struct {
 }
 
 func (d *DetailedSleepDataWithMethods) Get(day time.Time, c shared.APIClient) (interface{}, error) {
-	dateStr := day.Format("2006-01-02")
-	path := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s?date=%s&nonSleepBufferMinutes=60",
-		c.GetUsername(), dateStr)
+	path := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s", c.GetUsername())
+	params := url.Values{}
+	params.Set("date", day.Format("2006-01-02"))
+	params.Set("nonSleepBufferMinutes", "60")
 
-	data, err := c.ConnectAPI(path, "GET", nil, nil)
+	data, err := c.ConnectAPI(path, "GET", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get detailed sleep data: %w", err)
 	}
@@ -29,16 +31,16 @@ func (d *DetailedSleepDataWithMethods) Get(day time.Time, c shared.APIClient) (i
 	}
 
 	var response struct {
-		DailySleepDTO                       *garth.DetailedSleepData `json:"dailySleepDTO"`
-		SleepMovement                       []garth.SleepMovement    `json:"sleepMovement"`
-		RemSleepData                        bool                     `json:"remSleepData"`
-		SleepLevels                         []garth.SleepLevel       `json:"sleepLevels"`
-		SleepRestlessMoments                []interface{}            `json:"sleepRestlessMoments"`
-		RestlessMomentsCount                int                      `json:"restlessMomentsCount"`
-		WellnessSpO2SleepSummaryDTO         interface{}              `json:"wellnessSpO2SleepSummaryDTO"`
-		WellnessEpochSPO2DataDTOList        []interface{}            `json:"wellnessEpochSPO2DataDTOList"`
-		WellnessEpochRespirationDataDTOList []interface{}            `json:"wellnessEpochRespirationDataDTOList"`
-		SleepStress                         interface{}              `json:"sleepStress"`
+		DailySleepDTO                       *garth.DetailedSleepData    `json:"dailySleepDTO"`
+		SleepMovement                       []garth.SleepMovement       `json:"sleepMovement"`
+		RemSleepData                        bool                        `json:"remSleepData"`
+		SleepLevels                         []garth.SleepLevel          `json:"sleepLevels"`
+		SleepRestlessMoments                []garth.SleepRestlessMoment `json:"sleepRestlessMoments"`
+		RestlessMomentsCount                int                         `json:"restlessMomentsCount"`
+		WellnessSpO2SleepSummaryDTO         *garth.SpO2SleepSummary     `json:"wellnessSpO2SleepSummaryDTO"`
+		WellnessEpochSPO2DataDTOList        []garth.SpO2Epoch           `json:"wellnessEpochSPO2DataDTOList"`
+		WellnessEpochRespirationDataDTOList []garth.RespirationEpoch    `json:"wellnessEpochRespirationDataDTOList"`
+		SleepStress                         []garth.SleepStressEpoch    `json:"sleepStress"`
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
@@ -52,13 +54,19 @@ func (d *DetailedSleepDataWithMethods) Get(day time.Time, c shared.APIClient) (i
 	// Populate additional data
 	response.DailySleepDTO.SleepMovement = response.SleepMovement
 	response.DailySleepDTO.SleepLevels = response.SleepLevels
+	response.DailySleepDTO.SpO2Summary = response.WellnessSpO2SleepSummaryDTO
+	response.DailySleepDTO.SpO2Epochs = response.WellnessEpochSPO2DataDTOList
+	response.DailySleepDTO.RespirationEpochs = response.WellnessEpochRespirationDataDTOList
+	response.DailySleepDTO.StressEpochs = response.SleepStress
+	response.DailySleepDTO.RestlessMoments = response.SleepRestlessMoments
+	response.DailySleepDTO.RestlessCount = response.RestlessMomentsCount
 
 	return &DetailedSleepDataWithMethods{DetailedSleepData: *response.DailySleepDTO}, nil
 }
 
 // GetSleepEfficiency calculates sleep efficiency percentage
 func (d *DetailedSleepDataWithMethods) GetSleepEfficiency() float64 {
-	totalTime := d.DetailedSleepData.SleepEndTimestampGMT.Sub(d.DetailedSleepData.SleepStartTimestampGMT).Seconds()
+	totalTime := d.DetailedSleepData.SleepEndTimestampGMT.Sub(d.DetailedSleepData.SleepStartTimestampGMT.Time).Seconds()
 	sleepTime := float64(d.DetailedSleepData.DeepSleepSeconds + d.DetailedSleepData.LightSleepSeconds + d.DetailedSleepData.RemSleepSeconds)
 	if totalTime == 0 {
 		return 0
@@ -0,0 +1,216 @@
+package data
+
+import (
+	"sort"
+	"time"
+
+	garth "github.com/sstent/go-garth/pkg/garth/types"
+)
+
+// SleepStage is a stage of a hypnogram
+type SleepStage string
+
+// Sleep stages as reported by Garmin
+const (
+	SleepStageDeep         SleepStage = "deep"
+	SleepStageLight        SleepStage = "light"
+	SleepStageREM          SleepStage = "rem"
+	SleepStageAwake        SleepStage = "awake"
+	SleepStageUnmeasurable SleepStage = "unmeasurable"
+)
+
+// Asleep reports whether the stage is a sleep stage
+func (s SleepStage) Asleep() bool {
+	return s == SleepStageDeep || s == SleepStageLight || s == SleepStageREM
+}
+
+// remEpisodeGap is the longest interruption that still joins two REM
+// segments into one episode
+const remEpisodeGap = 5 * time.Minute
+
+// SleepSegment is a contiguous span of one sleep stage
+type SleepSegment struct {
+	Stage SleepStage
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the length of the segment
+func (s SleepSegment) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// SleepCycle is a sleep cycle, from sleep onset or the end of the previous
+// REM episode to the end of the next one
+type SleepCycle struct {
+	Start    time.Time
+	REMStart time.Time
+	End      time.Time
+}
+
+// Duration returns the length of the cycle
+func (c SleepCycle) Duration() time.Duration {
+	return c.End.Sub(c.Start)
+}
+
+// Hypnogram is the stage-by-stage structure of a night's sleep. Times are
+// in the sleeper's time zone, derived from the local sleep timestamps.
+type Hypnogram struct {
+	Location        *time.Location
+	SleepStart      time.Time
+	SleepEnd        time.Time
+	Segments        []SleepSegment // Oldest first; adjacent segments never share a stage
+	RestlessMoments []time.Time
+}
+
+// NewHypnogram builds the hypnogram of detailed sleep data
+func NewHypnogram(d *garth.DetailedSleepData) *Hypnogram {
+	offset := d.SleepStartTimestampLocal.Sub(d.SleepStartTimestampGMT.Time)
+	loc := time.UTC
+	if !d.SleepStartTimestampLocal.IsZero() && !d.SleepStartTimestampGMT.IsZero() && offset != 0 {
+		loc = time.FixedZone("", int(offset.Seconds()))
+	}
+	h := &Hypnogram{Location: loc}
+	if !d.SleepStartTimestampGMT.IsZero() {
+		h.SleepStart = d.SleepStartTimestampGMT.In(loc)
+	}
+	if !d.SleepEndTimestampGMT.IsZero() {
+		h.SleepEnd = d.SleepEndTimestampGMT.In(loc)
+	}
+
+	levels := append([]garth.SleepLevel(nil), d.SleepLevels...)
+	sort.Slice(levels, func(i, j int) bool { return levels[i].StartGMT.Before(levels[j].StartGMT.Time) })
+	for _, l := range levels {
+		seg := SleepSegment{
+			Stage: SleepStage(l.Stage()),
+			Start: l.StartGMT.In(loc),
+			End:   l.EndGMT.In(loc),
+		}
+		if n := len(h.Segments); n > 0 && h.Segments[n-1].Stage == seg.Stage && !seg.Start.After(h.Segments[n-1].End) {
+			h.Segments[n-1].End = seg.End
+			continue
+		}
+		h.Segments = append(h.Segments, seg)
+	}
+
+	for _, m := range d.RestlessMoments {
+		h.RestlessMoments = append(h.RestlessMoments, m.StartGMT.In(loc))
+	}
+	sort.Slice(h.RestlessMoments, func(i, j int) bool { return h.RestlessMoments[i].Before(h.RestlessMoments[j]) })
+	return h
+}
+
+// Hypnogram returns the hypnogram of the night
+func (d *DetailedSleepDataWithMethods) Hypnogram() *Hypnogram {
+	return NewHypnogram(&d.DetailedSleepData)
+}
+
+// sleepBounds returns the indexes of the first and last asleep segments, or
+// -1 when there are none
+func (h *Hypnogram) sleepBounds() (first, last int) {
+	first, last = -1, -1
+	for i, s := range h.Segments {
+		if s.Stage.Asleep() {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	return first, last
+}
+
+// Onset returns when the sleeper first fell asleep, or the zero time
+func (h *Hypnogram) Onset() time.Time {
+	first, _ := h.sleepBounds()
+	if first < 0 {
+		return time.Time{}
+	}
+	return h.Segments[first].Start
+}
+
+// FinalAwakening returns when the last sleep segment ended, or the zero time
+func (h *Hypnogram) FinalAwakening() time.Time {
+	_, last := h.sleepBounds()
+	if last < 0 {
+		return time.Time{}
+	}
+	return h.Segments[last].End
+}
+
+// Latency returns the time from the start of the recording to sleep onset
+func (h *Hypnogram) Latency() time.Duration {
+	first, _ := h.sleepBounds()
+	if first <= 0 {
+		return 0
+	}
+	return h.Segments[first].Start.Sub(h.Segments[0].Start)
+}
+
+// WASO returns the wake after sleep onset: time awake between onset and the
+// final awakening
+func (h *Hypnogram) WASO() time.Duration {
+	var waso time.Duration
+	h.eachAwakening(func(s SleepSegment) { waso += s.Duration() })
+	return waso
+}
+
+// Awakenings returns the number of times the sleeper woke between onset and
+// the final awakening
+func (h *Hypnogram) Awakenings() int {
+	n := 0
+	h.eachAwakening(func(SleepSegment) { n++ })
+	return n
+}
+
+func (h *Hypnogram) eachAwakening(fn func(SleepSegment)) {
+	first, last := h.sleepBounds()
+	if first < 0 {
+		return
+	}
+	for _, s := range h.Segments[first : last+1] {
+		if s.Stage == SleepStageAwake {
+			fn(s)
+		}
+	}
+}
+
+// Transitions returns the number of stage changes
+func (h *Hypnogram) Transitions() int {
+	return max(len(h.Segments)-1, 0)
+}
+
+// StageDurations returns the time spent in each stage
+func (h *Hypnogram) StageDurations() map[SleepStage]time.Duration {
+	durations := make(map[SleepStage]time.Duration)
+	for _, s := range h.Segments {
+		durations[s.Stage] += s.Duration()
+	}
+	return durations
+}
+
+// Cycles detects sleep cycles, each ending with a REM episode. REM segments
+// interrupted for at most five minutes form one episode.
+func (h *Hypnogram) Cycles() []SleepCycle {
+	var cycles []SleepCycle
+	start := h.Onset()
+	var episode *SleepCycle
+	for _, s := range h.Segments {
+		if s.Stage != SleepStageREM {
+			continue
+		}
+		if episode != nil && s.Start.Sub(episode.End) <= remEpisodeGap {
+			episode.End = s.End
+			continue
+		}
+		if episode != nil {
+			cycles = append(cycles, *episode)
+			start = episode.End
+		}
+		episode = &SleepCycle{Start: start, REMStart: s.Start, End: s.End}
+	}
+	if episode != nil {
+		cycles = append(cycles, *episode)
+	}
+	return cycles
+}
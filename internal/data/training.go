@@ -16,7 +16,7 @@ type TrainingStatusWithMethods struct {
 
 func (t *TrainingStatusWithMethods) Get(day time.Time, c shared.APIClient) (interface{}, error) {
 	dateStr := day.Format("2006-01-02")
-	path := fmt.Sprintf("/metrics-service/metrics/trainingStatus/%s", dateStr)
+	path := fmt.Sprintf("/metrics-service/metrics/trainingstatus/aggregated/%s", dateStr)
 
 	data, err := c.ConnectAPI(path, "GET", nil, nil)
 	if err != nil {
@@ -27,12 +27,15 @@ func (t *TrainingStatusWithMethods) Get(day time.Time, c shared.APIClient) (inte
 		return nil, nil
 	}
 
-	var result garth.TrainingStatus
-	if err := json.Unmarshal(data, &result); err != nil {
+	result, err := garth.ParseTrainingStatus(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse training status: %w", err)
 	}
+	if result == nil {
+		return nil, nil
+	}
 
-	return &TrainingStatusWithMethods{TrainingStatus: result}, nil
+	return &TrainingStatusWithMethods{TrainingStatus: *result}, nil
 }
 
 // TrainingLoadWithMethods embeds garth.TrainingLoad and adds methods
@@ -42,8 +45,7 @@ type TrainingLoadWithMethods struct {
 
 func (t *TrainingLoadWithMethods) Get(day time.Time, c shared.APIClient) (interface{}, error) {
 	dateStr := day.Format("2006-01-02")
-	endDate := day.AddDate(0, 0, 6).Format("2006-01-02") // Get week of data
-	path := fmt.Sprintf("/metrics-service/metrics/trainingLoad/%s/%s", dateStr, endDate)
+	path := fmt.Sprintf("/metrics-service/metrics/trainingLoad/%s/%s", dateStr, dateStr)
 
 	data, err := c.ConnectAPI(path, "GET", nil, nil)
 	if err != nil {
@@ -59,9 +61,10 @@ func (t *TrainingLoadWithMethods) Get(day time.Time, c shared.APIClient) (interf
 		return nil, fmt.Errorf("failed to parse training load: %w", err)
 	}
 
-	if len(results) == 0 {
-		return nil, nil
+	for _, result := range results {
+		if result.CalendarDate.Format("2006-01-02") == dateStr {
+			return &TrainingLoadWithMethods{TrainingLoad: result}, nil
+		}
 	}
-
-	return &TrainingLoadWithMethods{TrainingLoad: results[0]}, nil
+	return nil, nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sstent/go-garth/pkg/garth/client"
 	garth "github.com/sstent/go-garth/pkg/garth/types"
 	shared "github.com/sstent/go-garth/shared/interfaces"
 )
@@ -20,9 +21,18 @@ func NewVO2MaxData() *VO2MaxData {
 	return vo2
 }
 
-// get implements the specific VO2 max data retrieval logic
+// get returns the VO2 max profile as of day: the latest running and cycling
+// estimates from the maxmet history, or the user settings values if the past
+// year has none
 func (v *VO2MaxData) get(day time.Time, c shared.APIClient) (interface{}, error) {
-	// Primary approach: Get from user settings (most reliable)
+	history, err := client.GetVO2MaxHistory(c, day.AddDate(0, 0, -client.VO2MaxLookback), day)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > 0 {
+		return garth.NewVO2MaxProfile(history), nil
+	}
+
 	settings, err := c.GetUserSettings()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user settings: %w", err)
@@ -58,21 +68,20 @@ func (v *VO2MaxData) get(day time.Time, c shared.APIClient) (interface{}, error)
 	return vo2Profile, nil
 }
 
-// List implements concurrent fetching for multiple days
-// Note: VO2 max typically doesn't change daily, so this returns the same values
+// List returns the VO2 max estimates made in the days up to end, newest
+// first, as *garth.VO2MaxData. VO2 max is only re-estimated after qualifying
+// activities, so days without a new estimate are omitted. The history is
+// fetched in a single request, so maxWorkers is unused.
 func (v *VO2MaxData) List(end time.Time, days int, c shared.APIClient, maxWorkers int) ([]interface{}, []error) {
-	// For VO2 max, we want current values from user settings
-	vo2Data, err := v.get(end, c)
+	history, err := client.GetVO2MaxHistory(c, end.AddDate(0, 0, 1-days), end)
 	if err != nil {
 		return nil, []error{err}
 	}
 
-	// Return the same VO2 max data for all requested days
-	results := make([]interface{}, days)
-	for i := 0; i < days; i++ {
-		results[i] = vo2Data
+	results := make([]interface{}, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		results = append(results, &history[i])
 	}
-
 	return results, nil
 }
 
@@ -1,14 +1,19 @@
 package data
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/sstent/go-garth/pkg/garth/client"
 	garth "github.com/sstent/go-garth/pkg/garth/types"
 	"github.com/sstent/go-garth/shared/interfaces"
 	"github.com/sstent/go-garth/shared/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVO2MaxData_Get(t *testing.T) {
@@ -69,3 +74,50 @@ func TestVO2MaxData_Get(t *testing.T) {
 	assert.Equal(t, 50.0, profile.Cycling.Value)
 	assert.Equal(t, "cycling", profile.Cycling.ActivityType)
 }
+
+func TestVO2MaxData_List(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`[
+			{"userId": 12345,
+			 "generic": {"calendarDate": "2025-03-01", "vo2MaxPreciseValue": 51.6, "vo2MaxValue": 52, "fitnessAge": 31},
+			 "cycling": null, "heatAltitudeAcclimation": null},
+			{"userId": 12345,
+			 "generic": {"calendarDate": "2025-03-05", "vo2MaxPreciseValue": 52.1, "vo2MaxValue": 52, "fitnessAge": 30},
+			 "cycling": {"calendarDate": "2025-03-05", "vo2MaxPreciseValue": 55.4, "vo2MaxValue": 55},
+			 "heatAltitudeAcclimation": {"calendarDate": "2025-03-05", "heatAcclimationPercentage": 40, "heatTrend": "ACCLIMATIZING"}},
+			{"userId": 12345, "generic": null, "cycling": null, "heatAltitudeAcclimation": null}]`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	c, err := client.NewClient(u.Host)
+	require.NoError(t, err)
+	c.HTTPClient = server.Client()
+	c.AuthToken = "Bearer testtoken"
+
+	end := time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC)
+	results, errs := NewVO2MaxData().List(end, 7, c, 4)
+	require.Empty(t, errs)
+	assert.Equal(t, "/metrics-service/metrics/maxmet/daily/2025-03-01/2025-03-07", path)
+	require.Len(t, results, 2)
+
+	latest := results[0].(*garth.VO2MaxData)
+	assert.Equal(t, 5, latest.Date.Day())
+	assert.Equal(t, 52.1, *latest.VO2MaxRunning)
+	assert.Equal(t, 55.4, *latest.VO2MaxCycling)
+	assert.Equal(t, 30, *latest.FitnessAge)
+	assert.Equal(t, 40, latest.HeatAltitudeAcclimation.HeatAcclimationPercentage)
+	assert.Equal(t, 51.6, *results[1].(*garth.VO2MaxData).VO2MaxRunning)
+
+	result, err := NewVO2MaxData().Get(end, c)
+	require.NoError(t, err)
+	profile := result.(*garth.VO2MaxProfile)
+	assert.Equal(t, 12345, profile.UserProfilePK)
+	assert.Equal(t, 52.1, profile.Running.Value)
+	assert.Equal(t, "maxmet", profile.Running.Source)
+	assert.Equal(t, 55.4, profile.Cycling.Value)
+	assert.Equal(t, 30, *profile.FitnessAge)
+	assert.Equal(t, 5, profile.LastUpdated.Day())
+}
@@ -3,12 +3,15 @@ package data
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"sort"
 	"time"
 
 	shared "github.com/sstent/go-garth/shared/interfaces"
 )
 
-// WeightData represents weight data
+// WeightData represents weight data. Masses are converted to kilograms by
+// Get and List.
 type WeightData struct {
 	Date       time.Time `json:"calendarDate"`
 	Weight     float64   `json:"weight"` // in grams
@@ -16,7 +19,10 @@ type WeightData struct {
 	BodyFat    float64   `json:"bodyFat"`
 	BoneMass   float64   `json:"boneMass"`
 	MuscleMass float64   `json:"muscleMass"`
-	Hydration  float64   `json:"hydration"`
+	Hydration  float64   `json:"hydration"` // body water, in percent
+	SamplePK   int64     `json:"samplePk"`
+	Timestamp  time.Time `json:"timestamp"` // measurement time, UTC
+	SourceType string    `json:"sourceType"`
 }
 
 // WeightDataWithMethods embeds WeightData and adds methods
@@ -35,46 +41,131 @@ func (w *WeightDataWithMethods) Validate() error {
 	return nil
 }
 
-// Get implements the Data interface for WeightData
+// Get returns the latest weigh-in of a day, or nil when there is none
 func (w *WeightDataWithMethods) Get(day time.Time, c shared.APIClient) (any, error) {
-	startDate := day.Format("2006-01-02")
-	endDate := day.Format("2006-01-02")
-	path := fmt.Sprintf("/weight-service/weight/dateRange?startDate=%s&endDate=%s",
-		startDate, endDate)
+	entries, err := fetchWeighIns(day, day, c)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return entries[len(entries)-1], nil
+}
 
-	data, err := c.ConnectAPI(path, "GET", nil, nil)
+// List returns every weigh-in of the days up to end, newest first, as
+// *WeightDataWithMethods. The range is fetched in a single request, so
+// maxWorkers is unused.
+func (w *WeightDataWithMethods) List(end time.Time, days int, c shared.APIClient, maxWorkers int) ([]any, []error) {
+	entries, err := fetchWeighIns(end.AddDate(0, 0, 1-days), end, c)
 	if err != nil {
-		return nil, err
+		return nil, []error{err}
 	}
 
-	if len(data) == 0 {
-		return nil, nil
+	results := make([]any, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		results = append(results, entries[i])
 	}
+	return results, nil
+}
 
-	var response struct {
-		WeightList []WeightData `json:"weightList"`
+// fetchWeighIns retrieves the weigh-ins between two dates, oldest first, with
+// masses converted from grams to kilograms
+func fetchWeighIns(start, end time.Time, c shared.APIClient) ([]*WeightDataWithMethods, error) {
+	path := fmt.Sprintf("/weight-service/weight/range/%s/%s",
+		start.Format("2006-01-02"), end.Format("2006-01-02"))
+	params := url.Values{}
+	params.Set("includeAll", "true")
+
+	data, err := c.ConnectAPI(path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weight data: %w", err)
 	}
-	if err := json.Unmarshal(data, &response); err != nil {
-		return nil, err
+
+	dtos, err := ParseWeighIns(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse weight data: %w", err)
 	}
 
-	if len(response.WeightList) == 0 {
-		return nil, nil
+	kg := func(grams *float64) float64 {
+		if grams == nil {
+			return 0
+		}
+		return *grams / 1000
+	}
+	value := func(v *float64) float64 {
+		if v == nil {
+			return 0
+		}
+		return *v
 	}
+	entries := make([]*WeightDataWithMethods, 0, len(dtos))
+	for _, d := range dtos {
+		date, _ := time.Parse("2006-01-02", d.CalendarDate)
+		entries = append(entries, &WeightDataWithMethods{WeightData: WeightData{
+			Date:       date,
+			Weight:     d.Weight / 1000,
+			BMI:        value(d.BMI),
+			BodyFat:    value(d.BodyFat),
+			BoneMass:   kg(d.BoneMass),
+			MuscleMass: kg(d.MuscleMass),
+			Hydration:  value(d.BodyWater),
+			SamplePK:   d.SamplePK,
+			Timestamp:  d.Time(),
+			SourceType: d.SourceType,
+		}})
+	}
+	return entries, nil
+}
 
-	weightData := response.WeightList[0]
-	// Convert grams to kilograms
-	weightData.Weight = weightData.Weight / 1000
-	weightData.BoneMass = weightData.BoneMass / 1000
-	weightData.MuscleMass = weightData.MuscleMass / 1000
-	weightData.Hydration = weightData.Hydration / 1000
+// WeighInDTO mirrors a weight-service weigh-in. Masses are in grams;
+// body-composition fields are nil when not measured.
+type WeighInDTO struct {
+	SamplePK       int64    `json:"samplePk"`
+	TimestampGMT   int64    `json:"timestampGMT"` // Milliseconds
+	Date           int64    `json:"date"`         // Local time in milliseconds
+	CalendarDate   string   `json:"calendarDate"`
+	Weight         float64  `json:"weight"`
+	BMI            *float64 `json:"bmi"`
+	BodyFat        *float64 `json:"bodyFat"`
+	BodyWater      *float64 `json:"bodyWater"`
+	BoneMass       *float64 `json:"boneMass"`
+	MuscleMass     *float64 `json:"muscleMass"`
+	PhysiqueRating *float64 `json:"physiqueRating"`
+	VisceralFat    *float64 `json:"visceralFat"`
+	MetabolicAge   *float64 `json:"metabolicAge"`
+	SourceType     string   `json:"sourceType"`
+}
 
-	return &WeightDataWithMethods{WeightData: weightData}, nil
+// Time returns the measurement time in UTC, falling back to the local time
+// when the GMT timestamp is missing
+func (d *WeighInDTO) Time() time.Time {
+	ts := d.TimestampGMT
+	if ts == 0 {
+		ts = d.Date
+	}
+	return time.UnixMilli(ts).UTC()
 }
 
-// List implements the Data interface for concurrent fetching
-func (w *WeightDataWithMethods) List(end time.Time, days int, c shared.APIClient, maxWorkers int) ([]any, error) {
-	// BaseData is not part of garth.WeightData, so this line needs to be removed or re-evaluated.
-	// For now, I will return an empty slice and no error, as this function is not directly related to the task.
-	return []any{}, nil
+// ParseWeighIns parses a weight-service range response into its weigh-ins,
+// oldest first. An empty body has no weigh-ins.
+func ParseWeighIns(body []byte) ([]WeighInDTO, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+
+	var response struct {
+		DailyWeightSummaries []struct {
+			AllWeightMetrics []WeighInDTO `json:"allWeightMetrics"`
+		} `json:"dailyWeightSummaries"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	var weighIns []WeighInDTO
+	for _, day := range response.DailyWeightSummaries {
+		weighIns = append(weighIns, day.AllWeightMetrics...)
+	}
+	sort.SliceStable(weighIns, func(i, j int) bool {
+		return weighIns[i].Time().Before(weighIns[j].Time())
+	})
+	return weighIns, nil
 }
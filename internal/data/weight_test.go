@@ -0,0 +1,62 @@
+package data
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garth/client"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightDataWithMethods(t *testing.T) {
+	var path string
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		w.Write([]byte(`{"dailyWeightSummaries": [`))
+		if r.URL.Path != "/weight-service/weight/range/2025-03-01/2025-03-01" {
+			w.Write([]byte(`{"allWeightMetrics": [{"samplePk": 2, "calendarDate": "2025-03-03", "timestampGMT": 1741000000000, "weight": 71200, "bmi": 22.3}]},`))
+		}
+		w.Write([]byte(`{"allWeightMetrics": [
+			{"samplePk": 1, "calendarDate": "2025-03-01", "timestampGMT": 1740812400000, "weight": 70000, "bmi": 21.8, "boneMass": 3100},
+			{"samplePk": 3, "calendarDate": "2025-03-01", "timestampGMT": 1740855600000, "weight": 70400, "bmi": 21.9, "bodyWater": 55.2}]}]}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	c, err := client.NewClient(u.Host)
+	require.NoError(t, err)
+	c.HTTPClient = server.Client()
+	c.AuthToken = "Bearer testtoken"
+
+	results, errs := (&WeightDataWithMethods{}).List(time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC), 7, c, 1)
+	require.Empty(t, errs)
+	assert.Equal(t, "/weight-service/weight/range/2025-03-01/2025-03-07", path)
+	assert.Equal(t, "true", query.Get("includeAll"))
+	require.Len(t, results, 3)
+
+	var samples []int64
+	for _, r := range results {
+		w := r.(*WeightDataWithMethods)
+		samples = append(samples, w.SamplePK)
+		assert.NoError(t, w.Validate())
+	}
+	assert.Equal(t, []int64{2, 3, 1}, samples)
+
+	oldest := results[2].(*WeightDataWithMethods)
+	assert.Equal(t, 70.0, oldest.Weight)
+	assert.Equal(t, 3.1, oldest.BoneMass)
+	assert.Equal(t, time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC), oldest.Timestamp)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), oldest.Date)
+
+	latest, err := (&WeightDataWithMethods{}).Get(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), c)
+	require.NoError(t, err)
+	day := latest.(*WeightDataWithMethods)
+	assert.Equal(t, int64(3), day.SamplePK, "Get returns the latest weigh-in")
+	assert.Equal(t, 55.2, day.Hydration)
+}
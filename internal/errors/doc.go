@@ -1,5 +1,6 @@
 // Package errors defines structured error types used across the module,
-// including APIError, IOError, AuthenticationError, OAuthError, and
-// ValidationError. These implement error wrapping and preserve HTTP context.
+// including APIError, IOError, AuthenticationError, OAuthError,
+// ValidationError, and the resource errors NotFoundError, PermissionError and
+// ConflictError. These implement error wrapping and preserve HTTP context.
 // Note: This is an internal package and not intended for direct external use.
 package errors
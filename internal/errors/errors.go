@@ -82,3 +82,36 @@ func (e *ValidationError) Error() string {
 	}
 	return fmt.Sprintf("validation error: %s", e.Message)
 }
+
+// NotFoundError represents a request for a resource that does not exist
+type NotFoundError struct {
+	GarthHTTPError
+	Resource string
+	ID       string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.ID)
+}
+
+// PermissionError represents an operation on a resource owned by another user
+type PermissionError struct {
+	GarthHTTPError
+	Resource string
+	ID       string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%s %s is not owned by the current user", e.Resource, e.ID)
+}
+
+// ConflictError represents a resource that changed since it was last read
+type ConflictError struct {
+	GarthError
+	Resource string
+	ID       string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified: %s", e.Resource, e.ID, e.Message)
+}
@@ -1,7 +1,9 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -64,6 +66,16 @@ func (gt *GarminTime) UnmarshalJSON(b []byte) (err error) {
 		return nil
 	}
 
+	// Unquoted numbers are epoch timestamps in milliseconds
+	if len(b) > 0 && b[0] != '"' {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("cannot parse %q into a GarminTime", s)
+		}
+		gt.Time = time.UnixMilli(ms).UTC()
+		return nil
+	}
+
 	// Try parsing with milliseconds (e.g., "2018-09-01T00:13:25.000")
 	// Garmin sometimes returns .0 for milliseconds, which Go's time.Parse handles as .000
 	// The 'Z' in the layout indicates a UTC time without a specific offset, which is often how these are interpreted.
@@ -74,6 +86,7 @@ func (gt *GarminTime) UnmarshalJSON(b []byte) (err error) {
 		"2006-01-02T15:04:05.0", // Example: 2018-09-01T00:13:25.0
 		"2006-01-02T15:04:05",   // Example: 2018-09-01T00:13:25
 		"2006-01-02",            // Example: 2018-09-01
+		time.RFC3339Nano,        // As written by MarshalJSON, e.g. 2018-09-01T00:13:25Z
 	}
 
 	for _, layout := range layouts {
@@ -136,12 +149,74 @@ type UserProfile struct {
 	// Add other fields as needed from API response
 }
 
-// VO2MaxData represents VO2 max data
+// VO2MaxData represents the VO2 max estimates made on one day
 type VO2MaxData struct {
 	Date          time.Time `json:"calendarDate"`
 	VO2MaxRunning *float64  `json:"vo2MaxRunning"`
 	VO2MaxCycling *float64  `json:"vo2MaxCycling"`
 	UserProfilePK int       `json:"userProfilePk"`
+
+	Generic                 *MaxMetEstimate          `json:"generic,omitempty"`
+	Cycling                 *MaxMetEstimate          `json:"cycling,omitempty"`
+	FitnessAge              *int                     `json:"fitnessAge,omitempty"`
+	HeatAltitudeAcclimation *HeatAltitudeAcclimation `json:"heatAltitudeAcclimation,omitempty"`
+}
+
+// MaxMetEstimate is a VO2 max estimate from the metrics-service maxmet history.
+// Generic estimates come from running and walking, cycling ones from rides.
+type MaxMetEstimate struct {
+	CalendarDate          GarminTime `json:"calendarDate"`
+	VO2MaxPreciseValue    float64    `json:"vo2MaxPreciseValue"`
+	VO2MaxValue           float64    `json:"vo2MaxValue"`
+	FitnessAge            *int       `json:"fitnessAge"`
+	FitnessAgeDescription *string    `json:"fitnessAgeDescription"`
+	MaxMetCategory        int        `json:"maxMetCategory"`
+}
+
+// HeatAltitudeAcclimation holds the heat and altitude acclimation reported
+// alongside a VO2 max estimate
+type HeatAltitudeAcclimation struct {
+	CalendarDate              GarminTime `json:"calendarDate"`
+	HeatAcclimationPercentage int        `json:"heatAcclimationPercentage"`
+	HeatTrend                 string     `json:"heatTrend"`
+	AltitudeAcclimation       int        `json:"altitudeAcclimation"`
+	AltitudeTrend             string     `json:"altitudeTrend"`
+	CurrentAltitude           int        `json:"currentAltitude"`
+}
+
+// MaxMetDaily is one day of the maxmet history endpoint
+type MaxMetDaily struct {
+	UserID                  int                      `json:"userId"`
+	Generic                 *MaxMetEstimate          `json:"generic"`
+	Cycling                 *MaxMetEstimate          `json:"cycling"`
+	HeatAltitudeAcclimation *HeatAltitudeAcclimation `json:"heatAltitudeAcclimation"`
+}
+
+// VO2MaxData converts a maxmet day, returning false if it holds no estimate
+func (m *MaxMetDaily) VO2MaxData() (VO2MaxData, bool) {
+	v := VO2MaxData{
+		UserProfilePK:           m.UserID,
+		Generic:                 m.Generic,
+		Cycling:                 m.Cycling,
+		HeatAltitudeAcclimation: m.HeatAltitudeAcclimation,
+	}
+	if m.Generic != nil && m.Generic.VO2MaxPreciseValue > 0 {
+		running := m.Generic.VO2MaxPreciseValue
+		v.VO2MaxRunning = &running
+		v.FitnessAge = m.Generic.FitnessAge
+		v.Date = m.Generic.CalendarDate.Time
+	}
+	if m.Cycling != nil && m.Cycling.VO2MaxPreciseValue > 0 {
+		cycling := m.Cycling.VO2MaxPreciseValue
+		v.VO2MaxCycling = &cycling
+		if v.Date.IsZero() {
+			v.Date = m.Cycling.CalendarDate.Time
+		}
+	}
+	if v.Date.IsZero() && m.HeatAltitudeAcclimation != nil {
+		v.Date = m.HeatAltitudeAcclimation.CalendarDate.Time
+	}
+	return v, v.VO2MaxRunning != nil || v.VO2MaxCycling != nil
 }
 
 // Add these new structs
@@ -158,41 +233,96 @@ type VO2Max struct {
 	UpdatedDate  time.Time `json:"date"`
 }
 
-// VO2MaxProfile represents the current VO2 max profile from user settings
+// VO2MaxProfile represents the latest VO2 max values
 type VO2MaxProfile struct {
-	UserProfilePK int          `json:"userProfilePk"`
-	LastUpdated   time.Time    `json:"lastUpdated"`
-	Running       *VO2MaxEntry `json:"running,omitempty"`
-	Cycling       *VO2MaxEntry `json:"cycling,omitempty"`
+	UserProfilePK           int                      `json:"userProfilePk"`
+	LastUpdated             time.Time                `json:"lastUpdated"`
+	Running                 *VO2MaxEntry             `json:"running,omitempty"`
+	Cycling                 *VO2MaxEntry             `json:"cycling,omitempty"`
+	FitnessAge              *int                     `json:"fitnessAge,omitempty"`
+	HeatAltitudeAcclimation *HeatAltitudeAcclimation `json:"heatAltitudeAcclimation,omitempty"`
+}
+
+// NewVO2MaxProfile builds a profile from the latest running and cycling
+// estimates in a VO2 max history. LastUpdated is the date of the newest estimate.
+func NewVO2MaxProfile(history []VO2MaxData) *VO2MaxProfile {
+	profile := &VO2MaxProfile{}
+	for _, v := range history {
+		if v.UserProfilePK != 0 {
+			profile.UserProfilePK = v.UserProfilePK
+		}
+		if v.VO2MaxRunning != nil && (profile.Running == nil || !v.Date.Before(profile.Running.Date)) {
+			profile.Running = &VO2MaxEntry{Value: *v.VO2MaxRunning, ActivityType: "running", Date: v.Date, Source: "maxmet"}
+			profile.FitnessAge = v.FitnessAge
+		}
+		if v.VO2MaxCycling != nil && (profile.Cycling == nil || !v.Date.Before(profile.Cycling.Date)) {
+			profile.Cycling = &VO2MaxEntry{Value: *v.VO2MaxCycling, ActivityType: "cycling", Date: v.Date, Source: "maxmet"}
+		}
+		if v.HeatAltitudeAcclimation != nil && v.Date.After(profile.LastUpdated) {
+			profile.HeatAltitudeAcclimation = v.HeatAltitudeAcclimation
+		}
+		if v.Date.After(profile.LastUpdated) {
+			profile.LastUpdated = v.Date
+		}
+	}
+	return profile
 }
 
 // SleepLevel represents different sleep stages
 type SleepLevel struct {
-	StartGMT      time.Time `json:"startGmt"`
-	EndGMT        time.Time `json:"endGmt"`
-	ActivityLevel float64   `json:"activityLevel"`
-	SleepLevel    string    `json:"sleepLevel"` // "deep", "light", "rem", "awake"
+	StartGMT      GarminTime `json:"startGmt"`
+	EndGMT        GarminTime `json:"endGmt"`
+	ActivityLevel float64    `json:"activityLevel"` // 0 deep, 1 light, 2 REM, 3 awake
+	SleepLevel    string     `json:"sleepLevel"`    // "deep", "light", "rem", "awake"
+}
+
+// Stage returns the sleep stage of the level: "deep", "light", "rem",
+// "awake" or "unmeasurable". Garmin encodes the stage in ActivityLevel
+// when SleepLevel is not set.
+func (l SleepLevel) Stage() string {
+	if l.SleepLevel != "" {
+		return l.SleepLevel
+	}
+	switch l.ActivityLevel {
+	case 0:
+		return "deep"
+	case 1:
+		return "light"
+	case 2:
+		return "rem"
+	case 3:
+		return "awake"
+	}
+	return "unmeasurable"
 }
 
 // SleepMovement represents movement during sleep
 type SleepMovement struct {
-	StartGMT      time.Time `json:"startGmt"`
-	EndGMT        time.Time `json:"endGmt"`
-	ActivityLevel float64   `json:"activityLevel"`
+	StartGMT      GarminTime `json:"startGmt"`
+	EndGMT        GarminTime `json:"endGmt"`
+	ActivityLevel float64    `json:"activityLevel"`
 }
 
-// SleepScore represents detailed sleep scoring
-type SleepScore struct {
-	Overall          int                 `json:"overall"`
-	Composition      SleepScoreBreakdown `json:"composition"`
-	Revitalization   SleepScoreBreakdown `json:"revitalization"`
-	Duration         SleepScoreBreakdown `json:"duration"`
-	DeepPercentage   float64             `json:"deepPercentage"`
-	LightPercentage  float64             `json:"lightPercentage"`
-	RemPercentage    float64             `json:"remPercentage"`
-	RestfulnessValue float64             `json:"restfulnessValue"`
+// SleepRestlessMoment represents a restless moment during sleep
+type SleepRestlessMoment struct {
+	StartGMT GarminTime `json:"startGmt"`
+	Value    int        `json:"value"`
 }
 
+// SleepScore represents detailed sleep scoring, one breakdown per factor
+type SleepScore struct {
+	Overall         SleepScoreBreakdown `json:"overall"`
+	TotalDuration   SleepScoreBreakdown `json:"totalDuration"`
+	Stress          SleepScoreBreakdown `json:"stress"`
+	AwakeCount      SleepScoreBreakdown `json:"awakeCount"`
+	RemPercentage   SleepScoreBreakdown `json:"remPercentage"`
+	Restlessness    SleepScoreBreakdown `json:"restlessness"`
+	LightPercentage SleepScoreBreakdown `json:"lightPercentage"`
+	DeepPercentage  SleepScoreBreakdown `json:"deepPercentage"`
+}
+
+// SleepScoreBreakdown represents one factor of the sleep score with its
+// qualifier, e.g. "EXCELLENT" or "POOR", and optimal range
 type SleepScoreBreakdown struct {
 	QualifierKey   string  `json:"qualifierKey"`
 	OptimalStart   float64 `json:"optimalStart"`
@@ -205,11 +335,11 @@ type SleepScoreBreakdown struct {
 // DetailedSleepData represents comprehensive sleep data
 type DetailedSleepData struct {
 	UserProfilePK            int             `json:"userProfilePk"`
-	CalendarDate             time.Time       `json:"calendarDate"`
-	SleepStartTimestampGMT   time.Time       `json:"sleepStartTimestampGmt"`
-	SleepEndTimestampGMT     time.Time       `json:"sleepEndTimestampGmt"`
-	SleepStartTimestampLocal time.Time       `json:"sleepStartTimestampLocal"`
-	SleepEndTimestampLocal   time.Time       `json:"sleepEndTimestampLocal"`
+	CalendarDate             GarminTime      `json:"calendarDate"`
+	SleepStartTimestampGMT   GarminTime      `json:"sleepStartTimestampGmt"`
+	SleepEndTimestampGMT     GarminTime      `json:"sleepEndTimestampGmt"`
+	SleepStartTimestampLocal GarminTime      `json:"sleepStartTimestampLocal"` // Local wall clock time
+	SleepEndTimestampLocal   GarminTime      `json:"sleepEndTimestampLocal"`
 	UnmeasurableSleepSeconds int             `json:"unmeasurableSleepSeconds"`
 	DeepSleepSeconds         int             `json:"deepSleepSeconds"`
 	LightSleepSeconds        int             `json:"lightSleepSeconds"`
@@ -226,6 +356,48 @@ type DetailedSleepData struct {
 	LowestRespirationValue   *float64        `json:"lowestRespirationValue"`
 	HighestRespirationValue  *float64        `json:"highestRespirationValue"`
 	AvgSleepStress           *float64        `json:"avgSleepStress"`
+
+	// Populated from the sleep response alongside dailySleepDTO
+	RestlessMoments   []SleepRestlessMoment `json:"sleepRestlessMoments"`
+	RestlessCount     int                   `json:"restlessMomentsCount"`
+	SpO2Summary       *SpO2SleepSummary     `json:"wellnessSpO2SleepSummaryDTO"`
+	SpO2Epochs        []SpO2Epoch           `json:"wellnessEpochSPO2DataDTOList"`
+	RespirationEpochs []RespirationEpoch    `json:"wellnessEpochRespirationDataDTOList"`
+	StressEpochs      []SleepStressEpoch    `json:"sleepStress"`
+}
+
+// SpO2SleepSummary summarizes blood oxygen saturation during sleep
+type SpO2SleepSummary struct {
+	UserProfilePK                  int        `json:"userProfilePk"`
+	DeviceID                       int64      `json:"deviceId"`
+	SleepMeasurementStartGMT       GarminTime `json:"sleepMeasurementStartGMT"`
+	SleepMeasurementEndGMT         GarminTime `json:"sleepMeasurementEndGMT"`
+	AlertThresholdValue            *int       `json:"alertThresholdValue"`
+	NumberOfEventsBelowThreshold   *int       `json:"numberOfEventsBelowThreshold"`
+	DurationOfEventsBelowThreshold *int       `json:"durationOfEventsBelowThreshold"`
+	AverageSpO2                    *float64   `json:"averageSPO2"`
+	AverageSpO2HR                  *float64   `json:"averageSpO2HR"`
+	LowestSpO2                     *int       `json:"lowestSPO2"`
+}
+
+// SpO2Epoch is a blood oxygen saturation reading in percent
+type SpO2Epoch struct {
+	EpochTimestamp    GarminTime `json:"epochTimestamp"`
+	EpochDuration     int        `json:"epochDuration"` // Seconds
+	SpO2Reading       int        `json:"spo2Reading"`
+	ReadingConfidence int        `json:"readingConfidence"`
+}
+
+// RespirationEpoch is a respiration rate reading in breaths per minute
+type RespirationEpoch struct {
+	StartTimeGMT     GarminTime `json:"startTimeGMT"`
+	RespirationValue float64    `json:"respirationValue"`
+}
+
+// SleepStressEpoch is a stress level reading taken during sleep
+type SleepStressEpoch struct {
+	StartGMT GarminTime `json:"startGMT"`
+	Value    int        `json:"value"`
 }
 
 // HRVBaseline represents HRV baseline data
@@ -239,42 +411,73 @@ type HRVBaseline struct {
 // DailyHRVData represents comprehensive daily HRV data
 type DailyHRVData struct {
 	UserProfilePK          int          `json:"userProfilePk"`
-	CalendarDate           time.Time    `json:"calendarDate"`
+	CalendarDate           GarminTime   `json:"calendarDate"`
 	WeeklyAvg              *float64     `json:"weeklyAvg"`
 	LastNightAvg           *float64     `json:"lastNightAvg"`
 	LastNight5MinHigh      *float64     `json:"lastNight5MinHigh"`
 	Baseline               HRVBaseline  `json:"baseline"`
 	Status                 string       `json:"status"`
 	FeedbackPhrase         string       `json:"feedbackPhrase"`
-	CreateTimeStamp        time.Time    `json:"createTimeStamp"`
+	CreateTimeStamp        GarminTime   `json:"createTimeStamp"`
 	HRVReadings            []HRVReading `json:"hrvReadings"`
-	StartTimestampGMT      time.Time    `json:"startTimestampGmt"`
-	EndTimestampGMT        time.Time    `json:"endTimestampGmt"`
-	StartTimestampLocal    time.Time    `json:"startTimestampLocal"`
-	EndTimestampLocal      time.Time    `json:"endTimestampLocal"`
-	SleepStartTimestampGMT time.Time    `json:"sleepStartTimestampGmt"`
-	SleepEndTimestampGMT   time.Time    `json:"sleepEndTimestampGmt"`
+	StartTimestampGMT      GarminTime   `json:"startTimestampGmt"`
+	EndTimestampGMT        GarminTime   `json:"endTimestampGmt"`
+	StartTimestampLocal    GarminTime   `json:"startTimestampLocal"`
+	EndTimestampLocal      GarminTime   `json:"endTimestampLocal"`
+	SleepStartTimestampGMT GarminTime   `json:"sleepStartTimestampGmt"`
+	SleepEndTimestampGMT   GarminTime   `json:"sleepEndTimestampGmt"`
 }
 
 // BodyBatteryEvent represents events that impact Body Battery
 type BodyBatteryEvent struct {
-	EventType              string    `json:"eventType"` // "sleep", "activity", "stress"
-	EventStartTimeGMT      time.Time `json:"eventStartTimeGmt"`
-	TimezoneOffset         int       `json:"timezoneOffset"`
-	DurationInMilliseconds int       `json:"durationInMilliseconds"`
-	BodyBatteryImpact      int       `json:"bodyBatteryImpact"`
-	FeedbackType           string    `json:"feedbackType"`
-	ShortFeedback          string    `json:"shortFeedback"`
+	EventType              string     `json:"eventType"` // "sleep", "activity", "stress"
+	EventStartTimeGMT      GarminTime `json:"eventStartTimeGmt"`
+	TimezoneOffset         int        `json:"timezoneOffset"`
+	DurationInMilliseconds int        `json:"durationInMilliseconds"`
+	BodyBatteryImpact      int        `json:"bodyBatteryImpact"`
+	FeedbackType           string     `json:"feedbackType"`
+	ShortFeedback          string     `json:"shortFeedback"`
+}
+
+// End returns when the event ended
+func (e BodyBatteryEvent) End() time.Time {
+	return e.EventStartTimeGMT.Add(time.Duration(e.DurationInMilliseconds) * time.Millisecond)
+}
+
+// ParseBodyBatteryEvents decodes a Body Battery events response. Events are
+// either listed directly or wrapped with their activity details, as in
+// [{"event": {...}, "activityId": ...}].
+func ParseBodyBatteryEvents(data []byte) ([]BodyBatteryEvent, error) {
+	var wrapped []struct {
+		Event *BodyBatteryEvent `json:"event"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return nil, err
+	}
+	if len(wrapped) == 0 || wrapped[0].Event == nil {
+		var events []BodyBatteryEvent
+		if err := json.Unmarshal(data, &events); err != nil {
+			return nil, err
+		}
+		return events, nil
+	}
+	events := make([]BodyBatteryEvent, 0, len(wrapped))
+	for _, w := range wrapped {
+		if w.Event != nil {
+			events = append(events, *w.Event)
+		}
+	}
+	return events, nil
 }
 
 // DetailedBodyBatteryData represents comprehensive Body Battery data
 type DetailedBodyBatteryData struct {
 	UserProfilePK          int                `json:"userProfilePk"`
-	CalendarDate           time.Time          `json:"calendarDate"`
-	StartTimestampGMT      time.Time          `json:"startTimestampGmt"`
-	EndTimestampGMT        time.Time          `json:"endTimestampGmt"`
-	StartTimestampLocal    time.Time          `json:"startTimestampLocal"`
-	EndTimestampLocal      time.Time          `json:"endTimestampLocal"`
+	CalendarDate           GarminTime         `json:"calendarDate"`
+	StartTimestampGMT      GarminTime         `json:"startTimestampGmt"`
+	EndTimestampGMT        GarminTime         `json:"endTimestampGmt"`
+	StartTimestampLocal    GarminTime         `json:"startTimestampLocal"`
+	EndTimestampLocal      GarminTime         `json:"endTimestampLocal"`
 	MaxStressLevel         int                `json:"maxStressLevel"`
 	AvgStressLevel         int                `json:"avgStressLevel"`
 	BodyBatteryValuesArray [][]interface{}    `json:"bodyBatteryValuesArray"`
@@ -282,23 +485,252 @@ type DetailedBodyBatteryData struct {
 	Events                 []BodyBatteryEvent `json:"bodyBatteryEvents"`
 }
 
+// TrainingStatusKey is Garmin's training status
+type TrainingStatusKey string
+
+// Training statuses
+const (
+	TrainingStatusNone         TrainingStatusKey = "NO_STATUS"
+	TrainingStatusDetraining   TrainingStatusKey = "DETRAINING"
+	TrainingStatusRecovery     TrainingStatusKey = "RECOVERY"
+	TrainingStatusMaintaining  TrainingStatusKey = "MAINTAINING"
+	TrainingStatusProductive   TrainingStatusKey = "PRODUCTIVE"
+	TrainingStatusPeaking      TrainingStatusKey = "PEAKING"
+	TrainingStatusOverreaching TrainingStatusKey = "OVERREACHING"
+	TrainingStatusUnproductive TrainingStatusKey = "UNPRODUCTIVE"
+	TrainingStatusStrained     TrainingStatusKey = "STRAINED"
+	TrainingStatusPaused       TrainingStatusKey = "PAUSED"
+)
+
+// ParseTrainingStatusKey returns the status of a training status feedback
+// phrase such as "PRODUCTIVE_2"
+func ParseTrainingStatusKey(phrase string) TrainingStatusKey {
+	if i := strings.LastIndexByte(phrase, '_'); i > 0 {
+		if _, err := strconv.Atoi(phrase[i+1:]); err == nil {
+			phrase = phrase[:i]
+		}
+	}
+	if phrase == "" || phrase == "NONE" {
+		return TrainingStatusNone
+	}
+	return TrainingStatusKey(phrase)
+}
+
+// Description explains the status as Garmin Connect does
+func (k TrainingStatusKey) Description() string {
+	switch k {
+	case TrainingStatusNone:
+		return "Not enough training history to determine a training status"
+	case TrainingStatusDetraining:
+		return "Training load is much lower than usual and fitness is declining"
+	case TrainingStatusRecovery:
+		return "Lighter training load is letting the body recover"
+	case TrainingStatusMaintaining:
+		return "Training load is enough to maintain fitness"
+	case TrainingStatusProductive:
+		return "Training load is moving fitness in the right direction"
+	case TrainingStatusPeaking:
+		return "In ideal race condition after reducing training load"
+	case TrainingStatusOverreaching:
+		return "Training load is very high and counterproductive"
+	case TrainingStatusUnproductive:
+		return "Training load is good but fitness is decreasing"
+	case TrainingStatusStrained:
+		return "Recovery is limited by poor HRV, sleep or other factors"
+	case TrainingStatusPaused:
+		return "Training status is paused"
+	}
+	return string(k)
+}
+
+// AcuteTrainingLoad is the acute load compared with the chronic load
+type AcuteTrainingLoad struct {
+	Acute        float64 `json:"acute"`
+	Chronic      float64 `json:"chronic"`
+	OptimalMin   float64 `json:"optimalMin"` // Optimal acute load range
+	OptimalMax   float64 `json:"optimalMax"`
+	Ratio        float64 `json:"ratio"`        // Acute to chronic workload ratio
+	RatioPercent int     `json:"ratioPercent"` // Position of the ratio on Garmin's gauge
+	RatioStatus  string  `json:"ratioStatus"`  // "LOW", "OPTIMAL", "HIGH" or "VERY_HIGH"
+}
+
+// LoadFocusRange is four weeks of load in one focus with its target range
+type LoadFocusRange struct {
+	Load      float64 `json:"load"`
+	TargetMin float64 `json:"targetMin"`
+	TargetMax float64 `json:"targetMax"`
+}
+
+// InRange reports whether the load is within its target range
+func (r LoadFocusRange) InRange() bool {
+	return r.Load >= r.TargetMin && r.Load <= r.TargetMax
+}
+
+// LoadFocus is the balance of four weeks of load between anaerobic, high
+// aerobic and low aerobic training
+type LoadFocus struct {
+	Anaerobic   LoadFocusRange `json:"anaerobic"`
+	HighAerobic LoadFocusRange `json:"highAerobic"`
+	LowAerobic  LoadFocusRange `json:"lowAerobic"`
+	Feedback    string         `json:"feedback"` // e.g. "BALANCED", "AEROBIC_HIGH_SHORTAGE"
+}
+
+// TrainingVO2Max is the VO2 max behind a training status
+type TrainingVO2Max struct {
+	CalendarDate GarminTime `json:"calendarDate"`
+	Value        float64    `json:"vo2MaxValue"`
+	PreciseValue float64    `json:"vo2MaxPreciseValue"`
+	FitnessAge   *int       `json:"fitnessAge"`
+}
+
 // TrainingStatus represents current training status
 type TrainingStatus struct {
-	CalendarDate          time.Time `json:"calendarDate"`
-	TrainingStatusKey     string    `json:"trainingStatusKey"` // "DETRAINING", "RECOVERY", "MAINTAINING", "PRODUCTIVE", "PEAKING", "OVERREACHING", "UNPRODUCTIVE", "NONE"
-	TrainingStatusTypeKey string    `json:"trainingStatusTypeKey"`
-	TrainingStatusValue   int       `json:"trainingStatusValue"`
-	LoadRatio             float64   `json:"loadRatio"`
+	CalendarDate          GarminTime        `json:"calendarDate"`
+	TrainingStatusKey     TrainingStatusKey `json:"trainingStatusKey"`
+	TrainingStatusTypeKey string            `json:"trainingStatusTypeKey"` // Feedback phrase, e.g. "PRODUCTIVE_2"
+	TrainingStatusValue   int               `json:"trainingStatusValue"`
+	LoadRatio             float64           `json:"loadRatio"`
+	SinceDate             GarminTime        `json:"sinceDate"`
+	Sport                 string            `json:"sport"`
+	Paused                bool              `json:"paused"`
+	AcuteLoad             AcuteTrainingLoad `json:"acuteLoad"`
+	LoadFocus             *LoadFocus        `json:"loadFocus"`
+	RecoveryTime          time.Duration     `json:"-"` // From training readiness, filled by pkg/garmin
+	VO2MaxRunning         *TrainingVO2Max   `json:"vo2MaxRunning"`
+	VO2MaxCycling         *TrainingVO2Max   `json:"vo2MaxCycling"`
+}
+
+// trainingStatusResponse mirrors the aggregated training status response.
+// Status and load focus are reported per device.
+type trainingStatusResponse struct {
+	MostRecentVO2Max *struct {
+		Generic *TrainingVO2Max `json:"generic"`
+		Cycling *TrainingVO2Max `json:"cycling"`
+	} `json:"mostRecentVO2Max"`
+	MostRecentTrainingLoadBalance *struct {
+		Devices map[string]struct {
+			MonthlyLoadAerobicLow           float64 `json:"monthlyLoadAerobicLow"`
+			MonthlyLoadAerobicHigh          float64 `json:"monthlyLoadAerobicHigh"`
+			MonthlyLoadAnaerobic            float64 `json:"monthlyLoadAnaerobic"`
+			MonthlyLoadAerobicLowTargetMin  float64 `json:"monthlyLoadAerobicLowTargetMin"`
+			MonthlyLoadAerobicLowTargetMax  float64 `json:"monthlyLoadAerobicLowTargetMax"`
+			MonthlyLoadAerobicHighTargetMin float64 `json:"monthlyLoadAerobicHighTargetMin"`
+			MonthlyLoadAerobicHighTargetMax float64 `json:"monthlyLoadAerobicHighTargetMax"`
+			MonthlyLoadAnaerobicTargetMin   float64 `json:"monthlyLoadAnaerobicTargetMin"`
+			MonthlyLoadAnaerobicTargetMax   float64 `json:"monthlyLoadAnaerobicTargetMax"`
+			TrainingBalanceFeedbackPhrase   string  `json:"trainingBalanceFeedbackPhrase"`
+			PrimaryTrainingDevice           bool    `json:"primaryTrainingDevice"`
+		} `json:"metricsTrainingLoadBalanceDTOMap"`
+	} `json:"mostRecentTrainingLoadBalance"`
+	MostRecentTrainingStatus *struct {
+		Devices map[string]struct {
+			CalendarDate                 GarminTime `json:"calendarDate"`
+			SinceDate                    GarminTime `json:"sinceDate"`
+			TrainingStatus               int        `json:"trainingStatus"`
+			TrainingStatusFeedbackPhrase string     `json:"trainingStatusFeedbackPhrase"`
+			Sport                        string     `json:"sport"`
+			TrainingPaused               bool       `json:"trainingPaused"`
+			PrimaryTrainingDevice        bool       `json:"primaryTrainingDevice"`
+			AcuteTrainingLoadDTO         *struct {
+				AcwrPercent                    int     `json:"acwrPercent"`
+				AcwrStatus                     string  `json:"acwrStatus"`
+				DailyTrainingLoadAcute         float64 `json:"dailyTrainingLoadAcute"`
+				DailyTrainingLoadChronic       float64 `json:"dailyTrainingLoadChronic"`
+				MinTrainingLoadChronic         float64 `json:"minTrainingLoadChronic"`
+				MaxTrainingLoadChronic         float64 `json:"maxTrainingLoadChronic"`
+				DailyAcuteChronicWorkloadRatio float64 `json:"dailyAcuteChronicWorkloadRatio"`
+			} `json:"acuteTrainingLoadDTO"`
+		} `json:"latestTrainingStatusData"`
+	} `json:"mostRecentTrainingStatus"`
+}
+
+// primaryDevice returns the key of the entry reported by the primary
+// training device, or else the lowest key
+func primaryDevice(keys []string, primary func(string) bool) string {
+	sort.Strings(keys)
+	for _, k := range keys {
+		if primary(k) {
+			return k
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	return keys[0]
+}
+
+// ParseTrainingStatus decodes an aggregated training status response. It
+// returns nil when the response holds no training status.
+func ParseTrainingStatus(data []byte) (*TrainingStatus, error) {
+	var response trainingStatusResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, err
+	}
+	if response.MostRecentTrainingStatus == nil || len(response.MostRecentTrainingStatus.Devices) == 0 {
+		return nil, nil
+	}
+
+	devices := response.MostRecentTrainingStatus.Devices
+	keys := make([]string, 0, len(devices))
+	for k := range devices {
+		keys = append(keys, k)
+	}
+	d := devices[primaryDevice(keys, func(k string) bool { return devices[k].PrimaryTrainingDevice })]
+
+	status := &TrainingStatus{
+		CalendarDate:          d.CalendarDate,
+		TrainingStatusKey:     ParseTrainingStatusKey(d.TrainingStatusFeedbackPhrase),
+		TrainingStatusTypeKey: d.TrainingStatusFeedbackPhrase,
+		TrainingStatusValue:   d.TrainingStatus,
+		SinceDate:             d.SinceDate,
+		Sport:                 d.Sport,
+		Paused:                d.TrainingPaused,
+	}
+	if d.TrainingPaused {
+		status.TrainingStatusKey = TrainingStatusPaused
+	}
+	if acute := d.AcuteTrainingLoadDTO; acute != nil {
+		status.LoadRatio = acute.DailyAcuteChronicWorkloadRatio
+		status.AcuteLoad = AcuteTrainingLoad{
+			Acute:        acute.DailyTrainingLoadAcute,
+			Chronic:      acute.DailyTrainingLoadChronic,
+			OptimalMin:   acute.MinTrainingLoadChronic,
+			OptimalMax:   acute.MaxTrainingLoadChronic,
+			Ratio:        acute.DailyAcuteChronicWorkloadRatio,
+			RatioPercent: acute.AcwrPercent,
+			RatioStatus:  acute.AcwrStatus,
+		}
+	}
+
+	if balance := response.MostRecentTrainingLoadBalance; balance != nil && len(balance.Devices) > 0 {
+		keys := make([]string, 0, len(balance.Devices))
+		for k := range balance.Devices {
+			keys = append(keys, k)
+		}
+		b := balance.Devices[primaryDevice(keys, func(k string) bool { return balance.Devices[k].PrimaryTrainingDevice })]
+		status.LoadFocus = &LoadFocus{
+			Anaerobic:   LoadFocusRange{b.MonthlyLoadAnaerobic, b.MonthlyLoadAnaerobicTargetMin, b.MonthlyLoadAnaerobicTargetMax},
+			HighAerobic: LoadFocusRange{b.MonthlyLoadAerobicHigh, b.MonthlyLoadAerobicHighTargetMin, b.MonthlyLoadAerobicHighTargetMax},
+			LowAerobic:  LoadFocusRange{b.MonthlyLoadAerobicLow, b.MonthlyLoadAerobicLowTargetMin, b.MonthlyLoadAerobicLowTargetMax},
+			Feedback:    b.TrainingBalanceFeedbackPhrase,
+		}
+	}
+
+	if vo2 := response.MostRecentVO2Max; vo2 != nil {
+		status.VO2MaxRunning = vo2.Generic
+		status.VO2MaxCycling = vo2.Cycling
+	}
+	return status, nil
 }
 
 // TrainingLoad represents training load data
 type TrainingLoad struct {
-	CalendarDate            time.Time `json:"calendarDate"`
-	AcuteTrainingLoad       float64   `json:"acuteTrainingLoad"`
-	ChronicTrainingLoad     float64   `json:"chronicTrainingLoad"`
-	TrainingLoadRatio       float64   `json:"trainingLoadRatio"`
-	TrainingEffectAerobic   float64   `json:"trainingEffectAerobic"`
-	TrainingEffectAnaerobic float64   `json:"trainingEffectAnaerobic"`
+	CalendarDate            GarminTime `json:"calendarDate"`
+	AcuteTrainingLoad       float64    `json:"acuteTrainingLoad"`
+	ChronicTrainingLoad     float64    `json:"chronicTrainingLoad"`
+	TrainingLoadRatio       float64    `json:"trainingLoadRatio"`
+	TrainingEffectAerobic   float64    `json:"trainingEffectAerobic"`
+	TrainingEffectAnaerobic float64    `json:"trainingEffectAnaerobic"`
 }
 
 // FitnessAge represents fitness age calculation
@@ -37,6 +37,12 @@ func TestGarminTime_UnmarshalJSON(t *testing.T) {
 			expected: time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC),
 			wantErr:  false,
 		},
+		{
+			name:     "RFC 3339 with offset",
+			input:    `"2018-09-01T02:13:25.5+02:00"`,
+			expected: time.Date(2018, 9, 1, 0, 13, 25, 500000000, time.UTC),
+			wantErr:  false,
+		},
 		{
 			name:    "invalid format",
 			input:   `"invalid"`,
@@ -79,4 +85,24 @@ func TestGarminTime_UnmarshalJSON(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("marshal round trip", func(t *testing.T) {
+		for _, want := range []time.Time{
+			time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC),
+			time.Date(2025, 3, 2, 8, 0, 0, 250000000, time.FixedZone("CET", 3600)),
+		} {
+			data, err := json.Marshal(GarminTime{Time: want})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got GarminTime
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Errorf("cannot unmarshal %s: %v", data, err)
+				continue
+			}
+			if !got.Time.Equal(want) {
+				t.Errorf("expected %v, got %v", want, got.Time)
+			}
+		}
+	})
 }
@@ -31,40 +31,48 @@ type WeatherLocation struct {
 	PostalCode       *string  `json:"postalCode"`
 }
 
+// HydrationContainer is a hydration container preset; Volume is in Unit,
+// e.g. "milliliter" or "ounce"
+type HydrationContainer struct {
+	Name   string  `json:"name"`
+	Volume float64 `json:"volume"`
+	Unit   string  `json:"unit"`
+}
+
 type UserData struct {
-	Gender                         string                   `json:"gender"`
-	Weight                         float64                  `json:"weight"`
-	Height                         float64                  `json:"height"`
-	TimeFormat                     string                   `json:"timeFormat"`
-	BirthDate                      time.Time                `json:"birthDate"`
-	MeasurementSystem              string                   `json:"measurementSystem"`
-	ActivityLevel                  *string                  `json:"activityLevel"`
-	Handedness                     string                   `json:"handedness"`
-	PowerFormat                    PowerFormat              `json:"powerFormat"`
-	HeartRateFormat                PowerFormat              `json:"heartRateFormat"`
-	FirstDayOfWeek                 FirstDayOfWeek           `json:"firstDayOfWeek"`
-	VO2MaxRunning                  *float64                 `json:"vo2MaxRunning"`
-	VO2MaxCycling                  *float64                 `json:"vo2MaxCycling"`
-	LactateThresholdSpeed          *float64                 `json:"lactateThresholdSpeed"`
-	LactateThresholdHeartRate      *float64                 `json:"lactateThresholdHeartRate"`
-	DiveNumber                     *int                     `json:"diveNumber"`
-	IntensityMinutesCalcMethod     string                   `json:"intensityMinutesCalcMethod"`
-	ModerateIntensityMinutesHRZone int                      `json:"moderateIntensityMinutesHrZone"`
-	VigorousIntensityMinutesHRZone int                      `json:"vigorousIntensityMinutesHrZone"`
-	HydrationMeasurementUnit       string                   `json:"hydrationMeasurementUnit"`
-	HydrationContainers            []map[string]interface{} `json:"hydrationContainers"`
-	HydrationAutoGoalEnabled       bool                     `json:"hydrationAutoGoalEnabled"`
-	FirstbeatMaxStressScore        *float64                 `json:"firstbeatMaxStressScore"`
-	FirstbeatCyclingLTTimestamp    *int64                   `json:"firstbeatCyclingLtTimestamp"`
-	FirstbeatRunningLTTimestamp    *int64                   `json:"firstbeatRunningLtTimestamp"`
-	ThresholdHeartRateAutoDetected bool                     `json:"thresholdHeartRateAutoDetected"`
-	FTPAutoDetected                *bool                    `json:"ftpAutoDetected"`
-	TrainingStatusPausedDate       *string                  `json:"trainingStatusPausedDate"`
-	WeatherLocation                *WeatherLocation         `json:"weatherLocation"`
-	GolfDistanceUnit               *string                  `json:"golfDistanceUnit"`
-	GolfElevationUnit              *string                  `json:"golfElevationUnit"`
-	GolfSpeedUnit                  *string                  `json:"golfSpeedUnit"`
-	ExternalBottomTime             *float64                 `json:"externalBottomTime"`
+	Gender                         string               `json:"gender"`
+	Weight                         float64              `json:"weight"`
+	Height                         float64              `json:"height"`
+	TimeFormat                     string               `json:"timeFormat"`
+	BirthDate                      time.Time            `json:"birthDate"`
+	MeasurementSystem              string               `json:"measurementSystem"`
+	ActivityLevel                  *string              `json:"activityLevel"`
+	Handedness                     string               `json:"handedness"`
+	PowerFormat                    PowerFormat          `json:"powerFormat"`
+	HeartRateFormat                PowerFormat          `json:"heartRateFormat"`
+	FirstDayOfWeek                 FirstDayOfWeek       `json:"firstDayOfWeek"`
+	VO2MaxRunning                  *float64             `json:"vo2MaxRunning"`
+	VO2MaxCycling                  *float64             `json:"vo2MaxCycling"`
+	LactateThresholdSpeed          *float64             `json:"lactateThresholdSpeed"`
+	LactateThresholdHeartRate      *float64             `json:"lactateThresholdHeartRate"`
+	DiveNumber                     *int                 `json:"diveNumber"`
+	IntensityMinutesCalcMethod     string               `json:"intensityMinutesCalcMethod"`
+	ModerateIntensityMinutesHRZone int                  `json:"moderateIntensityMinutesHrZone"`
+	VigorousIntensityMinutesHRZone int                  `json:"vigorousIntensityMinutesHrZone"`
+	HydrationMeasurementUnit       string               `json:"hydrationMeasurementUnit"`
+	HydrationContainers            []HydrationContainer `json:"hydrationContainers"`
+	HydrationAutoGoalEnabled       bool                 `json:"hydrationAutoGoalEnabled"`
+	FirstbeatMaxStressScore        *float64             `json:"firstbeatMaxStressScore"`
+	FirstbeatCyclingLTTimestamp    *int64               `json:"firstbeatCyclingLtTimestamp"`
+	FirstbeatRunningLTTimestamp    *int64               `json:"firstbeatRunningLtTimestamp"`
+	ThresholdHeartRateAutoDetected bool                 `json:"thresholdHeartRateAutoDetected"`
+	FTPAutoDetected                *bool                `json:"ftpAutoDetected"`
+	TrainingStatusPausedDate       *string              `json:"trainingStatusPausedDate"`
+	WeatherLocation                *WeatherLocation     `json:"weatherLocation"`
+	GolfDistanceUnit               *string              `json:"golfDistanceUnit"`
+	GolfElevationUnit              *string              `json:"golfElevationUnit"`
+	GolfSpeedUnit                  *string              `json:"golfSpeedUnit"`
+	ExternalBottomTime             *float64             `json:"externalBottomTime"`
 }
 
 type UserSleep struct {
@@ -6,17 +6,18 @@ import (
 
 // ActivityOptions for filtering activity lists
 type ActivityOptions struct {
-	Limit      int
-	Offset     int
+	Limit        int
+	Offset       int
 	ActivityType string
-	DateFrom   time.Time
-	DateTo     time.Time
+	DateFrom     time.Time
+	DateTo       time.Time
 }
 
 // ActivityDetail represents detailed information for an activity
 type ActivityDetail struct {
-	Activity // Embed garmin.Activity from pkg/garmin/types.go
-	Description string `json:"description"`	// Add more fields as needed
+	Activity           // Embed garmin.Activity from pkg/garmin/types.go
+	Description string `json:"description"` // Add more fields as needed
+	Privacy     string `json:"privacy"`     // One of the Privacy* constants
 }
 
 // Lap represents a lap in an activity
@@ -31,8 +32,11 @@ type Metric struct {
 
 // DownloadOptions for downloading activity data
 type DownloadOptions struct {
-	Format    string // "gpx", "tcx", "fit", "csv"
-	Original  bool   // Download original uploaded file
+	Format    string // "gpx", "tcx", "kml", "csv" or "fit"
+	Original  bool   // Download original uploaded file (a zip archive)
+	Extract   bool   // Extract the .fit file from an original download
 	OutputDir string
-	Filename  string
+	// Filename may contain the placeholders {id}, {date}, {type}, {name} and
+	// {ext}, e.g. "{date}_{type}_{id}.{ext}". Defaults to "{id}.{ext}".
+	Filename string
 }
@@ -0,0 +1,250 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/sstent/go-garth/internal/errors"
+	garth "github.com/sstent/go-garth/pkg/garth/types"
+)
+
+// Activity privacy levels
+const (
+	PrivacyPublic      = "public"
+	PrivacyPrivate     = "private"
+	PrivacySubscribers = "subscribers"
+	PrivacyGroups      = "groups"
+)
+
+// privacyTypeIDs maps privacy levels to accessControlRuleDTO type IDs
+var privacyTypeIDs = map[string]int{
+	PrivacyPublic:      1,
+	PrivacyPrivate:     2,
+	PrivacySubscribers: 3,
+	PrivacyGroups:      4,
+}
+
+// ActivityUpdate describes changes to an activity. Nil fields are left unchanged.
+type ActivityUpdate struct {
+	Name        *string
+	Description *string
+	Type        *ActivityType
+	EventType   *EventType
+	Privacy     *string // One of the Privacy* constants
+
+	// Expected, when set, makes the update fail with a ConflictError if a
+	// field being changed no longer matches this earlier read of the activity
+	Expected *ActivityDetail
+}
+
+// ActivityResult is the outcome of one activity in a batch operation
+type ActivityResult struct {
+	ActivityID int
+	Err        error
+}
+
+// activityState mirrors the activity-service activity payload, which uses
+// activityTypeDTO/eventTypeDTO on detail reads and activityType/eventType in lists
+type activityState struct {
+	garth.Activity
+	ActivityTypeDTO   *ActivityType `json:"activityTypeDTO"`
+	EventTypeDTO      *EventType    `json:"eventTypeDTO"`
+	AccessControlRule *struct {
+		TypeID  int    `json:"typeId"`
+		TypeKey string `json:"typeKey"`
+	} `json:"accessControlRuleDTO"`
+}
+
+func (s *activityState) activityType() ActivityType {
+	if s.ActivityTypeDTO != nil {
+		return *s.ActivityTypeDTO
+	}
+	return s.ActivityType
+}
+
+func (s *activityState) eventType() EventType {
+	if s.EventTypeDTO != nil {
+		return *s.EventTypeDTO
+	}
+	return s.EventType
+}
+
+func (s *activityState) privacy() string {
+	if s.AccessControlRule == nil {
+		return ""
+	}
+	return s.AccessControlRule.TypeKey
+}
+
+// validate checks the update before any API call is made
+func (u *ActivityUpdate) validate() error {
+	if u.Name == nil && u.Description == nil && u.Type == nil && u.EventType == nil && u.Privacy == nil {
+		return validationError("ActivityUpdate", "no changes requested")
+	}
+	if u.Name != nil && *u.Name == "" {
+		return validationError("Name", "activity name cannot be empty")
+	}
+	if u.Type != nil && (u.Type.TypeID == 0 || u.Type.TypeKey == "") {
+		return validationError("Type", "activity type requires a type ID and key")
+	}
+	if u.EventType != nil && (u.EventType.TypeID == 0 || u.EventType.TypeKey == "") {
+		return validationError("EventType", "event type requires a type ID and key")
+	}
+	if u.Privacy != nil {
+		if _, ok := privacyTypeIDs[*u.Privacy]; !ok {
+			return validationError("Privacy", fmt.Sprintf("unknown privacy level %q", *u.Privacy))
+		}
+	}
+	return nil
+}
+
+// conflicts lists the fields being changed whose current value differs from
+// the expected one
+func (u *ActivityUpdate) conflicts(current *activityState) []string {
+	if u.Expected == nil {
+		return nil
+	}
+	var fields []string
+	if u.Name != nil && current.ActivityName != u.Expected.ActivityName {
+		fields = append(fields, "name")
+	}
+	if u.Description != nil && current.Description != u.Expected.Description {
+		fields = append(fields, "description")
+	}
+	if u.Type != nil && current.activityType().TypeKey != u.Expected.ActivityType.TypeKey {
+		fields = append(fields, "activity type")
+	}
+	if u.EventType != nil && current.eventType().TypeKey != u.Expected.EventType.TypeKey {
+		fields = append(fields, "event type")
+	}
+	if u.Privacy != nil && current.privacy() != u.Expected.Privacy {
+		fields = append(fields, "privacy")
+	}
+	return fields
+}
+
+// payload builds the activity-service PUT body
+func (u *ActivityUpdate) payload(activityID int) map[string]interface{} {
+	body := map[string]interface{}{"activityId": activityID}
+	if u.Name != nil {
+		body["activityName"] = *u.Name
+	}
+	if u.Description != nil {
+		body["description"] = *u.Description
+	}
+	if u.Type != nil {
+		body["activityTypeDTO"] = u.Type
+	}
+	if u.EventType != nil {
+		body["eventTypeDTO"] = u.EventType
+	}
+	if u.Privacy != nil {
+		body["accessControlRuleDTO"] = map[string]interface{}{
+			"typeId":  privacyTypeIDs[*u.Privacy],
+			"typeKey": *u.Privacy,
+		}
+	}
+	return body
+}
+
+// UpdateActivity changes the name, description, type, event type or privacy
+// of an activity. The activity is read first, so a missing activity yields a
+// NotFoundError before anything is written. A PermissionError is returned when
+// the service answers 403 to the read or the write.
+func (c *Client) UpdateActivity(ctx context.Context, activityID int, update ActivityUpdate) error {
+	if err := update.validate(); err != nil {
+		return err
+	}
+
+	id := strconv.Itoa(activityID)
+	path := fmt.Sprintf("/activity-service/activity/%d", activityID)
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return resourceError(fmt.Errorf("failed to get activity: %w", err), "activity", id)
+	}
+	if len(data) == 0 {
+		return &errors.NotFoundError{Resource: "activity", ID: id}
+	}
+	var current activityState
+	if err := json.Unmarshal(data, &current); err != nil {
+		return fmt.Errorf("failed to parse activity response: %w", err)
+	}
+	if fields := update.conflicts(&current); len(fields) > 0 {
+		return &errors.ConflictError{
+			GarthError: errors.GarthError{Message: fmt.Sprintf("%v changed since it was read", fields)},
+			Resource:   "activity",
+			ID:         id,
+		}
+	}
+
+	body, err := json.Marshal(update.payload(activityID))
+	if err != nil {
+		return fmt.Errorf("failed to encode activity update: %w", err)
+	}
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "PUT", nil, bytes.NewReader(body)); err != nil {
+		return resourceError(fmt.Errorf("failed to update activity: %w", err), "activity", id)
+	}
+	return nil
+}
+
+// DeleteActivity permanently deletes an activity
+func (c *Client) DeleteActivity(ctx context.Context, activityID int) error {
+	path := fmt.Sprintf("/activity-service/activity/%d", activityID)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "DELETE", nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to delete activity: %w", err), "activity", strconv.Itoa(activityID))
+	}
+	return nil
+}
+
+// UpdateActivities applies updates in activity ID order. Every activity is
+// attempted; the returned error joins the individual failures.
+func (c *Client) UpdateActivities(ctx context.Context, updates map[int]ActivityUpdate) ([]ActivityResult, error) {
+	ids := make([]int, 0, len(updates))
+	for id := range updates {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return c.batchActivities(ctx, ids, func(id int) error {
+		return c.UpdateActivity(ctx, id, updates[id])
+	})
+}
+
+// DeleteActivities deletes activities in the given order. Every activity is
+// attempted; the returned error joins the individual failures.
+func (c *Client) DeleteActivities(ctx context.Context, activityIDs []int) ([]ActivityResult, error) {
+	return c.batchActivities(ctx, activityIDs, func(id int) error {
+		return c.DeleteActivity(ctx, id)
+	})
+}
+
+// batchActivities runs op for each activity. Once the context is cancelled
+// the remaining activities are reported with the context error, which is
+// joined once, listing the activities that were not attempted.
+func (c *Client) batchActivities(ctx context.Context, ids []int, op func(id int) error) ([]ActivityResult, error) {
+	results := make([]ActivityResult, 0, len(ids))
+	var failures []error
+	var skipped []int
+	for _, id := range ids {
+		if err := ctx.Err(); err != nil {
+			results = append(results, ActivityResult{ActivityID: id, Err: err})
+			skipped = append(skipped, id)
+			continue
+		}
+		err := op(id)
+		results = append(results, ActivityResult{ActivityID: id, Err: err})
+		if err != nil {
+			failures = append(failures, fmt.Errorf("activity %d: %w", id, err))
+		}
+	}
+	if len(skipped) > 0 {
+		failures = append(failures, fmt.Errorf("activities %v not attempted: %w", skipped, ctx.Err()))
+	}
+	return results, stderrors.Join(failures...)
+}
@@ -0,0 +1,121 @@
+package garmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const editableActivity = `{"activityId": 42, "activityName": "Imported run", "description": "",
+	"activityTypeDTO": {"typeId": 1, "typeKey": "running"},
+	"eventTypeDTO": {"typeId": 9, "typeKey": "uncategorized"},
+	"accessControlRuleDTO": {"typeId": 2, "typeKey": "private"}}`
+
+func TestUpdateActivity(t *testing.T) {
+	var put map[string]interface{}
+	deleted := map[string]bool{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/activity-service/activity/42":
+			switch r.Method {
+			case "GET":
+				w.Write([]byte(editableActivity))
+			case "PUT":
+				if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&put)) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusNoContent)
+			case "DELETE":
+				deleted[r.URL.Path] = true
+				w.WriteHeader(http.StatusNoContent)
+			}
+		case "/activity-service/activity/7":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	name, privacy := "Trail run", garmin.PrivacyPublic
+
+	t.Run("sends only changed fields", func(t *testing.T) {
+		err := c.UpdateActivity(ctx, 42, garmin.ActivityUpdate{
+			Name:    &name,
+			Type:    &garmin.ActivityType{TypeID: 6, TypeKey: "trail_running"},
+			Privacy: &privacy,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, float64(42), put["activityId"])
+		assert.Equal(t, "Trail run", put["activityName"])
+		assert.Equal(t, "trail_running", put["activityTypeDTO"].(map[string]interface{})["typeKey"])
+		assert.Equal(t, float64(1), put["accessControlRuleDTO"].(map[string]interface{})["typeId"])
+		assert.NotContains(t, put, "description")
+	})
+
+	t.Run("optimistic check", func(t *testing.T) {
+		current, err := c.GetActivity(42)
+		require.NoError(t, err)
+		assert.Equal(t, garmin.PrivacyPrivate, current.Privacy)
+		assert.Equal(t, "running", current.ActivityType.TypeKey)
+		require.NoError(t, c.UpdateActivity(ctx, 42, garmin.ActivityUpdate{Name: &name, Expected: current}))
+
+		stale := *current
+		stale.ActivityName = "Renamed elsewhere"
+		err = c.UpdateActivity(ctx, 42, garmin.ActivityUpdate{Name: &name, Expected: &stale})
+		var conflict *garmin.ConflictError
+		assert.ErrorAs(t, err, &conflict)
+	})
+
+	t.Run("typed errors", func(t *testing.T) {
+		var notFound *garmin.NotFoundError
+		assert.ErrorAs(t, c.UpdateActivity(ctx, 99, garmin.ActivityUpdate{Name: &name}), &notFound)
+		assert.ErrorAs(t, c.DeleteActivity(ctx, 99), &notFound)
+
+		var forbidden *garmin.PermissionError
+		assert.ErrorAs(t, c.UpdateActivity(ctx, 7, garmin.ActivityUpdate{Name: &name}), &forbidden)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		empty, unknown := "", "friends"
+		var invalid *garmin.ValidationError
+		assert.ErrorAs(t, c.UpdateActivity(ctx, 42, garmin.ActivityUpdate{}), &invalid)
+		assert.ErrorAs(t, c.UpdateActivity(ctx, 42, garmin.ActivityUpdate{Name: &empty}), &invalid)
+		assert.ErrorAs(t, c.UpdateActivity(ctx, 42, garmin.ActivityUpdate{Privacy: &unknown}), &invalid)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		results, err := c.UpdateActivities(ctx, map[int]garmin.ActivityUpdate{
+			42: {Name: &name},
+			99: {Name: &name},
+		})
+		assert.Error(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, 42, results[0].ActivityID)
+		assert.NoError(t, results[0].Err)
+		assert.Error(t, results[1].Err)
+
+		results, err = c.DeleteActivities(ctx, []int{42})
+		require.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.True(t, deleted["/activity-service/activity/42"])
+	})
+
+	t.Run("batch cancelled", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		results, err := c.DeleteActivities(cancelled, []int{7, 8, 9})
+		require.Len(t, results, 3)
+		for _, r := range results {
+			assert.ErrorIs(t, r.Err, context.Canceled)
+		}
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, "activities [7 8 9] not attempted: context canceled", err.Error())
+	})
+}
@@ -0,0 +1,381 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// Sort fields accepted by ActivitySearch.SortBy
+const (
+	SortByStartTime     = "startLocal"
+	SortByDistance      = "distance"
+	SortByDuration      = "duration"
+	SortByElevationGain = "elevationGain"
+	SortByAverageHR     = "averageHR"
+	SortByAverageSpeed  = "averageSpeed"
+)
+
+// Sort orders accepted by ActivitySearch.SortBy
+const (
+	SortAscending  = "asc"
+	SortDescending = "desc"
+)
+
+// activitySortKeys returns the value of each sort field, for routes that
+// cannot sort server-side
+var activitySortKeys = map[string]func(a *Activity) float64{
+	SortByStartTime:     func(a *Activity) float64 { return float64(a.StartTimeLocal.Unix()) },
+	SortByDistance:      func(a *Activity) float64 { return a.Distance },
+	SortByDuration:      func(a *Activity) float64 { return a.Duration },
+	SortByElevationGain: func(a *Activity) float64 { return a.ElevationGain },
+	SortByAverageHR:     func(a *Activity) float64 { return a.AverageHR },
+	SortByAverageSpeed:  func(a *Activity) float64 { return a.AverageSpeed },
+}
+
+// searchPageSize is the number of activities requested per page
+const searchPageSize = 100
+
+// valueRange is an inclusive range where a zero bound is open
+type valueRange struct {
+	min, max float64
+}
+
+func (r valueRange) set() bool {
+	return r.min != 0 || r.max != 0
+}
+
+func (r valueRange) contains(v float64) bool {
+	return (r.min == 0 || v >= r.min) && (r.max == 0 || v <= r.max)
+}
+
+// ActivitySearch builds an activity query. Filters the activity list service
+// supports are sent as query parameters; the rest are applied client-side while
+// paginating, so every filter behaves the same from the caller's side. Build
+// one with NewActivitySearch and run it with Client.FindActivities.
+type ActivitySearch struct {
+	query           string
+	activityType    string
+	excludeChildren bool
+	from, to        time.Time
+	distance        valueRange // meters
+	duration        valueRange // seconds
+	elevationGain   valueRange // meters
+	averageHR       valueRange // bpm
+	speed           valueRange // m/s
+	gearUUID        string
+	favorites       bool
+	name            *regexp.Regexp
+	sortBy          string
+	sortOrder       string
+	limit           int
+	err             error
+}
+
+// NewActivitySearch returns a search with no filters, sorted by the server default
+func NewActivitySearch() *ActivitySearch {
+	return &ActivitySearch{}
+}
+
+// Query sets a free-text search over activity names
+func (s *ActivitySearch) Query(query string) *ActivitySearch {
+	s.query = query
+	return s
+}
+
+// Type restricts results to an activity type key. Child types (such as
+// trail_running for running) are included unless excludeChildren is set.
+func (s *ActivitySearch) Type(typeKey string, excludeChildren bool) *ActivitySearch {
+	s.activityType = typeKey
+	s.excludeChildren = excludeChildren
+	return s
+}
+
+// Between restricts results to activities started between two dates, inclusive
+func (s *ActivitySearch) Between(from, to time.Time) *ActivitySearch {
+	s.from, s.to = from, to
+	return s
+}
+
+// Distance restricts results to a distance range in meters; zero leaves a bound open
+func (s *ActivitySearch) Distance(min, max float64) *ActivitySearch {
+	s.distance = valueRange{min, max}
+	return s
+}
+
+// Duration restricts results to a duration range; zero leaves a bound open
+func (s *ActivitySearch) Duration(min, max time.Duration) *ActivitySearch {
+	s.duration = valueRange{min.Seconds(), max.Seconds()}
+	return s
+}
+
+// ElevationGain restricts results to an elevation gain range in meters
+func (s *ActivitySearch) ElevationGain(min, max float64) *ActivitySearch {
+	s.elevationGain = valueRange{min, max}
+	return s
+}
+
+// AverageHR restricts results to an average heart rate range in bpm
+func (s *ActivitySearch) AverageHR(min, max float64) *ActivitySearch {
+	s.averageHR = valueRange{min, max}
+	return s
+}
+
+// Speed restricts results to an average speed range in m/s
+func (s *ActivitySearch) Speed(min, max float64) *ActivitySearch {
+	s.speed = valueRange{min, max}
+	return s
+}
+
+// Gear restricts results to activities recorded with the given gear
+func (s *ActivitySearch) Gear(gearUUID string) *ActivitySearch {
+	s.gearUUID = gearUUID
+	return s
+}
+
+// Favorites restricts results to activities marked as favourite
+func (s *ActivitySearch) Favorites() *ActivitySearch {
+	s.favorites = true
+	return s
+}
+
+// NameMatches restricts results to activity names matching a regular
+// expression. An invalid expression is reported by Client.FindActivities.
+func (s *ActivitySearch) NameMatches(pattern string) *ActivitySearch {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		s.err = &errors.ValidationError{
+			GarthError: errors.GarthError{Message: "invalid name pattern", Cause: err},
+			Field:      "NameMatches",
+		}
+		return s
+	}
+	s.name = re
+	return s
+}
+
+// SortBy sets the sort field (one of the SortBy* constants) and order
+func (s *ActivitySearch) SortBy(field, order string) *ActivitySearch {
+	s.sortBy, s.sortOrder = field, order
+	return s
+}
+
+// Limit caps the number of activities returned; zero returns every match
+func (s *ActivitySearch) Limit(limit int) *ActivitySearch {
+	s.limit = limit
+	return s
+}
+
+// validate checks the search before any API call is made
+func (s *ActivitySearch) validate() error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.sortOrder != "" && s.sortOrder != SortAscending && s.sortOrder != SortDescending {
+		return &errors.ValidationError{
+			GarthError: errors.GarthError{Message: fmt.Sprintf("unknown sort order %q", s.sortOrder)},
+			Field:      "SortBy",
+		}
+	}
+	if _, ok := activitySortKeys[s.sortBy]; s.clientSorted() && !ok {
+		return &errors.ValidationError{
+			GarthError: errors.GarthError{Message: fmt.Sprintf("gear searches cannot sort by %q", s.sortBy)},
+			Field:      "SortBy",
+		}
+	}
+	if s.limit < 0 {
+		return &errors.ValidationError{
+			GarthError: errors.GarthError{Message: "limit cannot be negative"},
+			Field:      "Limit",
+		}
+	}
+	return nil
+}
+
+// path returns the list route; gear searches page through the gear's activities
+func (s *ActivitySearch) path() string {
+	if s.gearUUID != "" {
+		return fmt.Sprintf("/activitylist-service/activities/%s/gear", url.PathEscape(s.gearUUID))
+	}
+	return "/activitylist-service/activities/search/activities"
+}
+
+// params returns the server-side filters for one page
+func (s *ActivitySearch) params(start, limit int) url.Values {
+	params := url.Values{}
+	params.Set("start", strconv.Itoa(start))
+	params.Set("limit", strconv.Itoa(limit))
+	if s.gearUUID != "" {
+		// The gear route only pages; everything else is filtered and sorted
+		// client-side
+		return params
+	}
+
+	if s.query != "" {
+		params.Set("search", s.query)
+	}
+	if s.activityType != "" {
+		params.Set("activityType", s.activityType)
+		if s.excludeChildren {
+			params.Set("excludeChildren", "true")
+		}
+	}
+	if !s.from.IsZero() {
+		params.Set("startDate", s.from.Format("2006-01-02"))
+	}
+	if !s.to.IsZero() {
+		params.Set("endDate", s.to.Format("2006-01-02"))
+	}
+	setRange := func(minKey, maxKey string, r valueRange) {
+		if r.min != 0 {
+			params.Set(minKey, strconv.FormatFloat(r.min, 'f', -1, 64))
+		}
+		if r.max != 0 {
+			params.Set(maxKey, strconv.FormatFloat(r.max, 'f', -1, 64))
+		}
+	}
+	setRange("minDistance", "maxDistance", s.distance)
+	setRange("minDuration", "maxDuration", s.duration)
+	setRange("minElevation", "maxElevation", s.elevationGain)
+	if s.sortBy != "" {
+		params.Set("sortBy", s.sortBy)
+	}
+	if s.sortOrder != "" {
+		params.Set("sortOrder", s.sortOrder)
+	}
+	return params
+}
+
+// activityListItem is an activity list entry with the list-only fields
+type activityListItem struct {
+	Activity
+	Favorite bool `json:"favorite"`
+}
+
+// matches applies every filter to an activity. Server-side filters are checked
+// again so results are consistent when a route ignores a parameter. The type
+// catalog resolves child types; without it the type is left to the server.
+func (s *ActivitySearch) matches(a *activityListItem, types *ActivityTypeCatalog) bool {
+	if s.favorites && !a.Favorite {
+		return false
+	}
+	if s.name != nil && !s.name.MatchString(a.ActivityName) {
+		return false
+	}
+	if s.activityType != "" {
+		if types != nil && !s.excludeChildren {
+			if !types.IsA(a.Activity, s.activityType) {
+				return false
+			}
+		} else if s.excludeChildren && a.ActivityType.TypeKey != s.activityType {
+			return false
+		}
+	}
+	if day := a.StartTimeLocal.Format("2006-01-02"); !a.StartTimeLocal.IsZero() {
+		if !s.from.IsZero() && day < s.from.Format("2006-01-02") {
+			return false
+		}
+		if !s.to.IsZero() && day > s.to.Format("2006-01-02") {
+			return false
+		}
+	}
+	if s.query != "" && s.gearUUID != "" && !strings.Contains(strings.ToLower(a.ActivityName), strings.ToLower(s.query)) {
+		return false
+	}
+	return s.distance.contains(a.Distance) &&
+		s.duration.contains(a.Duration) &&
+		s.elevationGain.contains(a.ElevationGain) &&
+		s.averageHR.contains(a.AverageHR) &&
+		s.speed.contains(a.AverageSpeed)
+}
+
+// FindActivities runs an activity search, paginating until the limit is
+// reached or the server has no more activities. Sorted gear searches read
+// every page, since the gear route cannot sort. It returns an empty slice
+// when nothing matches.
+func (c *Client) FindActivities(ctx context.Context, search *ActivitySearch) ([]Activity, error) {
+	if err := search.validate(); err != nil {
+		return nil, err
+	}
+
+	// The gear route cannot filter by type, so child types are resolved here
+	var types *ActivityTypeCatalog
+	if search.gearUUID != "" && search.activityType != "" && !search.excludeChildren {
+		var err error
+		if types, err = c.GetActivityTypes(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	pageSize := searchPageSize
+	if search.limit > 0 && search.limit < pageSize && !search.clientFiltered() {
+		pageSize = search.limit
+	}
+
+	results := []Activity{}
+	for start := 0; ; start += pageSize {
+		data, err := c.Client.ConnectAPIWithContext(ctx, search.path(), "GET", search.params(start, pageSize), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search activities: %w", err)
+		}
+		if len(data) == 0 {
+			break
+		}
+
+		var page []activityListItem
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse search response: %w", err)
+		}
+		for i := range page {
+			if !search.matches(&page[i], types) {
+				continue
+			}
+			results = append(results, page[i].Activity)
+			if search.limit > 0 && len(results) == search.limit && !search.clientSorted() {
+				return results, nil
+			}
+		}
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	if search.clientSorted() {
+		search.sortResults(results)
+		if search.limit > 0 && len(results) > search.limit {
+			results = results[:search.limit]
+		}
+	}
+	return results, nil
+}
+
+// sortResults orders activities by the sort field, descending unless ascending was
+// requested
+func (s *ActivitySearch) sortResults(activities []Activity) {
+	key := activitySortKeys[s.sortBy]
+	sort.SliceStable(activities, func(i, j int) bool {
+		if s.sortOrder == SortAscending {
+			return key(&activities[i]) < key(&activities[j])
+		}
+		return key(&activities[i]) > key(&activities[j])
+	})
+}
+
+// clientFiltered reports whether any filter is applied only client-side, in
+// which case pages may yield fewer matches than requested
+func (s *ActivitySearch) clientFiltered() bool {
+	return s.gearUUID != "" || s.favorites || s.name != nil || s.averageHR.set() || s.speed.set()
+}
+
+// clientSorted reports whether results must be sorted after filtering
+func (s *ActivitySearch) clientSorted() bool {
+	return s.gearUUID != "" && s.sortBy != ""
+}
@@ -0,0 +1,135 @@
+package garmin_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// activityPage returns list entries start..start+limit of a 250 activity
+// history; every third activity is a favourite and every fifth a tempo run
+func activityPage(start, limit int) string {
+	body := "["
+	for i := start; i < start+limit && i < 250; i++ {
+		if i > start {
+			body += ","
+		}
+		name := "Easy run"
+		if i%5 == 0 {
+			name = "Tempo run"
+		}
+		body += fmt.Sprintf(`{"activityId": %d, "activityName": %q, "startTimeLocal": "2025-03-02 07:00:00",
+			"activityType": {"typeId": 1, "typeKey": "running"}, "distance": %d, "duration": 1800,
+			"averageHR": %d, "averageSpeed": 3, "favorite": %t}`, i, name, 1000*(i%20), 120+i%40, i%3 == 0)
+	}
+	return body + "]"
+}
+
+func TestFindActivities(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		switch r.URL.Path {
+		case "/activitylist-service/activities/search/activities", "/activitylist-service/activities/shoe-1/gear":
+			w.Write([]byte(activityPage(start, limit)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	t.Run("server-side filters", func(t *testing.T) {
+		queries = nil
+		results, err := c.FindActivities(ctx, garmin.NewActivitySearch().
+			Type("running", false).
+			Distance(5000, 10000).
+			Duration(0, time.Hour).
+			Between(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)).
+			SortBy(garmin.SortByDistance, garmin.SortDescending).
+			Limit(10))
+		require.NoError(t, err)
+		assert.Len(t, results, 10)
+		for _, a := range results {
+			assert.GreaterOrEqual(t, a.Distance, 5000.0)
+			assert.LessOrEqual(t, a.Distance, 10000.0)
+		}
+
+		q := queries[0]
+		assert.Equal(t, "running", q.Get("activityType"))
+		assert.Equal(t, "5000", q.Get("minDistance"))
+		assert.Equal(t, "3600", q.Get("maxDuration"))
+		assert.Empty(t, q.Get("minDuration"))
+		assert.Equal(t, "2025-03-01", q.Get("startDate"))
+		assert.Equal(t, "distance", q.Get("sortBy"))
+		assert.Equal(t, "desc", q.Get("sortOrder"))
+	})
+
+	t.Run("client-side filters paginate", func(t *testing.T) {
+		queries = nil
+		results, err := c.FindActivities(ctx, garmin.NewActivitySearch().
+			Favorites().
+			NameMatches(`^Tempo`).
+			AverageHR(0, 150))
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		for _, a := range results {
+			assert.Zero(t, a.ActivityID%15)
+			assert.LessOrEqual(t, a.AverageHR, 150.0)
+		}
+		assert.Len(t, queries, 3)
+		assert.Equal(t, "200", queries[2].Get("start"))
+	})
+
+	t.Run("gear", func(t *testing.T) {
+		queries = nil
+		results, err := c.FindActivities(ctx, garmin.NewActivitySearch().Gear("shoe-1").Distance(19000, 0).Limit(3))
+		require.NoError(t, err)
+		assert.Len(t, results, 3)
+		assert.Equal(t, int64(19), results[0].ActivityID)
+		assert.Empty(t, queries[0].Get("minDistance"))
+	})
+
+	t.Run("gear sorted client-side", func(t *testing.T) {
+		queries = nil
+		results, err := c.FindActivities(ctx, garmin.NewActivitySearch().Gear("shoe-1").
+			SortBy(garmin.SortByAverageHR, garmin.SortDescending).Limit(3))
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		for _, a := range results {
+			assert.Equal(t, 159.0, a.AverageHR)
+		}
+		assert.Len(t, queries, 3, "every page is read before sorting")
+		assert.Empty(t, queries[0].Get("sortBy"))
+
+		_, err = c.FindActivities(ctx, garmin.NewActivitySearch().Gear("shoe-1").SortBy("calories", ""))
+		var invalid *garmin.ValidationError
+		assert.ErrorAs(t, err, &invalid)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		results, err := c.FindActivities(ctx, garmin.NewActivitySearch().NameMatches(`^Long`))
+		require.NoError(t, err)
+		assert.NotNil(t, results)
+		assert.Empty(t, results)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		_, err := c.FindActivities(ctx, garmin.NewActivitySearch().NameMatches(`(`))
+		var invalid *garmin.ValidationError
+		assert.ErrorAs(t, err, &invalid)
+
+		_, err = c.FindActivities(ctx, garmin.NewActivitySearch().SortBy(garmin.SortByDistance, "sideways"))
+		assert.ErrorAs(t, err, &invalid)
+	})
+}
@@ -0,0 +1,253 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ActivityTypeCatalog is the hierarchy of activity types and the list of event
+// types known to Garmin Connect. Types form a forest: top-level sports such as
+// running or cycling have child types such as trail_running or road_biking.
+type ActivityTypeCatalog struct {
+	Types      []ActivityType
+	EventTypes []EventType
+
+	byKey    map[string]ActivityType
+	byID     map[int]ActivityType
+	children map[int][]ActivityType
+}
+
+// displayNames overrides the names derived from type keys where the derived
+// name would read poorly
+var displayNames = map[string]string{
+	"hiit":                "HIIT",
+	"e_bike_fitness":      "eBike Fitness",
+	"e_bike_mountain":     "eBike Mountain",
+	"lap_swimming":        "Pool Swimming",
+	"virtual_ride":        "Virtual Cycling",
+	"indoor_cardio":       "Cardio",
+	"open_water_swimming": "Open Water Swimming",
+}
+
+// NewActivityTypeCatalog indexes activity and event types
+func NewActivityTypeCatalog(types []ActivityType, eventTypes []EventType) *ActivityTypeCatalog {
+	cat := &ActivityTypeCatalog{
+		Types:      types,
+		EventTypes: eventTypes,
+		byKey:      make(map[string]ActivityType, len(types)),
+		byID:       make(map[int]ActivityType, len(types)),
+		children:   make(map[int][]ActivityType),
+	}
+	for _, t := range types {
+		cat.byKey[t.TypeKey] = t
+		cat.byID[t.TypeID] = t
+		if t.ParentTypeID != nil && *t.ParentTypeID != t.TypeID {
+			cat.children[*t.ParentTypeID] = append(cat.children[*t.ParentTypeID], t)
+		}
+	}
+	for id := range cat.children {
+		sortTypes(cat.children[id])
+	}
+	return cat
+}
+
+// Lookup returns the activity type with the given key
+func (cat *ActivityTypeCatalog) Lookup(typeKey string) (ActivityType, bool) {
+	t, ok := cat.byKey[typeKey]
+	return t, ok
+}
+
+// LookupID returns the activity type with the given ID
+func (cat *ActivityTypeCatalog) LookupID(typeID int) (ActivityType, bool) {
+	t, ok := cat.byID[typeID]
+	return t, ok
+}
+
+// EventType returns the event type with the given key
+func (cat *ActivityTypeCatalog) EventType(typeKey string) (EventType, bool) {
+	for _, e := range cat.EventTypes {
+		if e.TypeKey == typeKey {
+			return e, true
+		}
+	}
+	return EventType{}, false
+}
+
+// Parent returns the parent of a type, or false for a top-level type
+func (cat *ActivityTypeCatalog) Parent(typeKey string) (ActivityType, bool) {
+	t, ok := cat.byKey[typeKey]
+	if !ok || t.ParentTypeID == nil || *t.ParentTypeID == t.TypeID {
+		return ActivityType{}, false
+	}
+	parent, ok := cat.byID[*t.ParentTypeID]
+	return parent, ok
+}
+
+// Ancestors returns the parents of a type, nearest first
+func (cat *ActivityTypeCatalog) Ancestors(typeKey string) []ActivityType {
+	var ancestors []ActivityType
+	seen := map[string]bool{typeKey: true}
+	for {
+		parent, ok := cat.Parent(typeKey)
+		if !ok || seen[parent.TypeKey] {
+			return ancestors
+		}
+		ancestors = append(ancestors, parent)
+		seen[parent.TypeKey] = true
+		typeKey = parent.TypeKey
+	}
+}
+
+// Children returns the direct children of a type, sorted by key
+func (cat *ActivityTypeCatalog) Children(typeKey string) []ActivityType {
+	t, ok := cat.byKey[typeKey]
+	if !ok {
+		return nil
+	}
+	return cat.children[t.TypeID]
+}
+
+// Descendants returns every type below a type, depth first
+func (cat *ActivityTypeCatalog) Descendants(typeKey string) []ActivityType {
+	var out []ActivityType
+	for _, child := range cat.Children(typeKey) {
+		out = append(out, child)
+		out = append(out, cat.Descendants(child.TypeKey)...)
+	}
+	return out
+}
+
+// Roots returns the top-level types, sorted by key
+func (cat *ActivityTypeCatalog) Roots() []ActivityType {
+	var roots []ActivityType
+	for _, t := range cat.Types {
+		if _, ok := cat.Parent(t.TypeKey); !ok {
+			roots = append(roots, t)
+		}
+	}
+	sortTypes(roots)
+	return roots
+}
+
+// TopLevel returns the top-level sport a type belongs to. Unknown keys are
+// returned as their own top level.
+func (cat *ActivityTypeCatalog) TopLevel(typeKey string) ActivityType {
+	if ancestors := cat.Ancestors(typeKey); len(ancestors) > 0 {
+		return ancestors[len(ancestors)-1]
+	}
+	if t, ok := cat.byKey[typeKey]; ok {
+		return t
+	}
+	return ActivityType{TypeKey: typeKey}
+}
+
+// IsType reports whether typeKey is ancestorKey or one of its descendants
+func (cat *ActivityTypeCatalog) IsType(typeKey, ancestorKey string) bool {
+	if typeKey == ancestorKey {
+		return true
+	}
+	for _, a := range cat.Ancestors(typeKey) {
+		if a.TypeKey == ancestorKey {
+			return true
+		}
+	}
+	return false
+}
+
+// IsA reports whether an activity is of the given type or one of its children,
+// e.g. IsA(trailRun, "running")
+func (cat *ActivityTypeCatalog) IsA(activity Activity, typeKey string) bool {
+	return activity.ActivityType.TypeKey == typeKey || cat.IsType(cat.resolve(activity.ActivityType), typeKey)
+}
+
+// resolve returns the catalog key for an activity's type. Types missing from
+// the catalog fall back to the parent ID carried by the activity.
+func (cat *ActivityTypeCatalog) resolve(at ActivityType) string {
+	if _, ok := cat.byKey[at.TypeKey]; ok || at.ParentTypeID == nil {
+		return at.TypeKey
+	}
+	if parent, ok := cat.byID[*at.ParentTypeID]; ok {
+		return parent.TypeKey
+	}
+	return at.TypeKey
+}
+
+// Walk visits every type depth first from the roots; depth is 0 for top-level
+// types. Returning false from fn skips the type's children.
+func (cat *ActivityTypeCatalog) Walk(fn func(t ActivityType, depth int) bool) {
+	var visit func(t ActivityType, depth int)
+	visit = func(t ActivityType, depth int) {
+		if !fn(t, depth) {
+			return
+		}
+		for _, child := range cat.children[t.TypeID] {
+			visit(child, depth+1)
+		}
+	}
+	for _, root := range cat.Roots() {
+		visit(root, 0)
+	}
+}
+
+// GroupBySport groups activities by the key of their top-level sport
+func (cat *ActivityTypeCatalog) GroupBySport(activities []Activity) map[string][]Activity {
+	groups := make(map[string][]Activity)
+	for _, a := range activities {
+		sport := cat.TopLevel(cat.resolve(a.ActivityType)).TypeKey
+		groups[sport] = append(groups[sport], a)
+	}
+	return groups
+}
+
+// DisplayName returns a human-readable name for an activity or event type key,
+// e.g. "Trail Running" for trail_running
+func DisplayName(typeKey string) string {
+	if name, ok := displayNames[typeKey]; ok {
+		return name
+	}
+	words := strings.Fields(strings.ReplaceAll(typeKey, "_", " "))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// GetActivityTypes retrieves the activity and event type catalogs. The result
+// is cached for the lifetime of the client.
+func (c *Client) GetActivityTypes(ctx context.Context) (*ActivityTypeCatalog, error) {
+	c.activityTypesMu.Lock()
+	defer c.activityTypesMu.Unlock()
+	if c.activityTypes != nil {
+		return c.activityTypes, nil
+	}
+
+	var types []ActivityType
+	if err := c.getCatalog(ctx, "/activity-service/activity/activityTypes", &types); err != nil {
+		return nil, fmt.Errorf("failed to get activity types: %w", err)
+	}
+	var eventTypes []EventType
+	if err := c.getCatalog(ctx, "/activity-service/activity/eventTypes", &eventTypes); err != nil {
+		return nil, fmt.Errorf("failed to get event types: %w", err)
+	}
+
+	c.activityTypes = NewActivityTypeCatalog(types, eventTypes)
+	return c.activityTypes, nil
+}
+
+func (c *Client) getCatalog(ctx context.Context, path string, v interface{}) error {
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+func sortTypes(types []ActivityType) {
+	sort.Slice(types, func(i, j int) bool { return types[i].TypeKey < types[j].TypeKey })
+}
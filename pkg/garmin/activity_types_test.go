@@ -0,0 +1,98 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const activityTypesBody = `[
+	{"typeId": 1, "typeKey": "running", "parentTypeId": 17},
+	{"typeId": 6, "typeKey": "trail_running", "parentTypeId": 1},
+	{"typeId": 7, "typeKey": "street_running", "parentTypeId": 1},
+	{"typeId": 2, "typeKey": "cycling", "parentTypeId": 17},
+	{"typeId": 10, "typeKey": "road_biking", "parentTypeId": 2},
+	{"typeId": 17, "typeKey": "all", "parentTypeId": 17}
+]`
+
+func TestGetActivityTypes(t *testing.T) {
+	calls := 0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/activity-service/activity/activityTypes":
+			w.Write([]byte(activityTypesBody))
+		case "/activity-service/activity/eventTypes":
+			w.Write([]byte(`[{"typeId": 9, "typeKey": "uncategorized"}, {"typeId": 1, "typeKey": "race"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	cat, err := c.GetActivityTypes(context.Background())
+	require.NoError(t, err)
+	_, err = c.GetActivityTypes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "catalog should be cached")
+
+	trail := garmin.Activity{ActivityType: garmin.ActivityType{TypeID: 6, TypeKey: "trail_running"}}
+	assert.True(t, cat.IsA(trail, "running"))
+	assert.True(t, cat.IsA(trail, "all"))
+	assert.False(t, cat.IsA(trail, "cycling"))
+
+	parent := 2
+	unknown := garmin.Activity{ActivityType: garmin.ActivityType{TypeID: 99, TypeKey: "gravel_cycling", ParentTypeID: &parent}}
+	assert.True(t, cat.IsA(unknown, "cycling"))
+
+	assert.Equal(t, "all", cat.TopLevel("trail_running").TypeKey)
+	assert.Equal(t, []string{"street_running", "trail_running"}, typeKeys(cat.Children("running")))
+	assert.Len(t, cat.Descendants("all"), 5)
+	assert.Equal(t, []string{"all"}, typeKeys(cat.Roots()))
+
+	race, ok := cat.EventType("race")
+	require.True(t, ok)
+	assert.Equal(t, 1, race.TypeID)
+
+	var depths []int
+	cat.Walk(func(t garmin.ActivityType, depth int) bool {
+		depths = append(depths, depth)
+		return t.TypeKey != "cycling"
+	})
+	assert.Equal(t, []int{0, 1, 1, 2, 2}, depths)
+
+	assert.Equal(t, "Trail Running", garmin.DisplayName("trail_running"))
+	assert.Equal(t, "HIIT", garmin.DisplayName("hiit"))
+}
+
+func TestActivityTypeCatalog_GroupBySport(t *testing.T) {
+	running, cycling, all := 1, 2, 17
+	cat := garmin.NewActivityTypeCatalog([]garmin.ActivityType{
+		{TypeID: 1, TypeKey: "running", ParentTypeID: &all},
+		{TypeID: 6, TypeKey: "trail_running", ParentTypeID: &running},
+		{TypeID: 2, TypeKey: "cycling", ParentTypeID: &all},
+		{TypeID: 10, TypeKey: "road_biking", ParentTypeID: &cycling},
+	}, nil)
+
+	groups := cat.GroupBySport([]garmin.Activity{
+		{ActivityID: 1, ActivityType: garmin.ActivityType{TypeKey: "trail_running"}},
+		{ActivityID: 2, ActivityType: garmin.ActivityType{TypeKey: "running"}},
+		{ActivityID: 3, ActivityType: garmin.ActivityType{TypeKey: "road_biking"}},
+		{ActivityID: 4, ActivityType: garmin.ActivityType{TypeKey: "yoga"}},
+	})
+	assert.Len(t, groups["running"], 2)
+	assert.Len(t, groups["cycling"], 1)
+	assert.Len(t, groups["yoga"], 1)
+}
+
+func typeKeys(types []garmin.ActivityType) []string {
+	keys := make([]string, len(types))
+	for i, t := range types {
+		keys[i] = t.TypeKey
+	}
+	return keys
+}
@@ -0,0 +1,276 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// BloodPressureCategory classifies a reading using the American Heart
+// Association ranges, as Garmin Connect does
+type BloodPressureCategory string
+
+// Blood pressure categories, in increasing severity
+const (
+	BloodPressureNormal   BloodPressureCategory = "NORMAL"
+	BloodPressureElevated BloodPressureCategory = "ELEVATED"
+	BloodPressureStage1   BloodPressureCategory = "STAGE_1_HIGH"
+	BloodPressureStage2   BloodPressureCategory = "STAGE_2_HIGH"
+	BloodPressureCrisis   BloodPressureCategory = "HIGH_CRISIS"
+)
+
+// bloodPressureSeverity orders categories for comparison
+var bloodPressureSeverity = map[BloodPressureCategory]int{
+	BloodPressureNormal:   0,
+	BloodPressureElevated: 1,
+	BloodPressureStage1:   2,
+	BloodPressureStage2:   3,
+	BloodPressureCrisis:   4,
+}
+
+// ClassifyBloodPressure returns the category of a reading in mmHg. The more
+// severe of the systolic and diastolic categories applies.
+func ClassifyBloodPressure(systolic, diastolic int) BloodPressureCategory {
+	switch {
+	case systolic > 180 || diastolic > 120:
+		return BloodPressureCrisis
+	case systolic >= 140 || diastolic >= 90:
+		return BloodPressureStage2
+	case systolic >= 130 || diastolic >= 80:
+		return BloodPressureStage1
+	case systolic >= 120:
+		return BloodPressureElevated
+	}
+	return BloodPressureNormal
+}
+
+// Severity returns the rank of the category, 0 for normal; unknown
+// categories rank -1
+func (c BloodPressureCategory) Severity() int {
+	if s, ok := bloodPressureSeverity[c]; ok {
+		return s
+	}
+	return -1
+}
+
+// String returns a readable category name
+func (c BloodPressureCategory) String() string {
+	switch c {
+	case BloodPressureNormal:
+		return "Normal"
+	case BloodPressureElevated:
+		return "Elevated"
+	case BloodPressureStage1:
+		return "High: Stage 1"
+	case BloodPressureStage2:
+		return "High: Stage 2"
+	case BloodPressureCrisis:
+		return "Hypertensive Crisis"
+	}
+	return string(c)
+}
+
+// BloodPressure is a single blood pressure reading in mmHg
+type BloodPressure struct {
+	Version        int64 // Identifies the reading for DeleteBloodPressure
+	Systolic       int
+	Diastolic      int
+	Pulse          int // Beats per minute; zero when not measured
+	Timestamp      time.Time
+	TimestampLocal time.Time
+	Notes          string
+	SourceType     string // e.g. "MANUAL"
+	Category       BloodPressureCategory
+}
+
+// BloodPressureSummary summarizes the readings of a day
+type BloodPressureSummary struct {
+	Date             string // YYYY-MM-DD
+	Count            int
+	HighSystolic     int
+	LowSystolic      int
+	HighDiastolic    int
+	LowDiastolic     int
+	AverageSystolic  float64
+	AverageDiastolic float64
+	Category         BloodPressureCategory // Category of the average reading
+}
+
+// BloodPressureReadings holds the readings of a date range with daily summaries
+type BloodPressureReadings struct {
+	Measurements []BloodPressure        // Oldest first
+	Daily        []BloodPressureSummary // Oldest first
+}
+
+// bloodPressureDTO mirrors a bloodpressure-service measurement
+type bloodPressureDTO struct {
+	Version                   int64      `json:"version"`
+	Systolic                  int        `json:"systolic"`
+	Diastolic                 int        `json:"diastolic"`
+	Pulse                     int        `json:"pulse"`
+	MeasurementTimestampGMT   GarminTime `json:"measurementTimestampGMT"`
+	MeasurementTimestampLocal GarminTime `json:"measurementTimestampLocal"`
+	Notes                     string     `json:"notes"`
+	SourceType                string     `json:"sourceType"`
+	Category                  string     `json:"category"`
+}
+
+// GetBloodPressure retrieves the blood pressure readings between two dates,
+// inclusive. Readings without a category from the server are classified
+// client-side.
+func (c *Client) GetBloodPressure(ctx context.Context, startDate, endDate time.Time) (*BloodPressureReadings, error) {
+	path := fmt.Sprintf("/bloodpressure-service/bloodpressure/range/%s/%s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	params := url.Values{}
+	params.Set("includeAll", "true")
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blood pressure: %w", err)
+	}
+
+	result := &BloodPressureReadings{}
+	if len(data) == 0 {
+		return result, nil
+	}
+
+	var response struct {
+		MeasurementSummaries []struct {
+			StartDate    string             `json:"startDate"`
+			Measurements []bloodPressureDTO `json:"measurements"`
+		} `json:"measurementSummaries"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse blood pressure response: %w", err)
+	}
+
+	for _, day := range response.MeasurementSummaries {
+		var readings []BloodPressure
+		for _, m := range day.Measurements {
+			bp := BloodPressure{
+				Version:        m.Version,
+				Systolic:       m.Systolic,
+				Diastolic:      m.Diastolic,
+				Pulse:          m.Pulse,
+				Timestamp:      m.MeasurementTimestampGMT.Time,
+				TimestampLocal: m.MeasurementTimestampLocal.Time,
+				Notes:          m.Notes,
+				SourceType:     m.SourceType,
+				Category:       BloodPressureCategory(m.Category),
+			}
+			if bp.Category == "" {
+				bp.Category = ClassifyBloodPressure(bp.Systolic, bp.Diastolic)
+			}
+			readings = append(readings, bp)
+		}
+		if len(readings) == 0 {
+			continue
+		}
+		date := day.StartDate
+		if date == "" {
+			date = readings[0].TimestampLocal.Format("2006-01-02")
+		}
+		result.Measurements = append(result.Measurements, readings...)
+		result.Daily = append(result.Daily, summarizeBloodPressure(date, readings))
+	}
+
+	sort.Slice(result.Measurements, func(i, j int) bool {
+		return result.Measurements[i].Timestamp.Before(result.Measurements[j].Timestamp)
+	})
+	sort.Slice(result.Daily, func(i, j int) bool { return result.Daily[i].Date < result.Daily[j].Date })
+	return result, nil
+}
+
+// summarizeBloodPressure summarizes a non-empty day of readings
+func summarizeBloodPressure(date string, readings []BloodPressure) BloodPressureSummary {
+	s := BloodPressureSummary{
+		Date:          date,
+		Count:         len(readings),
+		HighSystolic:  readings[0].Systolic,
+		LowSystolic:   readings[0].Systolic,
+		HighDiastolic: readings[0].Diastolic,
+		LowDiastolic:  readings[0].Diastolic,
+	}
+	for _, r := range readings {
+		s.HighSystolic = max(s.HighSystolic, r.Systolic)
+		s.LowSystolic = min(s.LowSystolic, r.Systolic)
+		s.HighDiastolic = max(s.HighDiastolic, r.Diastolic)
+		s.LowDiastolic = min(s.LowDiastolic, r.Diastolic)
+		s.AverageSystolic += float64(r.Systolic)
+		s.AverageDiastolic += float64(r.Diastolic)
+	}
+	s.AverageSystolic /= float64(len(readings))
+	s.AverageDiastolic /= float64(len(readings))
+	s.Category = ClassifyBloodPressure(int(s.AverageSystolic+0.5), int(s.AverageDiastolic+0.5))
+	return s
+}
+
+// NewBloodPressure describes a blood pressure reading to record
+type NewBloodPressure struct {
+	Time      time.Time // Defaults to now
+	Systolic  int
+	Diastolic int
+	Pulse     int // Optional
+	Notes     string
+}
+
+// validate checks the reading before any API call is made
+func (bp *NewBloodPressure) validate() error {
+	if bp.Systolic < 50 || bp.Systolic > 300 {
+		return validationError("Systolic", "systolic pressure must be between 50 and 300 mmHg")
+	}
+	if bp.Diastolic < 30 || bp.Diastolic > 200 {
+		return validationError("Diastolic", "diastolic pressure must be between 30 and 200 mmHg")
+	}
+	if bp.Diastolic >= bp.Systolic {
+		return validationError("Diastolic", "diastolic pressure must be below systolic")
+	}
+	if bp.Pulse != 0 && (bp.Pulse < 20 || bp.Pulse > 250) {
+		return validationError("Pulse", "pulse must be between 20 and 250 bpm")
+	}
+	return nil
+}
+
+// AddBloodPressure records a blood pressure reading
+func (c *Client) AddBloodPressure(ctx context.Context, reading NewBloodPressure) error {
+	if err := reading.validate(); err != nil {
+		return err
+	}
+	if reading.Time.IsZero() {
+		reading.Time = time.Now()
+	}
+
+	payload := map[string]interface{}{
+		"measurementTimestampLocal": reading.Time.Format("2006-01-02T15:04:05.000"),
+		"measurementTimestampGMT":   reading.Time.UTC().Format("2006-01-02T15:04:05.000"),
+		"systolic":                  reading.Systolic,
+		"diastolic":                 reading.Diastolic,
+		"sourceType":                "MANUAL",
+		"notes":                     reading.Notes,
+	}
+	if reading.Pulse != 0 {
+		payload["pulse"] = reading.Pulse
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode blood pressure: %w", err)
+	}
+
+	if _, err := c.Client.ConnectAPIWithContext(ctx, "/bloodpressure-service/bloodpressure", "POST", nil, bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("failed to add blood pressure: %w", err)
+	}
+	return nil
+}
+
+// DeleteBloodPressure deletes a reading, identified by its local date and version
+func (c *Client) DeleteBloodPressure(ctx context.Context, date time.Time, version int64) error {
+	path := fmt.Sprintf("/bloodpressure-service/bloodpressure/%s/%d", date.Format("2006-01-02"), version)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "DELETE", nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to delete blood pressure: %w", err), "blood pressure", fmt.Sprint(version))
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package garmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyBloodPressure(t *testing.T) {
+	tests := []struct {
+		systolic, diastolic int
+		want                garmin.BloodPressureCategory
+	}{
+		{115, 75, garmin.BloodPressureNormal},
+		{125, 78, garmin.BloodPressureElevated},
+		{118, 82, garmin.BloodPressureStage1},
+		{135, 70, garmin.BloodPressureStage1},
+		{128, 92, garmin.BloodPressureStage2},
+		{190, 100, garmin.BloodPressureCrisis},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, garmin.ClassifyBloodPressure(tt.systolic, tt.diastolic), "%d/%d", tt.systolic, tt.diastolic)
+	}
+	assert.Greater(t, garmin.BloodPressureStage2.Severity(), garmin.BloodPressureStage1.Severity())
+	assert.Equal(t, "High: Stage 1", garmin.BloodPressureStage1.String())
+}
+
+func TestBloodPressure(t *testing.T) {
+	var posted map[string]interface{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /bloodpressure-service/bloodpressure/range/2025-03-01/2025-03-02":
+			w.Write([]byte(`{"measurementSummaries": [
+				{"startDate": "2025-03-02", "measurements": [
+					{"version": 12, "systolic": 142, "diastolic": 88, "pulse": 70,
+					 "measurementTimestampGMT": "2025-03-02T19:00:00.0", "measurementTimestampLocal": "2025-03-02T20:00:00.0"}]},
+				{"startDate": "2025-03-01", "measurements": [
+					{"version": 11, "systolic": 128, "diastolic": 82, "pulse": 64, "notes": "after coffee", "category": "STAGE_1_HIGH",
+					 "measurementTimestampGMT": "2025-03-01T18:00:00.0", "measurementTimestampLocal": "2025-03-01T19:00:00.0"},
+					{"version": 10, "systolic": 118, "diastolic": 76, "pulse": 60,
+					 "measurementTimestampGMT": "2025-03-01T06:00:00.0", "measurementTimestampLocal": "2025-03-01T07:00:00.0"}]}]}`))
+		case "POST /bloodpressure-service/bloodpressure":
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&posted)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{}`))
+		case "DELETE /bloodpressure-service/bloodpressure/2025-03-01/11":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	readings, err := c.GetBloodPressure(ctx, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, readings.Measurements, 3)
+	assert.Equal(t, int64(10), readings.Measurements[0].Version)
+	assert.Equal(t, garmin.BloodPressureNormal, readings.Measurements[0].Category)
+	assert.Equal(t, "after coffee", readings.Measurements[1].Notes)
+	assert.Equal(t, garmin.BloodPressureStage2, readings.Measurements[2].Category)
+	assert.Equal(t, 20, readings.Measurements[2].TimestampLocal.Hour())
+
+	require.Len(t, readings.Daily, 2)
+	day := readings.Daily[0]
+	assert.Equal(t, "2025-03-01", day.Date)
+	assert.Equal(t, 2, day.Count)
+	assert.Equal(t, 128, day.HighSystolic)
+	assert.Equal(t, 76, day.LowDiastolic)
+	assert.Equal(t, 123.0, day.AverageSystolic)
+	assert.Equal(t, garmin.BloodPressureElevated, day.Category)
+
+	at := time.Date(2025, 3, 3, 7, 30, 0, 0, time.UTC)
+	require.NoError(t, c.AddBloodPressure(ctx, garmin.NewBloodPressure{Time: at, Systolic: 121, Diastolic: 79, Pulse: 58, Notes: "cuff"}))
+	assert.Equal(t, float64(121), posted["systolic"])
+	assert.Equal(t, float64(58), posted["pulse"])
+	assert.Equal(t, "2025-03-03T07:30:00.000", posted["measurementTimestampGMT"])
+
+	var invalid *garmin.ValidationError
+	assert.ErrorAs(t, c.AddBloodPressure(ctx, garmin.NewBloodPressure{Systolic: 80, Diastolic: 90}), &invalid)
+
+	require.NoError(t, c.DeleteBloodPressure(ctx, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 11))
+}
@@ -0,0 +1,59 @@
+package garmin
+
+import (
+	"context"
+	"time"
+
+	"github.com/sstent/go-garth/internal/data"
+)
+
+// BodyBatteryTimeline is a continuous Body Battery series with charge and
+// drain totals, peak and trough, sleep charge and data gaps
+type BodyBatteryTimeline = data.BodyBatteryTimeline
+
+// BodyBatteryPoint is a Body Battery level at a point in time
+type BodyBatteryPoint = data.BodyBatteryPoint
+
+// BodyBatteryEventImpact is the charge and drain measured during an event
+type BodyBatteryEventImpact = data.BodyBatteryEventImpact
+
+// DrainAttribution splits drained Body Battery between activities, stress
+// and everything else
+type DrainAttribution = data.DrainAttribution
+
+// TimeWindow is a span of time
+type TimeWindow = data.TimeWindow
+
+// NewBodyBatteryTimeline builds the timeline of a day of Body Battery data,
+// such as the one returned by GetBodyBatteryData
+func NewBodyBatteryTimeline(day *DetailedBodyBatteryData) *BodyBatteryTimeline {
+	return data.NewBodyBatteryTimeline(data.ParseBodyBatteryReadings(day.BodyBatteryValuesArray), day.Events)
+}
+
+// GetBodyBatteryTimeline retrieves the Body Battery readings and events
+// between two dates, inclusive, as one continuous timeline
+func (c *Client) GetBodyBatteryTimeline(ctx context.Context, startDate, endDate time.Time) (*BodyBatteryTimeline, error) {
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	var days []*BodyBatteryTimeline
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		bb, err := c.Client.GetDetailedBodyBatteryDataWithContext(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, NewBodyBatteryTimeline(bb))
+	}
+	return data.MergeBodyBatteryTimelines(days...), nil
+}
+
+// StressWindows returns the time windows of stress periods, for use with
+// BodyBatteryTimeline.AttributeDrain
+func StressWindows(periods []StressPeriod) []TimeWindow {
+	windows := make([]TimeWindow, len(periods))
+	for i, p := range periods {
+		windows[i] = TimeWindow{Start: p.Start, End: p.End}
+	}
+	return windows
+}
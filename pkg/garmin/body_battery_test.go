@@ -0,0 +1,57 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBodyBatteryTimeline(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wellness-service/wellness/dailyStress/2025-03-01":
+			w.Write([]byte(`{"calendarDate": "2025-03-01", "startTimestampGMT": "2025-03-01T00:00:00.0",
+				"bodyBatteryValuesArray": [[1740870000000, "MEASURED", 30, 2.0], [1740870180000, "MEASURED", 32, 2.0]]}`))
+		case "/wellness-service/wellness/dailyStress/2025-03-02":
+			w.Write([]byte(`{"calendarDate": "2025-03-02",
+				"bodyBatteryValuesArray": [[1740870180000, "MEASURED", 32, 2.0], [1740870360000, "MEASURED", 35, 2.0],
+					[1740870540000, "MEASURED", 31, 2.0]]}`))
+		case "/wellness-service/wellness/bodyBattery/events/2025-03-01":
+			w.Write([]byte(`[{"event": {"eventType": "sleep", "eventStartTimeGmt": "2025-03-01T23:00:00.0",
+				"durationInMilliseconds": 28800000, "bodyBatteryImpact": 40}, "activityId": null}]`))
+		case "/wellness-service/wellness/bodyBattery/events/2025-03-02":
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	timeline, err := c.GetBodyBatteryTimeline(context.Background(),
+		time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, timeline.Points, 4, "readings repeated across days appear once")
+	assert.Equal(t, 35, timeline.Peak.Level)
+	assert.Equal(t, 30, timeline.Trough.Level)
+	assert.Equal(t, 5, timeline.Charged)
+	assert.Equal(t, 4, timeline.Drained)
+	assert.Equal(t, 5, timeline.SleepCharge)
+	assert.Empty(t, timeline.Gaps)
+	require.Len(t, timeline.Events, 1)
+	assert.Equal(t, 40, timeline.Events[0].BodyBatteryImpact)
+
+	drain := timeline.AttributeDrain(garmin.StressWindows([]garmin.StressPeriod{{
+		Start: time.Date(2025, 3, 1, 23, 5, 0, 0, time.UTC),
+		End:   time.Date(2025, 3, 1, 23, 30, 0, 0, time.UTC),
+	}}))
+	assert.Equal(t, 4, drain.Stress)
+
+	var invalid *garmin.ValidationError
+	_, err = c.GetBodyBatteryTimeline(context.Background(), time.Now(), time.Now().AddDate(0, 0, -1))
+	assert.ErrorAs(t, err, &invalid)
+}
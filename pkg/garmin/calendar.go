@@ -0,0 +1,210 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Calendar item types
+const (
+	CalendarItemActivity        = "activity"
+	CalendarItemWorkout         = "workout"
+	CalendarItemAdaptiveWorkout = "fbtAdaptiveWorkout" // Garmin Coach training plan workout
+	CalendarItemTrainingPlan    = "trainingPlan"
+	CalendarItemEvent           = "event" // Races and other events
+	CalendarItemNote            = "note"
+)
+
+// CalendarItem is an entry on the Garmin Connect training calendar
+type CalendarItem struct {
+	ID                  int64      `json:"id"` // Activity ID for activities, schedule ID for workouts
+	ItemType            string     `json:"itemType"`
+	Title               string     `json:"title"`
+	Date                GarminTime `json:"date"`
+	StartTimestampLocal GarminTime `json:"startTimestampLocal"`
+	ActivityTypeID      int        `json:"activityTypeId"`
+	Duration            float64    `json:"duration"` // Seconds
+	Distance            float64    `json:"distance"` // Meters
+	Calories            float64    `json:"calories"`
+	WorkoutID           *int64     `json:"workoutId"`
+	TrainingPlanID      *int64     `json:"trainingPlanId"`
+	IsRace              bool       `json:"isRace"`
+}
+
+// ActivityID returns the ID of the completed activity, if the item is one
+func (i CalendarItem) ActivityID() (int64, bool) {
+	return i.ID, i.ItemType == CalendarItemActivity
+}
+
+// URL returns the Garmin Connect page for the item, or "" if it has none
+func (i CalendarItem) URL() string {
+	switch {
+	case i.ItemType == CalendarItemActivity:
+		return fmt.Sprintf("https://connect.garmin.com/modern/activity/%d", i.ID)
+	case i.WorkoutID != nil:
+		return fmt.Sprintf("https://connect.garmin.com/modern/workout/%d", *i.WorkoutID)
+	}
+	return ""
+}
+
+// GetCalendar retrieves the calendar items of a month
+func (c *Client) GetCalendar(ctx context.Context, year int, month time.Month) ([]CalendarItem, error) {
+	// calendar-service months are zero-based
+	path := fmt.Sprintf("/calendar-service/year/%d/month/%d", year, int(month)-1)
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var response struct {
+		CalendarItems []CalendarItem `json:"calendarItems"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse calendar response: %w", err)
+	}
+	sortCalendarItems(response.CalendarItems)
+	return response.CalendarItems, nil
+}
+
+// GetCalendarWeek retrieves the calendar items of the Monday-to-Sunday week
+// containing date
+func (c *Client) GetCalendarWeek(ctx context.Context, date time.Time) ([]CalendarItem, error) {
+	y, m, d := date.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	end := start.AddDate(0, 0, 6)
+
+	items, err := c.GetCalendar(ctx, start.Year(), start.Month())
+	if err != nil {
+		return nil, err
+	}
+	if end.Month() != start.Month() {
+		next, err := c.GetCalendar(ctx, end.Year(), end.Month())
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, next...)
+	}
+
+	from, to := start.Format("2006-01-02"), end.Format("2006-01-02")
+	var week []CalendarItem
+	for _, item := range items {
+		if day := item.Date.Format("2006-01-02"); day >= from && day <= to {
+			week = append(week, item)
+		}
+	}
+	return week, nil
+}
+
+func sortCalendarItems(items []CalendarItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Date.Before(items[j].Date.Time)
+	})
+}
+
+// WriteICalendar writes calendar items as an iCalendar (RFC 5545) feed.
+// Items with a start time become timed events; the rest are all-day events.
+func WriteICalendar(w io.Writer, items []CalendarItem) error {
+	var b bytes.Buffer
+	line := func(s string) {
+		b.WriteString(foldICalLine(s))
+		b.WriteString("\r\n")
+	}
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//go-garth//Garmin Connect Calendar//EN")
+	line("CALSCALE:GREGORIAN")
+	for _, item := range items {
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:%s-%d@connect.garmin.com", item.ItemType, item.ID))
+		line("DTSTAMP:" + stamp)
+		if start := item.StartTimestampLocal.Time; !start.IsZero() && item.Duration > 0 {
+			// Floating local times, as the calendar stores them
+			line("DTSTART:" + start.Format("20060102T150405"))
+			line("DTEND:" + start.Add(time.Duration(item.Duration*float64(time.Second))).Format("20060102T150405"))
+		} else {
+			line("DTSTART;VALUE=DATE:" + item.Date.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + item.Date.AddDate(0, 0, 1).Format("20060102"))
+		}
+		line("SUMMARY:" + escapeICalText(item.Title))
+		if description := item.summary(); description != "" {
+			line("DESCRIPTION:" + escapeICalText(description))
+		}
+		line("CATEGORIES:" + escapeICalText(item.ItemType))
+		if url := item.URL(); url != "" {
+			line("URL:" + url)
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// ICalendar returns calendar items as an iCalendar feed
+func ICalendar(items []CalendarItem) []byte {
+	var b bytes.Buffer
+	// Writes to a bytes.Buffer cannot fail, so neither can WriteICalendar
+	_ = WriteICalendar(&b, items)
+	return b.Bytes()
+}
+
+// summary describes the distance, duration and calories of an item
+func (i CalendarItem) summary() string {
+	var parts []string
+	if i.IsRace {
+		parts = append(parts, "Race")
+	}
+	if i.Distance > 0 {
+		parts = append(parts, fmt.Sprintf("Distance: %.2f km", i.Distance/1000))
+	}
+	if i.Duration > 0 {
+		parts = append(parts, "Duration: "+(time.Duration(i.Duration)*time.Second).String())
+	}
+	if i.Calories > 0 {
+		parts = append(parts, fmt.Sprintf("Calories: %.0f kcal", i.Calories))
+	}
+	return strings.Join(parts, "\n")
+}
+
+// icalEscaper escapes TEXT values. CRLF and lone CR line breaks are escaped
+// like LF, as a raw CR would end the content line.
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\r", `\n`, "\n", `\n`)
+
+func escapeICalText(s string) string {
+	return icalEscaper.Replace(s)
+}
+
+// foldICalLine splits lines longer than 75 octets, continuing them with a
+// leading space, without breaking UTF-8 sequences
+func foldICalLine(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range s {
+		n := len(string(r))
+		if width+n > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += n
+	}
+	return b.String()
+}
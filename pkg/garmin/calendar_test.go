@@ -0,0 +1,79 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCalendar(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/calendar-service/year/2025/month/2":
+			w.Write([]byte(`{"year": 2025, "month": 2, "calendarItems": [
+				{"id": 555, "itemType": "activity", "title": "Morning Run", "date": "2025-03-31",
+				 "startTimestampLocal": "2025-03-31T07:00:00.0", "activityTypeId": 1,
+				 "duration": 3000, "distance": 10000, "calories": 700},
+				{"id": 901, "itemType": "workout", "title": "5x1k", "date": "2025-03-30", "workoutId": 77},
+				{"id": 3, "itemType": "event", "title": "Spring 10K, City Park", "date": "2025-03-02", "isRace": true}]}`))
+		case "/calendar-service/year/2025/month/3":
+			w.Write([]byte(`{"calendarItems": [
+				{"id": 4, "itemType": "note", "title": "Rest", "date": "2025-04-01"},
+				{"id": 5, "itemType": "note", "title": "Next week", "date": "2025-04-08"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	items, err := c.GetCalendar(ctx, 2025, time.March)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, "Spring 10K, City Park", items[0].Title)
+	assert.True(t, items[0].IsRace)
+	assert.Equal(t, int64(77), *items[1].WorkoutID)
+	id, ok := items[2].ActivityID()
+	assert.True(t, ok)
+	assert.Equal(t, int64(555), id)
+	_, ok = items[1].ActivityID()
+	assert.False(t, ok)
+
+	paths = nil
+	week, err := c.GetCalendarWeek(ctx, time.Date(2025, 4, 2, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/calendar-service/year/2025/month/2", "/calendar-service/year/2025/month/3"}, paths)
+	var titles []string
+	for _, item := range week {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Morning Run", "Rest"}, titles)
+
+	ics := string(garmin.ICalendar(items))
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Equal(t, 3, strings.Count(ics, "BEGIN:VEVENT"))
+	assert.Contains(t, ics, "SUMMARY:Spring 10K\\, City Park\r\n")
+	assert.Contains(t, ics, "DTSTART;VALUE=DATE:20250302\r\nDTEND;VALUE=DATE:20250303\r\n")
+	assert.Contains(t, ics, "DTSTART:20250331T070000\r\nDTEND:20250331T075000\r\n")
+	assert.Contains(t, ics, "URL:https://connect.garmin.com/modern/workout/77\r\n")
+	assert.Contains(t, ics, "UID:activity-555@connect.garmin.com\r\n")
+	for _, l := range strings.Split(ics, "\r\n") {
+		assert.LessOrEqual(t, len(l), 75)
+	}
+}
+
+func TestICalendar_EscapesLineBreaks(t *testing.T) {
+	item := garmin.CalendarItem{ID: 9, ItemType: "workout", Title: "Intervals\r\n6x800m\rcool down\nstretch", Date: garmin.GarminTime{Time: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)}}
+	ics := string(garmin.ICalendar([]garmin.CalendarItem{item}))
+	assert.Contains(t, ics, "SUMMARY:Intervals\\n6x800m\\ncool down\\nstretch\r\n")
+	assert.NotContains(t, strings.ReplaceAll(ics, "\r\n", ""), "\r")
+}
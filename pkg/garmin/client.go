@@ -1,12 +1,14 @@
 package garmin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/sstent/go-garth/internal/errors"
@@ -19,6 +21,9 @@ import (
 // Client is the main Garmin Connect client type
 type Client struct {
 	Client *internalClient.Client
+
+	activityTypesMu sync.Mutex
+	activityTypes   *ActivityTypeCatalog
 }
 
 var _ shared.APIClient = (*Client)(nil)
@@ -115,7 +120,7 @@ func (c *Client) GetActivity(activityID int) (*ActivityDetail, error) {
 		return nil, fmt.Errorf("activity not found")
 	}
 
-	var activity garth.Activity
+	var activity activityState
 	if err := json.Unmarshal(data, &activity); err != nil {
 		return nil, fmt.Errorf("failed to parse activity response: %w", err)
 	}
@@ -124,34 +129,64 @@ func (c *Client) GetActivity(activityID int) (*ActivityDetail, error) {
 		Activity: Activity{
 			ActivityID:     activity.ActivityID,
 			ActivityName:   activity.ActivityName,
-			ActivityType:   ActivityType(activity.ActivityType),
+			ActivityType:   activity.activityType(),
+			EventType:      activity.eventType(),
 			StartTimeLocal: activity.StartTimeLocal,
 			Distance:       activity.Distance,
 			Duration:       activity.Duration,
 		},
 		Description: activity.Description,
+		Privacy:     activity.privacy(),
 	}, nil
 }
 
-// DownloadActivity downloads activity data
+// DownloadActivity downloads activity data. Export formats use their
+// format-specific route; Original (or Format "fit") fetches the uploaded file
+// archive, optionally extracting the contained .fit file. The response is
+// checked against the expected content type and file signature before saving.
 func (c *Client) DownloadActivity(activityID int, opts DownloadOptions) error {
-	// TODO: Determine file extension based on format
-	fileExtension := opts.Format
-	if fileExtension == "csv" {
-		fileExtension = "csv"
-	} else if fileExtension == "gpx" {
-		fileExtension = "gpx"
-	} else if fileExtension == "tcx" {
-		fileExtension = "tcx"
-	} else {
-		return fmt.Errorf("unsupported download format: %s", opts.Format)
+	return c.DownloadActivityWithContext(context.Background(), activityID, opts)
+}
+
+// DownloadActivityWithContext is DownloadActivity with a caller-supplied context
+func (c *Client) DownloadActivityWithContext(ctx context.Context, activityID int, opts DownloadOptions) error {
+	format, extract, err := resolveDownloadFormat(opts)
+	if err != nil {
+		return err
+	}
+
+	path := internalClient.ActivityDownloadPath(fmt.Sprintf("%d", activityID), format.route)
+	data, contentType, err := c.Client.DownloadWithContext(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := validateDownload(format, data, contentType); err != nil {
+		return err
+	}
+
+	ext := format.ext
+	if extract {
+		if data, err = extractFIT(data); err != nil {
+			return err
+		}
+		ext = "fit"
 	}
 
 	// Construct filename
-	filename := fmt.Sprintf("%d.%s", activityID, fileExtension)
+	template := "{id}.{ext}"
 	if opts.Filename != "" {
-		filename = opts.Filename
+		template = opts.Filename
+	}
+	var activity *Activity
+	if needsActivityMetadata(template) {
+		detail, err := c.GetActivity(activityID)
+		if err != nil {
+			return err
+		}
+		activity = &detail.Activity
 	}
+	filename := expandFilename(template, activityID, activity, ext)
 
 	// Construct output path
 	outputPath := filename
@@ -159,65 +194,27 @@ func (c *Client) DownloadActivity(activityID int, opts DownloadOptions) error {
 		outputPath = filepath.Join(opts.OutputDir, filename)
 	}
 
-	err := c.Client.Download(fmt.Sprintf("%d", activityID), opts.Format, outputPath)
-	if err != nil {
-		return err
-	}
-
-	// Basic validation: check if file is empty
-	fileInfo, err := os.Stat(outputPath)
-	if err != nil {
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
 		return &errors.IOError{
 			GarthError: errors.GarthError{
-				Message: "Failed to get file info after download",
+				Message: "Failed to save file",
 				Cause:   err,
 			},
 		}
 	}
-	if fileInfo.Size() == 0 {
-		return &errors.IOError{
-			GarthError: errors.GarthError{
-				Message: "Downloaded file is empty",
-			},
-		}
-	}
 
 	return nil
 }
 
-// SearchActivities searches for activities by a query string
-func (c *Client) SearchActivities(query string) ([]Activity, error) {
-	params := url.Values{}
-	params.Add("search", query)
-	params.Add("limit", "20") // Default limit
-
-	data, err := c.Client.ConnectAPI("/activitylist-service/activities/search/activities", "GET", params, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search activities: %w", err)
-	}
-
-	if len(data) == 0 {
-		return []Activity{}, nil
-	}
-
-	var garthActivities []garth.Activity
-	if err := json.Unmarshal(data, &garthActivities); err != nil {
-		return nil, fmt.Errorf("failed to parse search response: %w", err)
-	}
-
-	var activities []Activity
-	for _, act := range garthActivities {
-		activities = append(activities, Activity{
-			ActivityID:     act.ActivityID,
-			ActivityName:   act.ActivityName,
-			ActivityType:   ActivityType(act.ActivityType),
-			StartTimeLocal: act.StartTimeLocal,
-			Distance:       act.Distance,
-			Duration:       act.Duration,
-		})
-	}
+// Upload sends an activity, course or workout file (FIT, GPX or TCX) to Garmin Connect
+func (c *Client) Upload(filePath string) error {
+	return c.Client.Upload(filePath)
+}
 
-	return activities, nil
+// SearchActivities searches for activities by a query string. See
+// FindActivities for structured filters.
+func (c *Client) SearchActivities(query string) ([]Activity, error) {
+	return c.FindActivities(context.Background(), NewActivitySearch().Query(query).Limit(20))
 }
 
 // GetSleepData retrieves sleep data for a specified date range
@@ -255,26 +252,78 @@ func (c *Client) GetCaloriesData(startDate, endDate time.Time) ([]CaloriesData,
 	return c.Client.GetCaloriesData(startDate, endDate)
 }
 
-// GetVO2MaxData retrieves VO2 max data for a specified date range
+// GetVO2MaxData retrieves the VO2 max history for a specified date range.
+// Only days with a new estimate are returned, oldest first.
 func (c *Client) GetVO2MaxData(startDate, endDate time.Time) ([]VO2MaxData, error) {
 	return c.Client.GetVO2MaxData(startDate, endDate)
 }
 
+// GetCurrentVO2Max retrieves the latest running and cycling VO2 max values
+func (c *Client) GetCurrentVO2Max() (*VO2MaxProfile, error) {
+	return c.Client.GetCurrentVO2Max()
+}
+
+// GetFitnessAgeHistory retrieves the fitness age recorded with each VO2 max
+// estimate in a date range, oldest first
+func (c *Client) GetFitnessAgeHistory(startDate, endDate time.Time) ([]FitnessAge, error) {
+	history, err := c.Client.GetVO2MaxData(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var ages []FitnessAge
+	for _, v := range history {
+		if v.FitnessAge == nil || v.VO2MaxRunning == nil {
+			continue
+		}
+		ages = append(ages, FitnessAge{
+			FitnessAge:    *v.FitnessAge,
+			VO2MaxRunning: *v.VO2MaxRunning,
+			LastUpdated:   v.Date,
+		})
+	}
+	return ages, nil
+}
+
 // GetHeartRateZones retrieves heart rate zone data
 func (c *Client) GetHeartRateZones() (*HeartRateZones, error) {
 	return c.Client.GetHeartRateZones()
 }
 
-// GetTrainingStatus retrieves current training status
+// GetTrainingStatus retrieves the training status of a day with its acute
+// load, load focus, VO2 max and the recovery time of the day's latest
+// training readiness assessment. It returns nil when there is no status.
+// RecoveryTime is left at zero when the readiness cannot be retrieved.
 func (c *Client) GetTrainingStatus(date time.Time) (*TrainingStatus, error) {
-	return c.Client.GetTrainingStatus(date)
+	ctx := context.Background()
+	status, err := c.Client.GetTrainingStatusWithContext(ctx, date)
+	if err != nil || status == nil {
+		return nil, err
+	}
+
+	// The recovery time is a convenience; a readiness failure must not hide
+	// the status itself
+	if readiness, err := c.GetTrainingReadiness(ctx, date); err == nil && readiness != nil {
+		status.RecoveryTime = readiness.RecoveryTime
+	}
+	return status, nil
 }
 
-// GetTrainingLoad retrieves training load data
+// GetTrainingLoad retrieves the training load of a day. It returns nil when
+// there is none.
 func (c *Client) GetTrainingLoad(date time.Time) (*TrainingLoad, error) {
 	return c.Client.GetTrainingLoad(date)
 }
 
+// GetTrainingLoadRange retrieves the daily training load between two dates,
+// inclusive, oldest first
+func (c *Client) GetTrainingLoadRange(ctx context.Context, startDate, endDate time.Time) ([]TrainingLoad, error) {
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	return c.Client.GetTrainingLoadRange(ctx, startDate, endDate)
+}
+
 // GetFitnessAge retrieves fitness age calculation
 func (c *Client) GetFitnessAge() (*FitnessAge, error) {
 	data, err := c.Client.ConnectAPI("/fitness-service/fitness/fitnessAge", "GET", nil, nil)
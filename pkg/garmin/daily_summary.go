@@ -0,0 +1,249 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DailySummarySpO2 is the blood oxygen summary of a day, in percent
+type DailySummarySpO2 struct {
+	Average float64
+	Lowest  float64
+	Latest  float64
+}
+
+// DailySummaryRespiration is the respiration summary of a day, in breaths
+// per minute
+type DailySummaryRespiration struct {
+	AverageWaking float64
+	Highest       float64
+	Lowest        float64
+	Latest        float64
+}
+
+// DailySummary is the "My Day" snapshot of a day's activity and wellness
+type DailySummary struct {
+	Date string // YYYY-MM-DD
+
+	Steps           int
+	StepGoal        int
+	Distance        float64 // Meters
+	FloorsAscended  float64
+	FloorsDescended float64
+
+	ActiveCalories int // Kilocalories
+	BMRCalories    int
+	TotalCalories  int
+
+	ModerateIntensityMinutes int
+	VigorousIntensityMinutes int
+	IntensityMinutesGoal     int
+
+	MinHeartRate     int
+	MaxHeartRate     int
+	RestingHeartRate int
+
+	AverageStress      int
+	MaxStress          int
+	BodyBatteryHighest int
+	BodyBatteryLowest  int
+	SpO2               DailySummarySpO2
+	Respiration        DailySummaryRespiration
+
+	WellnessStart      time.Time // UTC
+	WellnessEnd        time.Time
+	WellnessStartLocal time.Time // Wall clock time in the user's time zone
+	WellnessEndLocal   time.Time
+}
+
+// IntensityMinutes returns the intensity minutes earned toward the weekly
+// goal. Vigorous minutes count double.
+func (s *DailySummary) IntensityMinutes() int {
+	return s.ModerateIntensityMinutes + 2*s.VigorousIntensityMinutes
+}
+
+// dailySummaryDTO mirrors the usersummary-service daily summary response
+type dailySummaryDTO struct {
+	CalendarDate              string     `json:"calendarDate"`
+	TotalSteps                *int       `json:"totalSteps"`
+	DailyStepGoal             *int       `json:"dailyStepGoal"`
+	TotalDistanceMeters       *float64   `json:"totalDistanceMeters"`
+	FloorsAscended            *float64   `json:"floorsAscended"`
+	FloorsDescended           *float64   `json:"floorsDescended"`
+	ActiveKilocalories        *float64   `json:"activeKilocalories"`
+	BMRKilocalories           *float64   `json:"bmrKilocalories"`
+	TotalKilocalories         *float64   `json:"totalKilocalories"`
+	ModerateIntensityMinutes  *int       `json:"moderateIntensityMinutes"`
+	VigorousIntensityMinutes  *int       `json:"vigorousIntensityMinutes"`
+	IntensityMinutesGoal      *int       `json:"intensityMinutesGoal"`
+	MinHeartRate              *int       `json:"minHeartRate"`
+	MaxHeartRate              *int       `json:"maxHeartRate"`
+	RestingHeartRate          *int       `json:"restingHeartRate"`
+	AverageStressLevel        *int       `json:"averageStressLevel"`
+	MaxStressLevel            *int       `json:"maxStressLevel"`
+	BodyBatteryHighestValue   *int       `json:"bodyBatteryHighestValue"`
+	BodyBatteryLowestValue    *int       `json:"bodyBatteryLowestValue"`
+	AverageSpo2               *float64   `json:"averageSpo2"`
+	LowestSpo2                *float64   `json:"lowestSpo2"`
+	LatestSpo2                *float64   `json:"latestSpo2"`
+	AvgWakingRespirationValue *float64   `json:"avgWakingRespirationValue"`
+	HighestRespirationValue   *float64   `json:"highestRespirationValue"`
+	LowestRespirationValue    *float64   `json:"lowestRespirationValue"`
+	LatestRespirationValue    *float64   `json:"latestRespirationValue"`
+	WellnessStartTimeGMT      GarminTime `json:"wellnessStartTimeGmt"`
+	WellnessEndTimeGMT        GarminTime `json:"wellnessEndTimeGmt"`
+	WellnessStartTimeLocal    GarminTime `json:"wellnessStartTimeLocal"`
+	WellnessEndTimeLocal      GarminTime `json:"wellnessEndTimeLocal"`
+}
+
+func (d *dailySummaryDTO) toDailySummary() *DailySummary {
+	// Stress and Body Battery use negative values for missing readings
+	levelOf := func(v *int) int {
+		return max(intOf(v), 0)
+	}
+	return &DailySummary{
+		Date:                     d.CalendarDate,
+		Steps:                    intOf(d.TotalSteps),
+		StepGoal:                 intOf(d.DailyStepGoal),
+		Distance:                 valueOf(d.TotalDistanceMeters),
+		FloorsAscended:           valueOf(d.FloorsAscended),
+		FloorsDescended:          valueOf(d.FloorsDescended),
+		ActiveCalories:           int(valueOf(d.ActiveKilocalories)),
+		BMRCalories:              int(valueOf(d.BMRKilocalories)),
+		TotalCalories:            int(valueOf(d.TotalKilocalories)),
+		ModerateIntensityMinutes: intOf(d.ModerateIntensityMinutes),
+		VigorousIntensityMinutes: intOf(d.VigorousIntensityMinutes),
+		IntensityMinutesGoal:     intOf(d.IntensityMinutesGoal),
+		MinHeartRate:             intOf(d.MinHeartRate),
+		MaxHeartRate:             intOf(d.MaxHeartRate),
+		RestingHeartRate:         intOf(d.RestingHeartRate),
+		AverageStress:            levelOf(d.AverageStressLevel),
+		MaxStress:                levelOf(d.MaxStressLevel),
+		BodyBatteryHighest:       levelOf(d.BodyBatteryHighestValue),
+		BodyBatteryLowest:        levelOf(d.BodyBatteryLowestValue),
+		SpO2: DailySummarySpO2{
+			Average: valueOf(d.AverageSpo2),
+			Lowest:  valueOf(d.LowestSpo2),
+			Latest:  valueOf(d.LatestSpo2),
+		},
+		Respiration: DailySummaryRespiration{
+			AverageWaking: valueOf(d.AvgWakingRespirationValue),
+			Highest:       valueOf(d.HighestRespirationValue),
+			Lowest:        valueOf(d.LowestRespirationValue),
+			Latest:        valueOf(d.LatestRespirationValue),
+		},
+		WellnessStart:      d.WellnessStartTimeGMT.Time,
+		WellnessEnd:        d.WellnessEndTimeGMT.Time,
+		WellnessStartLocal: d.WellnessStartTimeLocal.Time,
+		WellnessEndLocal:   d.WellnessEndTimeLocal.Time,
+	}
+}
+
+// GetDailySummary retrieves the daily summary of a day. It returns nil when
+// there is no data for the day.
+func (c *Client) GetDailySummary(ctx context.Context, date time.Time) (*DailySummary, error) {
+	path := fmt.Sprintf("/usersummary-service/usersummary/daily/%s", c.Client.Username)
+	params := url.Values{}
+	params.Set("calendarDate", date.Format("2006-01-02"))
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily summary: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dto dailySummaryDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse daily summary response: %w", err)
+	}
+	if dto.CalendarDate == "" {
+		return nil, nil
+	}
+	return dto.toDailySummary(), nil
+}
+
+// defaultSummaryWorkers is the number of daily summaries fetched at once
+// when no limit is given
+const defaultSummaryWorkers = 10
+
+// GetDailySummaryRange retrieves the daily summaries between two dates,
+// inclusive, oldest first, fetching up to maxWorkers days concurrently.
+// Days without data are omitted. The first error cancels the remaining
+// requests and is returned.
+func (c *Client) GetDailySummaryRange(ctx context.Context, startDate, endDate time.Time, maxWorkers int) ([]DailySummary, error) {
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	if maxWorkers < 1 {
+		maxWorkers = defaultSummaryWorkers
+	}
+
+	var dates []time.Time
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day)
+	}
+	maxWorkers = min(maxWorkers, len(dates))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	summaries := make([]*DailySummary, len(dates))
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	workCh := make(chan int)
+
+	worker := func() {
+		defer wg.Done()
+		for i := range workCh {
+			summary, err := c.GetDailySummary(ctx, dates[i])
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				continue
+			}
+			summaries[i] = summary
+		}
+	}
+
+	wg.Add(maxWorkers)
+	for i := 0; i < maxWorkers; i++ {
+		go worker()
+	}
+
+send:
+	for i := range dates {
+		select {
+		case workCh <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(workCh)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]DailySummary, 0, len(dates))
+	for _, s := range summaries {
+		if s != nil {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
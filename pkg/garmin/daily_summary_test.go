@@ -0,0 +1,101 @@
+package garmin_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDailySummary(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/usersummary-service/usersummary/daily/testuser" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "2025-03-01", r.URL.Query().Get("calendarDate"))
+		w.Write([]byte(`{"calendarDate": "2025-03-01", "totalSteps": 11234, "dailyStepGoal": 10000,
+			"totalDistanceMeters": 8950.5, "floorsAscended": 12.4, "floorsDescended": 11.0,
+			"activeKilocalories": 812.0, "bmrKilocalories": 1750.0, "totalKilocalories": 2562.0,
+			"moderateIntensityMinutes": 20, "vigorousIntensityMinutes": 15, "intensityMinutesGoal": 150,
+			"minHeartRate": 47, "maxHeartRate": 163, "restingHeartRate": 52,
+			"averageStressLevel": 31, "maxStressLevel": 92,
+			"bodyBatteryHighestValue": 88, "bodyBatteryLowestValue": 21,
+			"averageSpo2": 96.0, "lowestSpo2": 89.0, "latestSpo2": 97.0,
+			"avgWakingRespirationValue": 14.0, "highestRespirationValue": 21.0, "lowestRespirationValue": 9.0,
+			"latestRespirationValue": 13.0,
+			"wellnessStartTimeGmt": "2025-03-01T05:00:00.0", "wellnessEndTimeGmt": "2025-03-02T05:00:00.0",
+			"wellnessStartTimeLocal": "2025-03-01T00:00:00.0", "wellnessEndTimeLocal": "2025-03-02T00:00:00.0"}`))
+	}))
+
+	summary, err := c.GetDailySummary(context.Background(), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 11234, summary.Steps)
+	assert.Equal(t, 8950.5, summary.Distance)
+	assert.Equal(t, 12.4, summary.FloorsAscended)
+	assert.Equal(t, 812, summary.ActiveCalories)
+	assert.Equal(t, 1750, summary.BMRCalories)
+	assert.Equal(t, 2562, summary.TotalCalories)
+	assert.Equal(t, 50, summary.IntensityMinutes())
+	assert.Equal(t, 52, summary.RestingHeartRate)
+	assert.Equal(t, 163, summary.MaxHeartRate)
+	assert.Equal(t, 31, summary.AverageStress)
+	assert.Equal(t, 88, summary.BodyBatteryHighest)
+	assert.Equal(t, 21, summary.BodyBatteryLowest)
+	assert.Equal(t, garmin.DailySummarySpO2{Average: 96, Lowest: 89, Latest: 97}, summary.SpO2)
+	assert.Equal(t, 14.0, summary.Respiration.AverageWaking)
+	assert.Equal(t, time.Date(2025, 3, 1, 5, 0, 0, 0, time.UTC), summary.WellnessStart)
+	assert.Equal(t, 24*time.Hour, summary.WellnessEnd.Sub(summary.WellnessStart))
+	assert.Equal(t, 0, summary.WellnessStartLocal.Hour())
+}
+
+func TestGetDailySummaryRange(t *testing.T) {
+	var inFlight, peak int32
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		date := r.URL.Query().Get("calendarDate")
+		switch date {
+		case "2025-03-03":
+			w.Write([]byte(`{}`))
+		case "2025-03-09":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprintf(w, `{"calendarDate": %q, "totalSteps": %s, "averageStressLevel": -1}`, date, date[9:])
+		}
+	}))
+	ctx := context.Background()
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	summaries, err := c.GetDailySummaryRange(ctx, day, day.AddDate(0, 0, 6), 3)
+	require.NoError(t, err)
+	require.Len(t, summaries, 6, "days without data are omitted")
+	for i, want := range []int{1, 2, 4, 5, 6, 7} {
+		assert.Equal(t, want, summaries[i].Steps, "summaries are in date order")
+	}
+	assert.Equal(t, 0, summaries[0].AverageStress, "missing stress is zero")
+	assert.Greater(t, atomic.LoadInt32(&peak), int32(1), "days are fetched concurrently")
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+
+	_, err = c.GetDailySummaryRange(ctx, day, day.AddDate(0, 0, 9), 0)
+	assert.Error(t, err)
+
+	var invalid *garmin.ValidationError
+	_, err = c.GetDailySummaryRange(ctx, day, day.AddDate(0, 0, -1), 0)
+	assert.ErrorAs(t, err, &invalid)
+}
@@ -0,0 +1,238 @@
+package garmin
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// downloadFormat describes how an activity download is fetched and validated
+type downloadFormat struct {
+	route        string   // format segment of the download-service route
+	ext          string   // file extension of the saved file
+	contentTypes []string // accepted response media types
+	magic        func(data []byte) bool
+}
+
+var downloadFormats = map[string]downloadFormat{
+	"gpx": {
+		route:        "gpx",
+		ext:          "gpx",
+		contentTypes: []string{"application/gpx+xml", "application/xml", "text/xml"},
+		magic:        xmlRootMagic("gpx"),
+	},
+	"tcx": {
+		route:        "tcx",
+		ext:          "tcx",
+		contentTypes: []string{"application/vnd.garmin.tcx+xml", "application/xml", "text/xml"},
+		magic:        xmlRootMagic("TrainingCenterDatabase"),
+	},
+	"kml": {
+		route:        "kml",
+		ext:          "kml",
+		contentTypes: []string{"application/vnd.google-earth.kml+xml", "application/xml", "text/xml"},
+		magic:        xmlRootMagic("kml"),
+	},
+	"csv": {
+		route:        "csv",
+		ext:          "csv",
+		contentTypes: []string{"text/csv", "text/plain", "application/csv"},
+		magic:        isCSV,
+	},
+	"original": {
+		route:        "",
+		ext:          "zip",
+		contentTypes: []string{"application/zip", "application/x-zip-compressed"},
+		magic:        isZip,
+	},
+}
+
+// xmlRootMagic checks that the document's first element is root. The XML
+// declaration, comments, doctype and whitespace before it are skipped.
+func xmlRootMagic(root string) func(data []byte) bool {
+	return func(data []byte) bool {
+		dec := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		for {
+			tok, err := dec.RawToken()
+			if err != nil {
+				return false
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				return t.Name.Local == root
+			case xml.CharData:
+				if len(bytes.TrimSpace(t)) > 0 {
+					return false
+				}
+			}
+		}
+	}
+}
+
+// isCSV rejects bodies that are obviously HTML or JSON error pages
+func isCSV(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n\xef\xbb\xbf")
+	return len(trimmed) > 0 && trimmed[0] != '<' && trimmed[0] != '{' && trimmed[0] != '['
+}
+
+// isZip checks for the local file header signature
+func isZip(data []byte) bool {
+	return len(data) >= 4 && bytes.Equal(data[:4], []byte("PK\x03\x04"))
+}
+
+// isFIT checks for the ".FIT" data type signature in the file header
+func isFIT(data []byte) bool {
+	return len(data) >= 12 && bytes.Equal(data[8:12], []byte(".FIT"))
+}
+
+// resolveDownloadFormat maps download options to a format and whether the
+// .fit file should be extracted from the original archive
+func resolveDownloadFormat(opts DownloadOptions) (downloadFormat, bool, error) {
+	format := strings.ToLower(opts.Format)
+	if opts.Original || format == "fit" {
+		return downloadFormats["original"], opts.Extract || format == "fit", nil
+	}
+	if f, ok := downloadFormats[format]; ok && format != "original" {
+		return f, false, nil
+	}
+	return downloadFormat{}, false, &errors.ValidationError{
+		GarthError: errors.GarthError{
+			Message: fmt.Sprintf("unsupported download format: %s", opts.Format),
+		},
+		Field: "Format",
+	}
+}
+
+// validateDownload checks the response media type and leading bytes
+func validateDownload(f downloadFormat, data []byte, contentType string) error {
+	if len(data) == 0 {
+		return &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Downloaded file is empty",
+			},
+		}
+	}
+
+	if contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err == nil && mediaType != "application/octet-stream" && !slices.Contains(f.contentTypes, mediaType) {
+			return &errors.IOError{
+				GarthError: errors.GarthError{
+					Message: fmt.Sprintf("Unexpected content type %q for %s download", mediaType, f.ext),
+				},
+			}
+		}
+	}
+
+	if !f.magic(data) {
+		return &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: fmt.Sprintf("Downloaded content is not a valid %s file", f.ext),
+			},
+		}
+	}
+
+	return nil
+}
+
+// extractFIT returns the first .fit file contained in an original download archive
+func extractFIT(data []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to open downloaded archive",
+				Cause:   err,
+			},
+		}
+	}
+
+	for _, file := range archive.File {
+		if !strings.EqualFold(filepath.Ext(file.Name), ".fit") {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return nil, &errors.IOError{
+				GarthError: errors.GarthError{
+					Message: "Failed to open FIT file in archive",
+					Cause:   err,
+				},
+			}
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, &errors.IOError{
+				GarthError: errors.GarthError{
+					Message: "Failed to extract FIT file from archive",
+					Cause:   err,
+				},
+			}
+		}
+		if !isFIT(content) {
+			return nil, &errors.IOError{
+				GarthError: errors.GarthError{
+					Message: fmt.Sprintf("Archive entry %s is not a valid FIT file", file.Name),
+				},
+			}
+		}
+		return content, nil
+	}
+
+	return nil, &errors.IOError{
+		GarthError: errors.GarthError{
+			Message: "Downloaded archive does not contain a FIT file",
+		},
+	}
+}
+
+var filenamePlaceholder = regexp.MustCompile(`\{(id|date|type|name|ext)\}`)
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// needsActivityMetadata reports whether the template references activity fields
+func needsActivityMetadata(template string) bool {
+	return strings.Contains(template, "{date}") ||
+		strings.Contains(template, "{type}") ||
+		strings.Contains(template, "{name}")
+}
+
+// expandFilename fills the filename template placeholders. activity may be nil
+// when the template only uses {id} and {ext}.
+func expandFilename(template string, activityID int, activity *Activity, ext string) string {
+	return filenamePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		switch placeholder {
+		case "{id}":
+			return strconv.Itoa(activityID)
+		case "{ext}":
+			return ext
+		}
+		if activity == nil {
+			return ""
+		}
+		switch placeholder {
+		case "{date}":
+			return activity.StartTimeLocal.Format("2006-01-02")
+		case "{type}":
+			return sanitizeFilename(activity.ActivityType.TypeKey)
+		case "{name}":
+			return sanitizeFilename(activity.ActivityName)
+		}
+		return ""
+	})
+}
+
+// sanitizeFilename replaces characters that are unsafe in file names
+func sanitizeFilename(s string) string {
+	return strings.Trim(unsafeFilenameChars.ReplaceAllString(s, "_"), "_")
+}
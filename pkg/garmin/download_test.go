@@ -0,0 +1,107 @@
+package garmin_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fitHeader is a minimal 14-byte FIT file header with no records
+var fitHeader = []byte{14, 0x10, 0x00, 0x00, 0, 0, 0, 0, '.', 'F', 'I', 'T', 0, 0}
+
+func originalArchive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create("42_ACTIVITY.fit")
+	require.NoError(t, err)
+	_, err = f.Write(fitHeader)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDownloadActivity(t *testing.T) {
+	archive := originalArchive(t)
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/download-service/export/gpx/activity/42":
+			w.Header().Set("Content-Type", "application/gpx+xml")
+			w.Write([]byte("\xef\xbb\xbf<?xml version=\"1.0\"?>\n<!-- exported by Garmin Connect -->\n<gpx version=\"1.1\"></gpx>"))
+		case "/download-service/export/kml/activity/42":
+			w.Header().Set("Content-Type", "application/xml")
+			w.Write([]byte(`<?xml version="1.0"?><!-- <kml> export unavailable --><error/>`))
+		case "/download-service/export/tcx/activity/42":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html>error</html>`))
+		case "/download-service/files/activity/42":
+			w.Header().Set("Content-Type", "application/x-zip-compressed")
+			w.Write(archive)
+		case "/activity-service/activity/42":
+			w.Write([]byte(`{"activityId": 42, "activityName": "Morning Run",
+				"startTimeLocal": "2025-03-02 07:00:00", "activityType": {"typeKey": "running"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	dir := t.TempDir()
+
+	t.Run("export format", func(t *testing.T) {
+		err := c.DownloadActivity(42, garmin.DownloadOptions{Format: "gpx", OutputDir: dir})
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "42.gpx"))
+	})
+
+	t.Run("content type mismatch", func(t *testing.T) {
+		err := c.DownloadActivity(42, garmin.DownloadOptions{Format: "tcx", OutputDir: dir})
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "42.tcx"))
+	})
+
+	t.Run("unexpected root element", func(t *testing.T) {
+		err := c.DownloadActivity(42, garmin.DownloadOptions{Format: "kml", OutputDir: dir})
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "42.kml"))
+	})
+
+	t.Run("original archive", func(t *testing.T) {
+		err := c.DownloadActivity(42, garmin.DownloadOptions{Original: true, OutputDir: dir})
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "42.zip"))
+	})
+
+	t.Run("extracted fit with template", func(t *testing.T) {
+		err := c.DownloadActivity(42, garmin.DownloadOptions{
+			Format:    "fit",
+			OutputDir: dir,
+			Filename:  "{date}_{type}_{id}.{ext}",
+		})
+		require.NoError(t, err)
+		content, err := os.ReadFile(filepath.Join(dir, "2025-03-02_running_42.fit"))
+		require.NoError(t, err)
+		assert.Equal(t, fitHeader, content)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		out := t.TempDir()
+		err := c.DownloadActivityWithContext(ctx, 42, garmin.DownloadOptions{Format: "gpx", OutputDir: out})
+		assert.ErrorContains(t, err, context.Canceled.Error())
+		assert.NoFileExists(t, filepath.Join(out, "42.gpx"))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		err := c.DownloadActivity(42, garmin.DownloadOptions{Format: "pdf", OutputDir: dir})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package garmin
+
+import (
+	stderrors "errors"
+	"net/http"
+	"time"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// APIError represents an HTTP/API failure, including status code and response body
+type APIError = errors.APIError
+
+// IOError represents a file or network I/O failure
+type IOError = errors.IOError
+
+// ValidationError represents an input validation failure
+type ValidationError = errors.ValidationError
+
+// NotFoundError is returned when the requested resource does not exist
+type NotFoundError = errors.NotFoundError
+
+// PermissionError is returned when the resource belongs to another user
+type PermissionError = errors.PermissionError
+
+// ConflictError is returned when a resource changed since it was last read
+type ConflictError = errors.ConflictError
+
+// resourceError converts 404 and 403 API failures into NotFoundError and
+// PermissionError; other errors are returned unchanged
+func resourceError(err error, resource, id string) error {
+	var apiErr *errors.APIError
+	if !stderrors.As(err, &apiErr) {
+		return err
+	}
+	switch apiErr.StatusCode {
+	case http.StatusNotFound:
+		return &errors.NotFoundError{GarthHTTPError: apiErr.GarthHTTPError, Resource: resource, ID: id}
+	case http.StatusForbidden:
+		return &errors.PermissionError{GarthHTTPError: apiErr.GarthHTTPError, Resource: resource, ID: id}
+	}
+	return err
+}
+
+// validationError returns a ValidationError for an invalid input field
+func validationError(field, message string) error {
+	return &errors.ValidationError{GarthError: errors.GarthError{Message: message}, Field: field}
+}
+
+// validateDateRange rejects a date range that ends before it starts
+func validateDateRange(startDate, endDate time.Time) error {
+	if endDate.Before(startDate) {
+		return validationError("endDate", "end date must not be before start date")
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package fit
+
+import (
+	"math"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+)
+
+// NewActivityFile returns an activity file for the given records with a single
+// lap and session summarizing them. Timer events and the activity summary are
+// added when the file is encoded.
+func NewActivityFile(sport, subSport int, records []Record) *File {
+	f := &File{
+		FileID:  &FileID{Type: FileTypeActivity, Manufacturer: ManufacturerDevelopment},
+		Records: records,
+	}
+	if len(records) > 0 {
+		f.FileID.TimeCreated = records[0].Timestamp
+		lap := summarizeLap(records, sport)
+		f.Laps = []Lap{lap}
+		f.Sessions = []Session{summarizeSession(f.Laps, sport, subSport)}
+	}
+	return f
+}
+
+// NewCourseFile returns a course file following the given records, with
+// optional course points such as turns and climbs
+func NewCourseFile(name string, sport int, records []Record, points []CoursePoint) *File {
+	f := &File{
+		FileID:       &FileID{Type: FileTypeCourse, Manufacturer: ManufacturerDevelopment},
+		Course:       &Course{Name: name, Sport: sport},
+		Records:      records,
+		CoursePoints: points,
+	}
+	if len(records) > 0 {
+		f.FileID.TimeCreated = records[0].Timestamp
+	}
+	return f
+}
+
+// NewWorkoutFile returns a workout file with the given steps
+func NewWorkoutFile(name string, sport int, steps []WorkoutStep) *File {
+	return &File{
+		FileID:       &FileID{Type: FileTypeWorkout, Manufacturer: ManufacturerDevelopment, TimeCreated: time.Now()},
+		Workout:      &Workout{Name: name, Sport: sport, NumValidSteps: len(steps)},
+		WorkoutSteps: steps,
+	}
+}
+
+// NewWeightFile returns a weight file holding the given weigh-ins. The serial
+// number is taken from the first timestamp so repeated uploads of different
+// weigh-ins are not rejected as duplicates.
+func NewWeightFile(scales ...WeightScale) *File {
+	f := &File{
+		FileID:       &FileID{Type: FileTypeWeight, Manufacturer: ManufacturerDevelopment, TimeCreated: time.Now()},
+		WeightScales: scales,
+	}
+	if len(scales) > 0 {
+		f.FileID.TimeCreated = scales[0].Timestamp
+		f.FileID.SerialNumber = FromTime(scales[0].Timestamp)
+	}
+	return f
+}
+
+// RecordsFromStreams converts activity streams into record messages, one per
+// sample. NaN samples are left out of the record.
+func RecordsFromStreams(s *streams.ActivityStreams) []Record {
+	records := make([]Record, s.Len())
+	for i := range records {
+		records[i] = Record{
+			Timestamp:   s.Timestamps[i],
+			Lat:         s.Float(streams.Latitude, i),
+			Lon:         s.Float(streams.Longitude, i),
+			Altitude:    s.Float(streams.Altitude, i),
+			HeartRate:   s.Int(streams.HeartRate, i),
+			Cadence:     s.Int(streams.Cadence, i),
+			Distance:    s.Float(streams.Distance, i),
+			Speed:       s.Float(streams.Speed, i),
+			Power:       s.Int(streams.Power, i),
+			Temperature: s.Int(streams.Temperature, i),
+		}
+	}
+	return records
+}
+
+// summarizeLap derives a lap covering all records
+func summarizeLap(records []Record, sport int) Lap {
+	lap := Lap{Sport: sport, LapTrigger: 7} // session end
+	if len(records) == 0 {
+		return lap
+	}
+
+	lap.StartTime = records[0].Timestamp
+	lap.Timestamp = records[len(records)-1].Timestamp
+	elapsed := lap.Timestamp.Sub(lap.StartTime).Seconds()
+	lap.TotalElapsedTime = &elapsed
+	lap.TotalTimerTime = &elapsed
+
+	var hr, cadence, power streams.Stat
+	var maxSpeed, distance float64
+	var hasSpeed, hasDistance bool
+	var ascent, descent float64
+	var lastAltitude *float64
+	for _, r := range records {
+		if r.Lat != nil && r.Lon != nil {
+			if lap.StartLat == nil {
+				lap.StartLat, lap.StartLon = r.Lat, r.Lon
+			}
+			lap.EndLat, lap.EndLon = r.Lat, r.Lon
+		}
+		hr.Add(r.HeartRate)
+		cadence.Add(r.Cadence)
+		power.Add(r.Power)
+		if r.Speed != nil {
+			maxSpeed = math.Max(maxSpeed, *r.Speed)
+			hasSpeed = true
+		}
+		if r.Distance != nil {
+			distance = math.Max(distance, *r.Distance)
+			hasDistance = true
+		}
+		if r.Altitude != nil {
+			if lastAltitude != nil {
+				if d := *r.Altitude - *lastAltitude; d > 0 {
+					ascent += d
+				} else {
+					descent -= d
+				}
+			}
+			lastAltitude = r.Altitude
+		}
+	}
+
+	lap.AvgHeartRate, lap.MaxHeartRate = hr.Avg(), hr.Max()
+	lap.AvgCadence, lap.MaxCadence = cadence.Avg(), cadence.Max()
+	lap.AvgPower, lap.MaxPower = power.Avg(), power.Max()
+	if hasSpeed {
+		lap.MaxSpeed = &maxSpeed
+	}
+	if hasDistance {
+		lap.TotalDistance = &distance
+		if elapsed > 0 {
+			avg := distance / elapsed
+			lap.AvgSpeed = &avg
+		}
+	}
+	if lastAltitude != nil {
+		up, down := int(math.Round(ascent)), int(math.Round(descent))
+		lap.TotalAscent, lap.TotalDescent = &up, &down
+	}
+	return lap
+}
+
+// summarizeSession derives a session from its laps. Totals are summed,
+// maxima take the largest lap value and averages are weighted by timer time.
+func summarizeSession(laps []Lap, sport, subSport int) Session {
+	first := laps[0]
+	last := laps[len(laps)-1]
+	s := Session{
+		Timestamp: last.Timestamp,
+		StartTime: first.StartTime,
+		Sport:     sport,
+		SubSport:  subSport,
+		StartLat:  first.StartLat,
+		StartLon:  first.StartLon,
+	}
+	numLaps := len(laps)
+	s.NumLaps = &numLaps
+
+	elapsed := last.Timestamp.Sub(first.StartTime).Seconds()
+	s.TotalElapsedTime = &elapsed
+	s.TotalTimerTime = sumFloat(laps, func(l Lap) *float64 { return l.TotalTimerTime })
+	s.TotalDistance = sumFloat(laps, func(l Lap) *float64 { return l.TotalDistance })
+	s.TotalCalories = sumInt(laps, func(l Lap) *int { return l.TotalCalories })
+	s.TotalAscent = sumInt(laps, func(l Lap) *int { return l.TotalAscent })
+	s.TotalDescent = sumInt(laps, func(l Lap) *int { return l.TotalDescent })
+	s.MaxSpeed = maxFloat(laps, func(l Lap) *float64 { return l.MaxSpeed })
+	s.MaxHeartRate = maxInt(laps, func(l Lap) *int { return l.MaxHeartRate })
+	s.MaxCadence = maxInt(laps, func(l Lap) *int { return l.MaxCadence })
+	s.MaxPower = maxInt(laps, func(l Lap) *int { return l.MaxPower })
+	s.AvgHeartRate = weightedInt(laps, func(l Lap) *int { return l.AvgHeartRate })
+	s.AvgCadence = weightedInt(laps, func(l Lap) *int { return l.AvgCadence })
+	s.AvgPower = weightedInt(laps, func(l Lap) *int { return l.AvgPower })
+	if s.TotalDistance != nil && s.TotalTimerTime != nil && *s.TotalTimerTime > 0 {
+		avg := *s.TotalDistance / *s.TotalTimerTime
+		s.AvgSpeed = &avg
+	}
+	return s
+}
+
+func sumFloat(laps []Lap, field func(Lap) *float64) *float64 {
+	var total float64
+	found := false
+	for _, l := range laps {
+		if v := field(l); v != nil {
+			total += *v
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &total
+}
+
+func sumInt(laps []Lap, field func(Lap) *int) *int {
+	var total int
+	found := false
+	for _, l := range laps {
+		if v := field(l); v != nil {
+			total += *v
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &total
+}
+
+func maxFloat(laps []Lap, field func(Lap) *float64) *float64 {
+	var result *float64
+	for _, l := range laps {
+		if v := field(l); v != nil && (result == nil || *v > *result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func maxInt(laps []Lap, field func(Lap) *int) *int {
+	var result *int
+	for _, l := range laps {
+		if v := field(l); v != nil && (result == nil || *v > *result) {
+			result = v
+		}
+	}
+	return result
+}
+
+func weightedInt(laps []Lap, field func(Lap) *int) *int {
+	var sum, weight float64
+	for _, l := range laps {
+		v := field(l)
+		if v == nil {
+			continue
+		}
+		w := 1.0
+		if l.TotalTimerTime != nil && *l.TotalTimerTime > 0 {
+			w = *l.TotalTimerTime
+		}
+		sum += float64(*v) * w
+		weight += w
+	}
+	if weight == 0 {
+		return nil
+	}
+	avg := int(math.Round(sum / weight))
+	return &avg
+}
@@ -0,0 +1,455 @@
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// Header is the FIT file header
+type Header struct {
+	Size            uint8
+	ProtocolVersion uint8
+	ProfileVersion  uint16
+	DataSize        uint32
+	CRC             uint16 // Zero when the header has no CRC
+}
+
+// fieldDefinition describes one field of a definition message
+type fieldDefinition struct {
+	num      uint8
+	size     uint8
+	baseType BaseType
+}
+
+// devFieldDefinition describes one developer field of a definition message
+type devFieldDefinition struct {
+	num                uint8
+	size               uint8
+	developerDataIndex uint8
+}
+
+// definition is a decoded definition message bound to a local message type
+type definition struct {
+	global    MesgNum
+	byteOrder binary.ByteOrder
+	fields    []fieldDefinition
+	devFields []devFieldDefinition
+}
+
+// devFieldKey identifies a developer field description
+type devFieldKey struct {
+	developerDataIndex uint8
+	num                uint8
+}
+
+// devFieldDescription is a decoded field_description message
+type devFieldDescription struct {
+	name     string
+	units    string
+	baseType BaseType
+	scale    float64
+	offset   float64
+}
+
+// decoder holds the state needed while walking the record stream
+type decoder struct {
+	data        []byte
+	pos         int
+	definitions [16]*definition
+	devFields   map[devFieldKey]devFieldDescription
+	lastTime    uint32
+}
+
+// timestampFieldNum is the field number FIT reserves for timestamps in every message
+const timestampFieldNum = 253
+
+// timestamp16FieldNum is the monitoring field holding the low 16 bits of the timestamp
+const timestamp16FieldNum = 26
+
+// Decode reads and decodes a complete FIT file, verifying the header and file CRCs
+func Decode(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to read FIT data",
+				Cause:   err,
+			},
+		}
+	}
+	return DecodeBytes(data)
+}
+
+// DecodeFile decodes the FIT file at path
+func DecodeFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to read FIT file",
+				Cause:   err,
+			},
+		}
+	}
+	return DecodeBytes(data)
+}
+
+// DecodeBytes decodes a FIT file held in memory
+func DecodeBytes(data []byte) (*File, error) {
+	header, err := decodeHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	end := int(header.Size) + int(header.DataSize)
+	if len(data) < end+2 {
+		return nil, formatError("FIT file is truncated", nil)
+	}
+	if crc := binary.LittleEndian.Uint16(data[end : end+2]); crc != CRC(data[:end]) {
+		return nil, formatError("FIT file CRC mismatch", nil)
+	}
+
+	d := &decoder{
+		data:      data[:end],
+		pos:       int(header.Size),
+		devFields: make(map[devFieldKey]devFieldDescription),
+	}
+
+	file := &File{Header: header}
+	for d.pos < len(d.data) {
+		msg, err := d.next()
+		if err != nil {
+			return nil, err
+		}
+		if msg != nil {
+			file.add(msg)
+		}
+	}
+
+	return file, nil
+}
+
+// decodeHeader parses and validates the file header
+func decodeHeader(data []byte) (Header, error) {
+	if len(data) < 12 {
+		return Header{}, formatError("FIT header is truncated", nil)
+	}
+
+	header := Header{
+		Size:            data[0],
+		ProtocolVersion: data[1],
+		ProfileVersion:  binary.LittleEndian.Uint16(data[2:4]),
+		DataSize:        binary.LittleEndian.Uint32(data[4:8]),
+	}
+	if header.Size != 12 && header.Size != 14 {
+		return Header{}, formatError(fmt.Sprintf("invalid FIT header size %d", header.Size), nil)
+	}
+	if !bytes.Equal(data[8:12], []byte(".FIT")) {
+		return Header{}, formatError("missing .FIT signature", nil)
+	}
+	if header.Size == 14 {
+		if len(data) < 14 {
+			return Header{}, formatError("FIT header is truncated", nil)
+		}
+		header.CRC = binary.LittleEndian.Uint16(data[12:14])
+		if header.CRC != 0 && header.CRC != CRC(data[:12]) {
+			return Header{}, formatError("FIT header CRC mismatch", nil)
+		}
+	}
+
+	return header, nil
+}
+
+// next decodes one record, returning a message for data records and nil for definitions
+func (d *decoder) next() (*Message, error) {
+	recordHeader, err := d.read(1)
+	if err != nil {
+		return nil, err
+	}
+	h := recordHeader[0]
+
+	// Compressed timestamp header: local type in bits 5-6, time offset in bits 0-4
+	if h&0x80 != 0 {
+		local := (h >> 5) & 0x03
+		offset := uint32(h & 0x1F)
+		timestamp := (d.lastTime &^ 0x1F) + offset
+		if offset < d.lastTime&0x1F {
+			timestamp += 0x20
+		}
+		d.lastTime = timestamp
+
+		msg, err := d.decodeData(local)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := msg.Field(timestampFieldNum); !ok {
+			msg.Fields = append(msg.Fields, Field{Num: timestampFieldNum, Type: BaseTypeUint32, Value: uint64(timestamp)})
+		}
+		return msg, nil
+	}
+
+	local := h & 0x0F
+	if h&0x40 != 0 {
+		return nil, d.decodeDefinition(local, h&0x20 != 0)
+	}
+
+	msg, err := d.decodeData(local)
+	if err != nil {
+		return nil, err
+	}
+	if ts, ok := msg.Uint(timestampFieldNum); ok {
+		d.lastTime = uint32(ts)
+	} else if ts16, ok := msg.Uint(timestamp16FieldNum); ok && msg.Num == MesgNumMonitoring {
+		// Monitoring files carry only the low 16 bits of most timestamps
+		d.lastTime += (uint32(ts16) - d.lastTime) & 0xFFFF
+		msg.Fields = append(msg.Fields, Field{Num: timestampFieldNum, Type: BaseTypeUint32, Value: uint64(d.lastTime)})
+	}
+	return msg, nil
+}
+
+// decodeDefinition reads a definition message and binds it to a local type
+func (d *decoder) decodeDefinition(local uint8, hasDevFields bool) error {
+	fixed, err := d.read(5)
+	if err != nil {
+		return err
+	}
+
+	def := &definition{byteOrder: binary.LittleEndian}
+	if fixed[1] == 1 {
+		def.byteOrder = binary.BigEndian
+	}
+	def.global = MesgNum(def.byteOrder.Uint16(fixed[2:4]))
+
+	for i := 0; i < int(fixed[4]); i++ {
+		raw, err := d.read(3)
+		if err != nil {
+			return err
+		}
+		def.fields = append(def.fields, fieldDefinition{num: raw[0], size: raw[1], baseType: normalizeBaseType(raw[2])})
+	}
+
+	if hasDevFields {
+		count, err := d.read(1)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < int(count[0]); i++ {
+			raw, err := d.read(3)
+			if err != nil {
+				return err
+			}
+			def.devFields = append(def.devFields, devFieldDefinition{num: raw[0], size: raw[1], developerDataIndex: raw[2]})
+		}
+	}
+
+	d.definitions[local] = def
+	return nil
+}
+
+// decodeData reads a data message using the definition bound to local
+func (d *decoder) decodeData(local uint8) (*Message, error) {
+	def := d.definitions[local]
+	if def == nil {
+		return nil, formatError(fmt.Sprintf("data message for undefined local type %d", local), nil)
+	}
+
+	msg := &Message{Num: def.global}
+	for _, fd := range def.fields {
+		raw, err := d.read(int(fd.size))
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := decodeValue(raw, fd.baseType, def.byteOrder); ok {
+			msg.Fields = append(msg.Fields, Field{Num: fd.num, Type: fd.baseType, Value: value})
+		}
+	}
+
+	for _, fd := range def.devFields {
+		raw, err := d.read(int(fd.size))
+		if err != nil {
+			return nil, err
+		}
+		desc, ok := d.devFields[devFieldKey{fd.developerDataIndex, fd.num}]
+		if !ok {
+			continue
+		}
+		value, ok := decodeValue(raw, desc.baseType, def.byteOrder)
+		if !ok {
+			continue
+		}
+		msg.DeveloperFields = append(msg.DeveloperFields, DeveloperField{
+			DeveloperDataIndex: fd.developerDataIndex,
+			Num:                fd.num,
+			Name:               desc.name,
+			Units:              desc.units,
+			Value:              scaleDeveloperValue(value, desc.scale, desc.offset),
+		})
+	}
+
+	if msg.Num == MesgNumFieldDescription {
+		d.registerFieldDescription(msg)
+	}
+
+	return msg, nil
+}
+
+// registerFieldDescription records a developer field description for later data messages
+func (d *decoder) registerFieldDescription(msg *Message) {
+	index, ok1 := msg.Uint(0)
+	num, ok2 := msg.Uint(1)
+	baseType, ok3 := msg.Uint(2)
+	if !ok1 || !ok2 || !ok3 {
+		return
+	}
+	desc := devFieldDescription{baseType: normalizeBaseType(byte(baseType))}
+	desc.name, _ = msg.String(3)
+	desc.units, _ = msg.String(8)
+	if scale, ok := msg.Uint(6); ok {
+		desc.scale = float64(scale)
+	}
+	if offset, ok := msg.Int(7); ok {
+		desc.offset = float64(offset)
+	}
+	d.devFields[devFieldKey{uint8(index), uint8(num)}] = desc
+}
+
+// scaleDeveloperValue applies a developer field scale and offset to numeric values
+func scaleDeveloperValue(value any, scale, offset float64) any {
+	if scale == 0 && offset == 0 {
+		return value
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	switch t := value.(type) {
+	case int64:
+		return float64(t)/scale - offset
+	case uint64:
+		return float64(t)/scale - offset
+	case float64:
+		return t/scale - offset
+	}
+	return value
+}
+
+// read consumes n bytes from the record stream
+func (d *decoder) read(n int) ([]byte, error) {
+	if d.pos+n > len(d.data) {
+		return nil, formatError("unexpected end of FIT data", io.ErrUnexpectedEOF)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+// decodeValue converts raw field bytes to a Go value, dropping invalid values
+func decodeValue(raw []byte, baseType BaseType, order binary.ByteOrder) (any, bool) {
+	switch baseType {
+	case BaseTypeString:
+		if i := bytes.IndexByte(raw, 0); i >= 0 {
+			raw = raw[:i]
+		}
+		if len(raw) == 0 {
+			return nil, false
+		}
+		return string(raw), true
+	case BaseTypeByte:
+		if len(raw) == 1 {
+			if raw[0] == 0xFF {
+				return nil, false
+			}
+			return uint64(raw[0]), true
+		}
+		return append([]byte(nil), raw...), true
+	}
+
+	size := baseType.Size()
+	if len(raw) < size || len(raw)%size != 0 {
+		return append([]byte(nil), raw...), true
+	}
+
+	count := len(raw) / size
+	if count == 1 {
+		return decodeScalar(raw, baseType, order)
+	}
+
+	switch {
+	case baseType.float():
+		var values []float64
+		for i := 0; i < count; i++ {
+			if v, ok := decodeScalar(raw[i*size:(i+1)*size], baseType, order); ok {
+				values = append(values, v.(float64))
+			}
+		}
+		return values, len(values) > 0
+	case baseType.signed():
+		var values []int64
+		for i := 0; i < count; i++ {
+			if v, ok := decodeScalar(raw[i*size:(i+1)*size], baseType, order); ok {
+				values = append(values, v.(int64))
+			}
+		}
+		return values, len(values) > 0
+	default:
+		var values []uint64
+		for i := 0; i < count; i++ {
+			if v, ok := decodeScalar(raw[i*size:(i+1)*size], baseType, order); ok {
+				values = append(values, v.(uint64))
+			}
+		}
+		return values, len(values) > 0
+	}
+}
+
+// decodeScalar converts a single value of the given base type
+func decodeScalar(raw []byte, baseType BaseType, order binary.ByteOrder) (any, bool) {
+	var bits uint64
+	switch len(raw) {
+	case 1:
+		bits = uint64(raw[0])
+	case 2:
+		bits = uint64(order.Uint16(raw))
+	case 4:
+		bits = uint64(order.Uint32(raw))
+	case 8:
+		bits = order.Uint64(raw)
+	}
+
+	if bits == baseType.invalid() {
+		return nil, false
+	}
+
+	switch {
+	case baseType == BaseTypeFloat32:
+		return float64(math.Float32frombits(uint32(bits))), true
+	case baseType == BaseTypeFloat64:
+		return math.Float64frombits(bits), true
+	case baseType.signed():
+		switch len(raw) {
+		case 1:
+			return int64(int8(bits)), true
+		case 2:
+			return int64(int16(bits)), true
+		case 4:
+			return int64(int32(bits)), true
+		}
+		return int64(bits), true
+	}
+	return bits, true
+}
+
+// formatError reports malformed FIT content
+func formatError(message string, cause error) error {
+	return &errors.IOError{
+		GarthError: errors.GarthError{
+			Message: message,
+			Cause:   cause,
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildFile wraps raw record bytes in a 14-byte header and appends the file CRC
+func buildFile(records []byte) []byte {
+	header := []byte{14, 0x20, 0, 0, 0, 0, 0, 0, '.', 'F', 'I', 'T', 0, 0}
+	binary.LittleEndian.PutUint16(header[2:4], 2132)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(len(records)))
+	binary.LittleEndian.PutUint16(header[12:14], CRC(header[:12]))
+
+	data := append(header, records...)
+	return binary.LittleEndian.AppendUint16(data, CRC(data))
+}
+
+func testRecords(start uint32) []byte {
+	var b bytes.Buffer
+	le := binary.LittleEndian
+
+	// Definition: local 0 = file_id(type enum, manufacturer uint16, time_created uint32)
+	b.Write([]byte{0x40, 0, 0, 0, 0, 3, 0, 1, 0x00, 1, 2, 0x84, 4, 4, 0x86})
+	b.Write([]byte{0x00, 4})
+	b.Write(le.AppendUint16(nil, 1))
+	b.Write(le.AppendUint32(nil, start))
+
+	// Developer field description: local 1 = field_description(index, num, base type, name)
+	b.Write([]byte{0x41, 0, 0, 206, 0, 4, 0, 1, 0x02, 1, 1, 0x02, 2, 1, 0x02, 3, 8, 0x07})
+	b.Write([]byte{0x01, 0, 0, 0x02})
+	b.Write([]byte("Doughnut"))
+
+	// Definition with developer data: local 2 = record(timestamp, lat, lon, heart_rate, speed) + dev field 0
+	b.Write([]byte{0x62, 0, 0, 20, 0, 5,
+		253, 4, 0x86,
+		0, 4, 0x85,
+		1, 4, 0x85,
+		3, 1, 0x02,
+		6, 2, 0x84,
+		1, 0, 1, 0})
+	b.Write([]byte{0x02})
+	b.Write(le.AppendUint32(nil, start))
+	b.Write(le.AppendUint32(nil, uint32(Semicircles(45.5))))
+	b.Write(le.AppendUint32(nil, uint32(Semicircles(-122.25))))
+	b.Write([]byte{120})
+	b.Write(le.AppendUint16(nil, 2500))
+	b.Write([]byte{3})
+
+	// Compressed timestamp record (offset +2s) with missing position and speed
+	b.Write([]byte{0x80 | 2<<5 | byte((start+2)&0x1F)})
+	b.Write(le.AppendUint32(nil, 0xFFFFFFFF))
+	b.Write(le.AppendUint32(nil, 0x7FFFFFFF))
+	b.Write(le.AppendUint32(nil, 0x7FFFFFFF))
+	b.Write([]byte{130})
+	b.Write(le.AppendUint16(nil, 0xFFFF))
+	b.Write([]byte{4})
+
+	return b.Bytes()
+}
+
+func TestDecodeBytes(t *testing.T) {
+	start := FromTime(time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC))
+	file, err := DecodeBytes(buildFile(testRecords(start)))
+	require.NoError(t, err)
+
+	require.NotNil(t, file.FileID)
+	assert.Equal(t, 4, file.FileID.Type)
+	assert.Equal(t, 1, file.FileID.Manufacturer)
+	assert.Equal(t, Time(start), file.FileID.TimeCreated)
+
+	require.Len(t, file.Records, 2)
+	first, second := file.Records[0], file.Records[1]
+	assert.InDelta(t, 45.5, *first.Lat, 1e-6)
+	assert.InDelta(t, -122.25, *first.Lon, 1e-6)
+	assert.Equal(t, 120, *first.HeartRate)
+	assert.InDelta(t, 2.5, *first.Speed, 1e-9)
+	require.Len(t, first.DeveloperFields, 1)
+	assert.Equal(t, "Doughnut", first.DeveloperFields[0].Name)
+	assert.Equal(t, uint64(3), first.DeveloperFields[0].Value)
+
+	assert.Equal(t, Time(start+2), second.Timestamp)
+	assert.Nil(t, second.Lat)
+	assert.Nil(t, second.Speed)
+	assert.Equal(t, 130, *second.HeartRate)
+}
+
+func TestDecodeBytes_CRCMismatch(t *testing.T) {
+	data := buildFile(testRecords(FromTime(time.Now())))
+	data[len(data)-1] ^= 0xFF
+	_, err := DecodeBytes(data)
+	assert.Error(t, err)
+
+	_, err = DecodeBytes([]byte("not a fit file"))
+	assert.Error(t, err)
+}
+
+func TestDecodeBytes_UnflaggedBaseType(t *testing.T) {
+	var b bytes.Buffer
+	le := binary.LittleEndian
+	start := FromTime(time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC))
+
+	// Definition: local 0 = record(timestamp uint32, speed uint16) without the
+	// endian flag on either base type
+	b.Write([]byte{0x40, 0, 0, 20, 0, 2, 253, 4, 0x06, 6, 2, 0x04})
+	b.Write([]byte{0x00})
+	b.Write(le.AppendUint32(nil, start))
+	b.Write(le.AppendUint16(nil, 2500))
+	b.Write([]byte{0x00})
+	b.Write(le.AppendUint32(nil, start+1))
+	b.Write(le.AppendUint16(nil, 0xFFFF))
+
+	file, err := DecodeBytes(buildFile(b.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, file.Messages, 2)
+	assert.Equal(t, BaseTypeUint16, file.Messages[0].Fields[1].Type)
+
+	require.Len(t, file.Records, 2)
+	assert.Equal(t, Time(start), file.Records[0].Timestamp)
+	require.NotNil(t, file.Records[0].Speed)
+	assert.InDelta(t, 2.5, *file.Records[0].Speed, 1e-9)
+	assert.Nil(t, file.Records[1].Speed, "0xFFFF is the uint16 invalid value")
+}
+
+func TestFile_ActivityStreams(t *testing.T) {
+	start := FromTime(time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC))
+	file, err := DecodeBytes(buildFile(testRecords(start)))
+	require.NoError(t, err)
+
+	got := file.ActivityStreams()
+	assert.Equal(t, 2, got.Len())
+	assert.Equal(t, 2*time.Second, got.Duration())
+	assert.Equal(t, []float64{120, 130}, got.Values(streams.HeartRate))
+	assert.True(t, math.IsNaN(got.Values(streams.Speed)[1]))
+	assert.False(t, got.Has(streams.Power))
+	require.Len(t, got.Polyline, 1)
+	require.NotNil(t, got.Bounds)
+	assert.InDelta(t, 45.5, got.Bounds.MinLat, 1e-6)
+	assert.InDelta(t, -122.25, got.Bounds.MaxLon, 1e-6)
+}
@@ -0,0 +1,11 @@
+// Package fit reads and writes Garmin FIT (Flexible and Interoperable Data
+// Transfer) files. It decodes file headers, definition and data messages,
+// developer fields and compressed timestamps, verifies CRCs, and exposes typed
+// messages for the activity, device and monitoring data most applications need.
+// Record messages convert into streams.ActivityStreams so analysis code works on
+// downloaded files and on the activity details API alike.
+//
+// The encoder writes activity, course, workout and weight files from the typed
+// messages, deriving laps and sessions from records when they are missing, so
+// generated files can be passed straight to garmin.Client.Upload.
+package fit
@@ -0,0 +1,609 @@
+package fit
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// File types from the FIT profile
+const (
+	FileTypeActivity   = 4
+	FileTypeWorkout    = 5
+	FileTypeCourse     = 6
+	FileTypeWeight     = 9
+	FileTypeMonitoring = 15
+)
+
+// Sports from the FIT profile
+const (
+	SportGeneric          = 0
+	SportRunning          = 1
+	SportCycling          = 2
+	SportFitnessEquipment = 4
+	SportSwimming         = 5
+	SportTraining         = 10
+	SportWalking          = 11
+	SportHiking           = 17
+)
+
+// Manufacturer IDs written to the file_id of generated files
+const (
+	ManufacturerGarmin      = 1
+	ManufacturerDevelopment = 255
+)
+
+// Header versions written by the encoder: protocol 2.0, profile 21.32
+const (
+	encoderProtocolVersion = 0x20
+	encoderProfileVersion  = 2132
+)
+
+// Event and event type values used when encoding activities and courses
+const (
+	eventTimer     = 0
+	eventSession   = 8
+	eventLap       = 9
+	eventActivity  = 26
+	eventTypeStart = 0
+	eventTypeStop  = 1
+	eventTypeAll   = 4
+)
+
+// encField is a field to encode. A nil value is written as the base type's
+// invalid pattern; strings are written null-terminated with size bytes.
+type encField struct {
+	num      uint8
+	baseType BaseType
+	value    any
+	size     int
+}
+
+// encoder writes FIT messages, emitting a definition message whenever a
+// message layout differs from the one last defined for its local type
+type encoder struct {
+	buf         bytes.Buffer
+	definitions map[string]uint8
+	locals      [16]string
+	nextLocal   uint8
+}
+
+func newEncoder() *encoder {
+	return &encoder{definitions: make(map[string]uint8)}
+}
+
+// Encode writes f as a FIT file. Activity and course files get timer events,
+// a lap, a session and an activity summary derived from their records when
+// the file does not carry them.
+func Encode(w io.Writer, f *File) error {
+	data, err := EncodeBytes(f)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return formatError("failed to write FIT file", err)
+	}
+	return nil
+}
+
+// EncodeBytes returns f encoded as a FIT file
+func EncodeBytes(f *File) ([]byte, error) {
+	if f.FileID == nil {
+		return nil, &errors.ValidationError{
+			GarthError: errors.GarthError{Message: "FIT file requires a file_id message"},
+			Field:      "FileID",
+		}
+	}
+
+	e := newEncoder()
+	e.writeMessage(MesgNumFileID, fileIDFields(f.FileID))
+
+	switch f.FileID.Type {
+	case FileTypeWorkout:
+		if f.Workout == nil {
+			return nil, &errors.ValidationError{
+				GarthError: errors.GarthError{Message: "workout file requires a workout message"},
+				Field:      "Workout",
+			}
+		}
+		workout := *f.Workout
+		if workout.NumValidSteps == 0 {
+			workout.NumValidSteps = len(f.WorkoutSteps)
+		}
+		e.writeMessage(MesgNumWorkout, workoutFields(&workout))
+		for i := range f.WorkoutSteps {
+			e.writeMessage(MesgNumWorkoutStep, workoutStepFields(i, &f.WorkoutSteps[i]))
+		}
+	case FileTypeWeight:
+		if len(f.WeightScales) == 0 {
+			return nil, &errors.ValidationError{
+				GarthError: errors.GarthError{Message: "weight file requires a weight_scale message"},
+				Field:      "WeightScales",
+			}
+		}
+		for i := range f.WeightScales {
+			e.writeMessage(MesgNumWeightScale, weightScaleFields(&f.WeightScales[i]))
+		}
+	case FileTypeCourse:
+		if f.Course == nil {
+			return nil, &errors.ValidationError{
+				GarthError: errors.GarthError{Message: "course file requires a course message"},
+				Field:      "Course",
+			}
+		}
+		e.writeMessage(MesgNumCourse, courseFields(f.Course))
+		e.writeRecordSequence(f, f.Course.Sport, 0, false)
+		for i := range f.CoursePoints {
+			e.writeMessage(MesgNumCoursePoint, coursePointFields(i, &f.CoursePoints[i]))
+		}
+	default:
+		sport, subSport := SportGeneric, 0
+		if len(f.Sessions) > 0 {
+			sport, subSport = f.Sessions[0].Sport, f.Sessions[0].SubSport
+		}
+		e.writeRecordSequence(f, sport, subSport, f.FileID.Type == FileTypeActivity)
+	}
+
+	return e.finish(), nil
+}
+
+// WriteFile encodes f and writes it to path, ready for Client.Upload
+func WriteFile(path string, f *File) error {
+	data, err := EncodeBytes(f)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return formatError("failed to write FIT file", err)
+	}
+	return nil
+}
+
+// writeRecordSequence writes records framed by timer events, followed by the
+// file's laps and sessions, derived from the records when missing. Activity
+// files are closed by an activity summary message.
+func (e *encoder) writeRecordSequence(f *File, sport, subSport int, activity bool) {
+	if len(f.Records) == 0 {
+		for i := range f.Laps {
+			e.writeMessage(MesgNumLap, lapFields(i, &f.Laps[i]))
+		}
+		e.writeSessions(f.Sessions)
+		return
+	}
+
+	start := f.Records[0].Timestamp
+	end := f.Records[len(f.Records)-1].Timestamp
+	events := f.Events
+	if len(events) == 0 {
+		events = []Event{
+			{Timestamp: start, Event: eventTimer, EventType: eventTypeStart},
+			{Timestamp: end, Event: eventTimer, EventType: eventTypeAll},
+		}
+	}
+	for i := range f.Records {
+		for len(events) > 0 && events[0].precedes(f.Records[i].Timestamp) {
+			e.writeMessage(MesgNumEvent, eventFields(&events[0]))
+			events = events[1:]
+		}
+		e.writeMessage(MesgNumRecord, recordFields(&f.Records[i]))
+	}
+	for i := range events {
+		e.writeMessage(MesgNumEvent, eventFields(&events[i]))
+	}
+
+	laps := f.Laps
+	if len(laps) == 0 {
+		laps = []Lap{summarizeLap(f.Records, sport)}
+	}
+	for i := range laps {
+		e.writeMessage(MesgNumLap, lapFields(i, &laps[i]))
+	}
+	if !activity {
+		return
+	}
+
+	sessions := f.Sessions
+	if len(sessions) == 0 {
+		sessions = []Session{summarizeSession(laps, sport, subSport)}
+	}
+	e.writeSessions(sessions)
+
+	summary := Activity{Timestamp: end, NumSessions: len(sessions)}
+	if f.Activity != nil {
+		summary = *f.Activity
+	}
+	if summary.TotalTimerTime == nil {
+		var total float64
+		for _, s := range sessions {
+			if s.TotalTimerTime != nil {
+				total += *s.TotalTimerTime
+			}
+		}
+		summary.TotalTimerTime = &total
+	}
+	e.writeMessage(MesgNumActivity, activityFields(&summary))
+}
+
+// writeSessions writes the session messages, pointing each at its first lap.
+// Laps are written in session order, so the index accumulates NumLaps.
+func (e *encoder) writeSessions(sessions []Session) {
+	firstLap := 0
+	for i := range sessions {
+		e.writeMessage(MesgNumSession, sessionFields(i, firstLap, &sessions[i]))
+		if sessions[i].NumLaps != nil {
+			firstLap += *sessions[i].NumLaps
+		}
+	}
+}
+
+// precedes reports whether the event is written before a record at t. Stop
+// events follow the record sharing their timestamp, so the timer is still
+// running when the final record is written.
+func (ev *Event) precedes(t time.Time) bool {
+	if ev.EventType == eventTypeStop || ev.EventType == eventTypeAll {
+		return ev.Timestamp.Before(t)
+	}
+	return !ev.Timestamp.After(t)
+}
+
+// finish returns the encoded file: header, messages and trailing CRC
+func (e *encoder) finish() []byte {
+	header := make([]byte, 14)
+	header[0] = 14
+	header[1] = encoderProtocolVersion
+	binary.LittleEndian.PutUint16(header[2:4], encoderProfileVersion)
+	binary.LittleEndian.PutUint32(header[4:8], uint32(e.buf.Len()))
+	copy(header[8:12], ".FIT")
+	binary.LittleEndian.PutUint16(header[12:14], CRC(header[:12]))
+
+	out := make([]byte, 0, len(header)+e.buf.Len()+2)
+	out = append(out, header...)
+	out = append(out, e.buf.Bytes()...)
+	return binary.LittleEndian.AppendUint16(out, CRC(out))
+}
+
+// writeMessage writes a data message, preceded by its definition when needed
+func (e *encoder) writeMessage(num MesgNum, fields []encField) {
+	for i := range fields {
+		if fields[i].size == 0 {
+			fields[i].size = fields[i].baseType.Size()
+		}
+	}
+
+	key := definitionKey(num, fields)
+	local, ok := e.definitions[key]
+	if !ok {
+		local = e.nextLocal
+		e.nextLocal = (e.nextLocal + 1) % 16
+		delete(e.definitions, e.locals[local])
+		e.definitions[key] = local
+		e.locals[local] = key
+		e.writeDefinition(local, num, fields)
+	}
+
+	e.buf.WriteByte(local)
+	for _, f := range fields {
+		e.buf.Write(encodeValue(f))
+	}
+}
+
+// writeDefinition writes a little-endian definition message
+func (e *encoder) writeDefinition(local uint8, num MesgNum, fields []encField) {
+	e.buf.WriteByte(0x40 | local)
+	e.buf.WriteByte(0) // reserved
+	e.buf.WriteByte(0) // little-endian
+	e.buf.Write(binary.LittleEndian.AppendUint16(nil, uint16(num)))
+	e.buf.WriteByte(byte(len(fields)))
+	for _, f := range fields {
+		e.buf.Write([]byte{f.num, byte(f.size), byte(f.baseType)})
+	}
+}
+
+// definitionKey identifies a message layout
+func definitionKey(num MesgNum, fields []encField) string {
+	key := make([]byte, 0, 2+3*len(fields))
+	key = binary.LittleEndian.AppendUint16(key, uint16(num))
+	for _, f := range fields {
+		key = append(key, f.num, byte(f.size), byte(f.baseType))
+	}
+	return string(key)
+}
+
+// encodeValue returns the little-endian bytes of a field value
+func encodeValue(f encField) []byte {
+	out := make([]byte, f.size)
+	if s, ok := f.value.(string); ok && f.baseType == BaseTypeString {
+		copy(out, s)
+		return out
+	}
+
+	var bits uint64
+	switch v := f.value.(type) {
+	case nil:
+		bits = f.baseType.invalid()
+	case uint64:
+		bits = v
+	case int64:
+		bits = uint64(v)
+	case float64:
+		if f.baseType == BaseTypeFloat32 {
+			bits = uint64(math.Float32bits(float32(v)))
+		} else {
+			bits = math.Float64bits(v)
+		}
+	}
+	for i := 0; i < f.size; i++ {
+		out[i] = byte(bits >> (8 * uint(i%f.baseType.Size())))
+	}
+	return out
+}
+
+// uintField, intField and enumField build integer fields; the value is
+// clamped out (written as invalid) when it does not fit the base type
+func uintField(num uint8, t BaseType, v uint64) encField {
+	if t.Size() < 8 && v >= 1<<(8*uint(t.Size()))-1 {
+		return encField{num: num, baseType: t}
+	}
+	return encField{num: num, baseType: t, value: v}
+}
+
+func intField(num uint8, t BaseType, v int64) encField {
+	limit := int64(1)<<(8*uint(t.Size())-1) - 1
+	if v >= limit || v < -limit {
+		return encField{num: num, baseType: t}
+	}
+	return encField{num: num, baseType: t, value: v}
+}
+
+func enumField(num uint8, v int) encField {
+	return uintField(num, BaseTypeEnum, uint64(v))
+}
+
+// maxStringLength is the longest string a field can hold: field sizes are one
+// byte and include the null terminator
+const maxStringLength = 254
+
+// stringField builds a null-terminated string field, truncating s on a UTF-8
+// boundary when it does not fit
+func stringField(num uint8, s string) encField {
+	if len(s) > maxStringLength {
+		n := maxStringLength
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n]
+	}
+	return encField{num: num, baseType: BaseTypeString, value: s, size: len(s) + 1}
+}
+
+func timeField(num uint8, t time.Time) encField {
+	if t.IsZero() {
+		return encField{num: num, baseType: BaseTypeUint32}
+	}
+	return uintField(num, BaseTypeUint32, uint64(FromTime(t)))
+}
+
+// scaledField stores round((v + offset) * scale), or invalid when v is nil
+// or negative for an unsigned type
+func scaledField(num uint8, t BaseType, v *float64, scale, offset float64) encField {
+	if v == nil || math.IsNaN(*v) {
+		return encField{num: num, baseType: t}
+	}
+	raw := math.Round((*v + offset) * scale)
+	if t.signed() {
+		return intField(num, t, int64(raw))
+	}
+	if raw < 0 {
+		return encField{num: num, baseType: t}
+	}
+	return uintField(num, t, uint64(raw))
+}
+
+func optUintField(num uint8, t BaseType, v *int) encField {
+	if v == nil || *v < 0 {
+		return encField{num: num, baseType: t}
+	}
+	return uintField(num, t, uint64(*v))
+}
+
+func optIntField(num uint8, t BaseType, v *int) encField {
+	if v == nil {
+		return encField{num: num, baseType: t}
+	}
+	return intField(num, t, int64(*v))
+}
+
+func degreesField(num uint8, v *float64) encField {
+	if v == nil || math.IsNaN(*v) {
+		return encField{num: num, baseType: BaseTypeSint32}
+	}
+	return intField(num, BaseTypeSint32, int64(Semicircles(*v)))
+}
+
+func fileIDFields(id *FileID) []encField {
+	fields := []encField{
+		enumField(0, id.Type),
+		uintField(1, BaseTypeUint16, uint64(id.Manufacturer)),
+		uintField(2, BaseTypeUint16, uint64(id.Product)),
+		{num: 3, baseType: BaseTypeUint32z, value: uint64(id.SerialNumber)},
+		timeField(4, id.TimeCreated),
+	}
+	if id.Number != 0 {
+		fields = append(fields, uintField(5, BaseTypeUint16, uint64(id.Number)))
+	}
+	if id.ProductName != "" {
+		fields = append(fields, stringField(8, id.ProductName))
+	}
+	return fields
+}
+
+func recordFields(r *Record) []encField {
+	return []encField{
+		timeField(timestampFieldNum, r.Timestamp),
+		degreesField(0, r.Lat),
+		degreesField(1, r.Lon),
+		scaledField(2, BaseTypeUint16, r.Altitude, 5, 500),
+		optUintField(3, BaseTypeUint8, r.HeartRate),
+		optUintField(4, BaseTypeUint8, r.Cadence),
+		scaledField(5, BaseTypeUint32, r.Distance, 100, 0),
+		scaledField(6, BaseTypeUint16, r.Speed, 1000, 0),
+		optUintField(7, BaseTypeUint16, r.Power),
+		scaledField(9, BaseTypeSint16, r.Grade, 100, 0),
+		optIntField(13, BaseTypeSint8, r.Temperature),
+		scaledField(73, BaseTypeUint32, r.Speed, 1000, 0),
+		scaledField(78, BaseTypeUint32, r.Altitude, 5, 500),
+	}
+}
+
+func eventFields(ev *Event) []encField {
+	return []encField{
+		timeField(timestampFieldNum, ev.Timestamp),
+		enumField(0, ev.Event),
+		enumField(1, ev.EventType),
+		optUintField(3, BaseTypeUint32, ev.Data),
+		optUintField(4, BaseTypeUint8, ev.EventGroup),
+	}
+}
+
+func lapFields(index int, l *Lap) []encField {
+	return []encField{
+		uintField(254, BaseTypeUint16, uint64(index)),
+		timeField(timestampFieldNum, l.Timestamp),
+		enumField(0, eventLap),
+		enumField(1, eventTypeStop),
+		timeField(2, l.StartTime),
+		degreesField(3, l.StartLat),
+		degreesField(4, l.StartLon),
+		degreesField(5, l.EndLat),
+		degreesField(6, l.EndLon),
+		scaledField(7, BaseTypeUint32, l.TotalElapsedTime, 1000, 0),
+		scaledField(8, BaseTypeUint32, l.TotalTimerTime, 1000, 0),
+		scaledField(9, BaseTypeUint32, l.TotalDistance, 100, 0),
+		optUintField(11, BaseTypeUint16, l.TotalCalories),
+		scaledField(13, BaseTypeUint16, l.AvgSpeed, 1000, 0),
+		scaledField(14, BaseTypeUint16, l.MaxSpeed, 1000, 0),
+		optUintField(15, BaseTypeUint8, l.AvgHeartRate),
+		optUintField(16, BaseTypeUint8, l.MaxHeartRate),
+		optUintField(17, BaseTypeUint8, l.AvgCadence),
+		optUintField(18, BaseTypeUint8, l.MaxCadence),
+		optUintField(19, BaseTypeUint16, l.AvgPower),
+		optUintField(20, BaseTypeUint16, l.MaxPower),
+		optUintField(21, BaseTypeUint16, l.TotalAscent),
+		optUintField(22, BaseTypeUint16, l.TotalDescent),
+		enumField(24, l.LapTrigger),
+		enumField(25, l.Sport),
+		scaledField(110, BaseTypeUint32, l.AvgSpeed, 1000, 0),
+		scaledField(111, BaseTypeUint32, l.MaxSpeed, 1000, 0),
+	}
+}
+
+func sessionFields(index, firstLap int, s *Session) []encField {
+	return []encField{
+		uintField(254, BaseTypeUint16, uint64(index)),
+		timeField(timestampFieldNum, s.Timestamp),
+		enumField(0, eventSession),
+		enumField(1, eventTypeStop),
+		timeField(2, s.StartTime),
+		degreesField(3, s.StartLat),
+		degreesField(4, s.StartLon),
+		enumField(5, s.Sport),
+		enumField(6, s.SubSport),
+		scaledField(7, BaseTypeUint32, s.TotalElapsedTime, 1000, 0),
+		scaledField(8, BaseTypeUint32, s.TotalTimerTime, 1000, 0),
+		scaledField(9, BaseTypeUint32, s.TotalDistance, 100, 0),
+		optUintField(11, BaseTypeUint16, s.TotalCalories),
+		scaledField(14, BaseTypeUint16, s.AvgSpeed, 1000, 0),
+		scaledField(15, BaseTypeUint16, s.MaxSpeed, 1000, 0),
+		optUintField(16, BaseTypeUint8, s.AvgHeartRate),
+		optUintField(17, BaseTypeUint8, s.MaxHeartRate),
+		optUintField(18, BaseTypeUint8, s.AvgCadence),
+		optUintField(19, BaseTypeUint8, s.MaxCadence),
+		optUintField(20, BaseTypeUint16, s.AvgPower),
+		optUintField(21, BaseTypeUint16, s.MaxPower),
+		optUintField(22, BaseTypeUint16, s.TotalAscent),
+		optUintField(23, BaseTypeUint16, s.TotalDescent),
+		uintField(25, BaseTypeUint16, uint64(firstLap)),
+		optUintField(26, BaseTypeUint16, s.NumLaps),
+		enumField(28, 0), // trigger: activity end
+		scaledField(124, BaseTypeUint32, s.AvgSpeed, 1000, 0),
+		scaledField(125, BaseTypeUint32, s.MaxSpeed, 1000, 0),
+	}
+}
+
+func activityFields(a *Activity) []encField {
+	local := a.LocalTimestamp
+	if local.IsZero() {
+		_, offset := a.Timestamp.Zone()
+		local = a.Timestamp.Add(time.Duration(offset) * time.Second)
+	}
+	return []encField{
+		timeField(timestampFieldNum, a.Timestamp),
+		scaledField(0, BaseTypeUint32, a.TotalTimerTime, 1000, 0),
+		uintField(1, BaseTypeUint16, uint64(a.NumSessions)),
+		enumField(2, 0), // type: manual
+		enumField(3, eventActivity),
+		enumField(4, eventTypeStop),
+		timeField(5, local),
+	}
+}
+
+func courseFields(c *Course) []encField {
+	return []encField{
+		enumField(4, c.Sport),
+		stringField(5, c.Name),
+		enumField(7, c.SubSport),
+	}
+}
+
+func coursePointFields(index int, p *CoursePoint) []encField {
+	return []encField{
+		uintField(254, BaseTypeUint16, uint64(index)),
+		timeField(1, p.Timestamp),
+		degreesField(2, p.Lat),
+		degreesField(3, p.Lon),
+		scaledField(4, BaseTypeUint32, p.Distance, 100, 0),
+		enumField(5, p.Type),
+		stringField(6, p.Name),
+	}
+}
+
+func workoutFields(w *Workout) []encField {
+	return []encField{
+		enumField(4, w.Sport),
+		uintField(6, BaseTypeUint16, uint64(w.NumValidSteps)),
+		stringField(8, w.Name),
+		enumField(11, w.SubSport),
+	}
+}
+
+func workoutStepFields(index int, s *WorkoutStep) []encField {
+	return []encField{
+		uintField(254, BaseTypeUint16, uint64(index)),
+		stringField(0, s.Name),
+		enumField(1, s.DurationType),
+		uintField(2, BaseTypeUint32, uint64(s.DurationValue)),
+		enumField(3, s.TargetType),
+		uintField(4, BaseTypeUint32, uint64(s.TargetValue)),
+		uintField(5, BaseTypeUint32, uint64(s.CustomTargetValueLow)),
+		uintField(6, BaseTypeUint32, uint64(s.CustomTargetValueHigh)),
+		enumField(7, s.Intensity),
+		stringField(8, s.Notes),
+	}
+}
+
+func weightScaleFields(w *WeightScale) []encField {
+	return []encField{
+		timeField(timestampFieldNum, w.Timestamp),
+		scaledField(0, BaseTypeUint16, w.Weight, 100, 0),
+		scaledField(1, BaseTypeUint16, w.PercentFat, 100, 0),
+		scaledField(2, BaseTypeUint16, w.PercentHydration, 100, 0),
+		scaledField(4, BaseTypeUint16, w.BoneMass, 100, 0),
+		scaledField(5, BaseTypeUint16, w.MuscleMass, 100, 0),
+	}
+}
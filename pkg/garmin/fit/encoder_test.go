@@ -0,0 +1,197 @@
+package fit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float(v float64) *float64 { return &v }
+func integer(v int) *int       { return &v }
+
+func testActivityRecords(start time.Time) []Record {
+	records := make([]Record, 0, 5)
+	for i := 0; i < 5; i++ {
+		records = append(records, Record{
+			Timestamp: start.Add(time.Duration(i) * time.Second),
+			Lat:       float(45.5 + float64(i)*0.0001),
+			Lon:       float(-122.25),
+			Altitude:  float(100 + float64(i)),
+			HeartRate: integer(120 + i),
+			Distance:  float(float64(i) * 3),
+			Speed:     float(3),
+			Power:     integer(200),
+		})
+	}
+	return records
+}
+
+func TestEncodeBytes_ActivityRoundTrip(t *testing.T) {
+	start := time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC)
+	data, err := EncodeBytes(NewActivityFile(SportRunning, 0, testActivityRecords(start)))
+	require.NoError(t, err)
+
+	decoded, err := DecodeBytes(data)
+	require.NoError(t, err)
+
+	require.NotNil(t, decoded.FileID)
+	assert.Equal(t, FileTypeActivity, decoded.FileID.Type)
+	require.Len(t, decoded.Records, 5)
+	last := decoded.Records[4]
+	assert.Equal(t, start.Add(4*time.Second), last.Timestamp)
+	assert.InDelta(t, 45.5004, *last.Lat, 1e-6)
+	assert.InDelta(t, 104, *last.Altitude, 0.2)
+	assert.Equal(t, 124, *last.HeartRate)
+	assert.InDelta(t, 12, *last.Distance, 0.01)
+	assert.InDelta(t, 3, *last.Speed, 0.001)
+	assert.Nil(t, last.Cadence)
+
+	require.Len(t, decoded.Events, 2)
+	require.Len(t, decoded.Laps, 1)
+	require.Len(t, decoded.Sessions, 1)
+	session := decoded.Sessions[0]
+	assert.Equal(t, SportRunning, session.Sport)
+	assert.InDelta(t, 4, *session.TotalElapsedTime, 0.001)
+	assert.InDelta(t, 12, *session.TotalDistance, 0.01)
+	assert.Equal(t, 122, *session.AvgHeartRate)
+	assert.Equal(t, 124, *session.MaxHeartRate)
+	assert.Equal(t, 4, *session.TotalAscent)
+	require.NotNil(t, decoded.Activity)
+	assert.Equal(t, 1, decoded.Activity.NumSessions)
+}
+
+func TestEncodeBytes_EventOrder(t *testing.T) {
+	start := time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC)
+	data, err := EncodeBytes(NewActivityFile(SportRunning, 0, testActivityRecords(start)))
+	require.NoError(t, err)
+
+	decoded, err := DecodeBytes(data)
+	require.NoError(t, err)
+
+	var order []string
+	for _, m := range decoded.Messages {
+		switch m.Num {
+		case MesgNumEvent:
+			order = append(order, "event")
+		case MesgNumRecord:
+			order = append(order, "record")
+		}
+	}
+	assert.Equal(t, []string{"event", "record", "record", "record", "record", "record", "event"}, order,
+		"the timer starts before the first record and stops after the last")
+}
+
+func TestEncodeBytes_MultisportFirstLapIndex(t *testing.T) {
+	start := time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC)
+	f := &File{
+		FileID: &FileID{Type: FileTypeActivity, Manufacturer: ManufacturerDevelopment, TimeCreated: start},
+		Laps:   []Lap{{StartTime: start}, {StartTime: start}, {StartTime: start}},
+		Sessions: []Session{
+			{StartTime: start, Sport: SportCycling, NumLaps: integer(2)},
+			{StartTime: start, Sport: SportRunning, NumLaps: integer(1)},
+		},
+	}
+	data, err := EncodeBytes(f)
+	require.NoError(t, err)
+
+	decoded, err := DecodeBytes(data)
+	require.NoError(t, err)
+
+	var firstLaps []int
+	for _, m := range decoded.Messages {
+		if m.Num == MesgNumSession {
+			firstLaps = append(firstLaps, *m.optInt(25))
+		}
+	}
+	assert.Equal(t, []int{0, 2}, firstLaps)
+}
+
+func TestEncodeBytes_Course(t *testing.T) {
+	start := time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC)
+	f := NewCourseFile("Loop", SportCycling, testActivityRecords(start), []CoursePoint{
+		{Timestamp: start.Add(2 * time.Second), Lat: float(45.5002), Lon: float(-122.25), Distance: float(6), Type: 6, Name: "Left"},
+	})
+	data, err := EncodeBytes(f)
+	require.NoError(t, err)
+
+	decoded, err := DecodeBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, FileTypeCourse, decoded.FileID.Type)
+	require.NotNil(t, decoded.Course)
+	assert.Equal(t, "Loop", decoded.Course.Name)
+	assert.Equal(t, SportCycling, decoded.Course.Sport)
+	assert.Len(t, decoded.Records, 5)
+	assert.Len(t, decoded.Laps, 1)
+	assert.Empty(t, decoded.Sessions)
+	require.Len(t, decoded.CoursePoints, 1)
+	assert.Equal(t, "Left", decoded.CoursePoints[0].Name)
+	assert.Equal(t, 6, decoded.CoursePoints[0].Type)
+	assert.InDelta(t, 6, *decoded.CoursePoints[0].Distance, 0.01)
+}
+
+func TestEncodeBytes_LongString(t *testing.T) {
+	name := strings.Repeat("a", 253) + "ü" + strings.Repeat("b", 10)
+	data, err := EncodeBytes(NewWorkoutFile(name, SportRunning, nil))
+	require.NoError(t, err)
+
+	decoded, err := DecodeBytes(data)
+	require.NoError(t, err)
+	require.NotNil(t, decoded.Workout)
+	assert.Equal(t, strings.Repeat("a", 253), decoded.Workout.Name, "truncated before the split rune")
+}
+
+func TestEncodeBytes_Workout(t *testing.T) {
+	f := NewWorkoutFile("Intervals", SportRunning, []WorkoutStep{
+		{Name: "Warm up", DurationType: 0, DurationValue: 600000, TargetType: 2, Intensity: 2},
+		{Name: "Fast", DurationType: 1, DurationValue: 40000, TargetType: 1, CustomTargetValueLow: 260, CustomTargetValueHigh: 270},
+		{DurationType: 6, DurationValue: 1, TargetValue: 4},
+	})
+	data, err := EncodeBytes(f)
+	require.NoError(t, err)
+
+	decoded, err := DecodeBytes(data)
+	require.NoError(t, err)
+	require.NotNil(t, decoded.Workout)
+	assert.Equal(t, "Intervals", decoded.Workout.Name)
+	assert.Equal(t, 3, decoded.Workout.NumValidSteps)
+	require.Len(t, decoded.WorkoutSteps, 3)
+	assert.Equal(t, "Fast", decoded.WorkoutSteps[1].Name)
+	assert.Equal(t, uint32(40000), decoded.WorkoutSteps[1].DurationValue)
+	assert.Equal(t, uint32(270), decoded.WorkoutSteps[1].CustomTargetValueHigh)
+	assert.Equal(t, 2, decoded.WorkoutSteps[0].Intensity)
+	assert.Equal(t, uint32(4), decoded.WorkoutSteps[2].TargetValue)
+}
+
+func TestEncodeBytes_Weight(t *testing.T) {
+	at := time.Date(2025, 3, 2, 7, 30, 0, 0, time.UTC)
+	f := NewWeightFile(WeightScale{Timestamp: at, Weight: float(81.25), PercentFat: float(18.4), BoneMass: float(3.2)})
+	data, err := EncodeBytes(f)
+	require.NoError(t, err)
+
+	decoded, err := DecodeBytes(data)
+	require.NoError(t, err)
+	assert.Equal(t, FileTypeWeight, decoded.FileID.Type)
+	assert.Equal(t, FromTime(at), decoded.FileID.SerialNumber)
+	require.Len(t, decoded.WeightScales, 1)
+	w := decoded.WeightScales[0]
+	assert.Equal(t, at, w.Timestamp)
+	assert.InDelta(t, 81.25, *w.Weight, 1e-9)
+	assert.InDelta(t, 18.4, *w.PercentFat, 1e-9)
+	assert.InDelta(t, 3.2, *w.BoneMass, 1e-9)
+	assert.Nil(t, w.PercentHydration)
+	assert.Nil(t, w.MuscleMass)
+
+	_, err = EncodeBytes(NewWeightFile())
+	assert.Error(t, err)
+}
+
+func TestEncodeBytes_RequiresFileID(t *testing.T) {
+	_, err := EncodeBytes(&File{})
+	assert.Error(t, err)
+
+	_, err = EncodeBytes(&File{FileID: &FileID{Type: FileTypeWorkout}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package fit
+
+import "time"
+
+// Field is a decoded field of a data message. Value holds an int64, uint64,
+// float64, string or []byte for single values, and a []int64, []uint64 or
+// []float64 for array fields. Invalid (missing) values are not decoded.
+type Field struct {
+	Num   uint8
+	Type  BaseType
+	Value any
+}
+
+// DeveloperField is a decoded developer data field, described by a preceding
+// field_description message
+type DeveloperField struct {
+	DeveloperDataIndex uint8
+	Num                uint8
+	Name               string
+	Units              string
+	Value              any
+}
+
+// Message is a decoded data message
+type Message struct {
+	Num             MesgNum
+	Fields          []Field
+	DeveloperFields []DeveloperField
+}
+
+// Field returns the value of the field with the given number
+func (m *Message) Field(num uint8) (any, bool) {
+	for _, f := range m.Fields {
+		if f.Num == num {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Uint returns an integer field as uint64
+func (m *Message) Uint(num uint8) (uint64, bool) {
+	v, ok := m.Field(num)
+	if !ok {
+		return 0, false
+	}
+	switch t := v.(type) {
+	case uint64:
+		return t, true
+	case int64:
+		return uint64(t), true
+	case float64:
+		return uint64(t), true
+	}
+	return 0, false
+}
+
+// Int returns an integer field as int64
+func (m *Message) Int(num uint8) (int64, bool) {
+	v, ok := m.Field(num)
+	if !ok {
+		return 0, false
+	}
+	switch t := v.(type) {
+	case int64:
+		return t, true
+	case uint64:
+		return int64(t), true
+	case float64:
+		return int64(t), true
+	}
+	return 0, false
+}
+
+// Float returns a numeric field converted with the profile scale and offset,
+// i.e. value/scale - offset
+func (m *Message) Float(num uint8, scale, offset float64) (float64, bool) {
+	v, ok := m.Field(num)
+	if !ok {
+		return 0, false
+	}
+	var f float64
+	switch t := v.(type) {
+	case int64:
+		f = float64(t)
+	case uint64:
+		f = float64(t)
+	case float64:
+		f = t
+	default:
+		return 0, false
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	return f/scale - offset, true
+}
+
+// String returns a string field
+func (m *Message) String(num uint8) (string, bool) {
+	v, ok := m.Field(num)
+	if !ok {
+		return "", false
+	}
+	s, ok := v.(string)
+	return s, ok
+}
+
+// Time returns a date_time field
+func (m *Message) Time(num uint8) (time.Time, bool) {
+	v, ok := m.Uint(num)
+	if !ok {
+		return time.Time{}, false
+	}
+	return Time(uint32(v)), true
+}
+
+// Floats returns an array field converted with the profile scale and offset.
+// A single value is returned as a one-element slice.
+func (m *Message) Floats(num uint8, scale, offset float64) []float64 {
+	v, ok := m.Field(num)
+	if !ok {
+		return nil
+	}
+	if scale == 0 {
+		scale = 1
+	}
+	var out []float64
+	switch t := v.(type) {
+	case []uint64:
+		for _, x := range t {
+			out = append(out, float64(x)/scale-offset)
+		}
+	case []int64:
+		for _, x := range t {
+			out = append(out, float64(x)/scale-offset)
+		}
+	case []float64:
+		for _, x := range t {
+			out = append(out, x/scale-offset)
+		}
+	default:
+		if f, ok := m.Float(num, scale, offset); ok {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// optFloat returns a pointer to a scaled field, or nil when it is missing
+func (m *Message) optFloat(num uint8, scale, offset float64) *float64 {
+	if f, ok := m.Float(num, scale, offset); ok {
+		return &f
+	}
+	return nil
+}
+
+// optInt returns a pointer to an integer field, or nil when it is missing
+func (m *Message) optInt(num uint8) *int {
+	if v, ok := m.Int(num); ok {
+		i := int(v)
+		return &i
+	}
+	return nil
+}
+
+// optDegrees returns a pointer to a semicircle field in degrees
+func (m *Message) optDegrees(num uint8) *float64 {
+	if v, ok := m.Int(num); ok {
+		d := Degrees(int32(v))
+		return &d
+	}
+	return nil
+}
+
+// optTime returns a date_time field, or the zero time when it is missing
+func (m *Message) optTime(num uint8) time.Time {
+	t, _ := m.Time(num)
+	return t
+}
@@ -0,0 +1,522 @@
+package fit
+
+import "time"
+
+// File is a decoded FIT file. Messages holds every data message in file order;
+// the typed slices hold the messages the package knows how to interpret.
+type File struct {
+	Header       Header
+	Messages     []Message
+	FileID       *FileID
+	Sessions     []Session
+	Laps         []Lap
+	Records      []Record
+	Events       []Event
+	DeviceInfos  []DeviceInfo
+	HRV          []HRV
+	Monitoring   []Monitoring
+	Activity     *Activity
+	Course       *Course
+	CoursePoints []CoursePoint
+	Workout      *Workout
+	WorkoutSteps []WorkoutStep
+	WeightScales []WeightScale
+}
+
+// add appends a decoded message and its typed form
+func (f *File) add(m *Message) {
+	f.Messages = append(f.Messages, *m)
+	switch m.Num {
+	case MesgNumFileID:
+		fileID := newFileID(m)
+		f.FileID = &fileID
+	case MesgNumSession:
+		f.Sessions = append(f.Sessions, newSession(m))
+	case MesgNumLap:
+		f.Laps = append(f.Laps, newLap(m))
+	case MesgNumRecord:
+		f.Records = append(f.Records, newRecord(m))
+	case MesgNumEvent:
+		f.Events = append(f.Events, newEvent(m))
+	case MesgNumDeviceInfo:
+		f.DeviceInfos = append(f.DeviceInfos, newDeviceInfo(m))
+	case MesgNumHRV:
+		f.HRV = append(f.HRV, newHRV(m))
+	case MesgNumMonitoring:
+		f.Monitoring = append(f.Monitoring, newMonitoring(m))
+	case MesgNumActivity:
+		activity := newActivity(m)
+		f.Activity = &activity
+	case MesgNumCourse:
+		course := newCourse(m)
+		f.Course = &course
+	case MesgNumCoursePoint:
+		f.CoursePoints = append(f.CoursePoints, newCoursePoint(m))
+	case MesgNumWorkout:
+		workout := newWorkout(m)
+		f.Workout = &workout
+	case MesgNumWorkoutStep:
+		f.WorkoutSteps = append(f.WorkoutSteps, newWorkoutStep(m))
+	case MesgNumWeightScale:
+		f.WeightScales = append(f.WeightScales, newWeightScale(m))
+	}
+}
+
+// FileID identifies the file type and the device that created it
+type FileID struct {
+	Type         int // 4 = activity, 5 = workout, 6 = course, 9 = weight, 15 = monitoring
+	Manufacturer int // 1 = Garmin, 255 = development
+	Product      int
+	SerialNumber uint32
+	TimeCreated  time.Time
+	Number       int
+	ProductName  string
+}
+
+func newFileID(m *Message) FileID {
+	id := FileID{TimeCreated: m.optTime(4)}
+	if v, ok := m.Int(0); ok {
+		id.Type = int(v)
+	}
+	if v, ok := m.Int(1); ok {
+		id.Manufacturer = int(v)
+	}
+	if v, ok := m.Int(2); ok {
+		id.Product = int(v)
+	}
+	if v, ok := m.Uint(3); ok {
+		id.SerialNumber = uint32(v)
+	}
+	if v, ok := m.Int(5); ok {
+		id.Number = int(v)
+	}
+	id.ProductName, _ = m.String(8)
+	return id
+}
+
+// Session summarises a complete activity or multisport leg
+type Session struct {
+	Timestamp        time.Time
+	StartTime        time.Time
+	Sport            int
+	SubSport         int
+	StartLat         *float64 // degrees
+	StartLon         *float64 // degrees
+	TotalElapsedTime *float64 // seconds
+	TotalTimerTime   *float64 // seconds
+	TotalDistance    *float64 // meters
+	TotalCalories    *int     // kcal
+	AvgSpeed         *float64 // m/s
+	MaxSpeed         *float64 // m/s
+	AvgHeartRate     *int     // bpm
+	MaxHeartRate     *int     // bpm
+	AvgCadence       *int     // rpm
+	MaxCadence       *int     // rpm
+	AvgPower         *int     // watts
+	MaxPower         *int     // watts
+	TotalAscent      *int     // meters
+	TotalDescent     *int     // meters
+	NumLaps          *int
+}
+
+func newSession(m *Message) Session {
+	s := Session{
+		Timestamp:        m.optTime(timestampFieldNum),
+		StartTime:        m.optTime(2),
+		StartLat:         m.optDegrees(3),
+		StartLon:         m.optDegrees(4),
+		TotalElapsedTime: m.optFloat(7, 1000, 0),
+		TotalTimerTime:   m.optFloat(8, 1000, 0),
+		TotalDistance:    m.optFloat(9, 100, 0),
+		TotalCalories:    m.optInt(11),
+		AvgSpeed:         m.optFloat(14, 1000, 0),
+		MaxSpeed:         m.optFloat(15, 1000, 0),
+		AvgHeartRate:     m.optInt(16),
+		MaxHeartRate:     m.optInt(17),
+		AvgCadence:       m.optInt(18),
+		MaxCadence:       m.optInt(19),
+		AvgPower:         m.optInt(20),
+		MaxPower:         m.optInt(21),
+		TotalAscent:      m.optInt(22),
+		TotalDescent:     m.optInt(23),
+		NumLaps:          m.optInt(26),
+	}
+	if v, ok := m.Int(5); ok {
+		s.Sport = int(v)
+	}
+	if v, ok := m.Int(6); ok {
+		s.SubSport = int(v)
+	}
+	// Enhanced fields supersede the 16-bit speed fields when present
+	if v := m.optFloat(124, 1000, 0); v != nil {
+		s.AvgSpeed = v
+	}
+	if v := m.optFloat(125, 1000, 0); v != nil {
+		s.MaxSpeed = v
+	}
+	return s
+}
+
+// Lap summarises a single lap of an activity
+type Lap struct {
+	Timestamp        time.Time
+	StartTime        time.Time
+	MessageIndex     int
+	StartLat         *float64 // degrees
+	StartLon         *float64 // degrees
+	EndLat           *float64 // degrees
+	EndLon           *float64 // degrees
+	TotalElapsedTime *float64 // seconds
+	TotalTimerTime   *float64 // seconds
+	TotalDistance    *float64 // meters
+	TotalCalories    *int     // kcal
+	AvgSpeed         *float64 // m/s
+	MaxSpeed         *float64 // m/s
+	AvgHeartRate     *int     // bpm
+	MaxHeartRate     *int     // bpm
+	AvgCadence       *int     // rpm
+	MaxCadence       *int     // rpm
+	AvgPower         *int     // watts
+	MaxPower         *int     // watts
+	TotalAscent      *int     // meters
+	TotalDescent     *int     // meters
+	LapTrigger       int
+	Sport            int
+}
+
+func newLap(m *Message) Lap {
+	l := Lap{
+		Timestamp:        m.optTime(timestampFieldNum),
+		StartTime:        m.optTime(2),
+		StartLat:         m.optDegrees(3),
+		StartLon:         m.optDegrees(4),
+		EndLat:           m.optDegrees(5),
+		EndLon:           m.optDegrees(6),
+		TotalElapsedTime: m.optFloat(7, 1000, 0),
+		TotalTimerTime:   m.optFloat(8, 1000, 0),
+		TotalDistance:    m.optFloat(9, 100, 0),
+		TotalCalories:    m.optInt(11),
+		AvgSpeed:         m.optFloat(13, 1000, 0),
+		MaxSpeed:         m.optFloat(14, 1000, 0),
+		AvgHeartRate:     m.optInt(15),
+		MaxHeartRate:     m.optInt(16),
+		AvgCadence:       m.optInt(17),
+		MaxCadence:       m.optInt(18),
+		AvgPower:         m.optInt(19),
+		MaxPower:         m.optInt(20),
+		TotalAscent:      m.optInt(21),
+		TotalDescent:     m.optInt(22),
+	}
+	if v, ok := m.Int(254); ok {
+		l.MessageIndex = int(v)
+	}
+	if v, ok := m.Int(24); ok {
+		l.LapTrigger = int(v)
+	}
+	if v, ok := m.Int(25); ok {
+		l.Sport = int(v)
+	}
+	if v := m.optFloat(110, 1000, 0); v != nil {
+		l.AvgSpeed = v
+	}
+	if v := m.optFloat(111, 1000, 0); v != nil {
+		l.MaxSpeed = v
+	}
+	return l
+}
+
+// Record is a single sample of an activity's time series
+type Record struct {
+	Timestamp   time.Time
+	Lat         *float64 // degrees
+	Lon         *float64 // degrees
+	Altitude    *float64 // meters
+	HeartRate   *int     // bpm
+	Cadence     *int     // rpm
+	Distance    *float64 // meters
+	Speed       *float64 // m/s
+	Power       *int     // watts
+	Grade       *float64 // percent
+	Temperature *int     // degrees Celsius
+	// DeveloperFields holds connect IQ and other developer data for the sample
+	DeveloperFields []DeveloperField
+}
+
+func newRecord(m *Message) Record {
+	r := Record{
+		Timestamp:       m.optTime(timestampFieldNum),
+		Lat:             m.optDegrees(0),
+		Lon:             m.optDegrees(1),
+		Altitude:        m.optFloat(2, 5, 500),
+		HeartRate:       m.optInt(3),
+		Cadence:         m.optInt(4),
+		Distance:        m.optFloat(5, 100, 0),
+		Speed:           m.optFloat(6, 1000, 0),
+		Power:           m.optInt(7),
+		Grade:           m.optFloat(9, 100, 0),
+		Temperature:     m.optInt(13),
+		DeveloperFields: m.DeveloperFields,
+	}
+	if v := m.optFloat(73, 1000, 0); v != nil {
+		r.Speed = v
+	}
+	if v := m.optFloat(78, 5, 500); v != nil {
+		r.Altitude = v
+	}
+	return r
+}
+
+// Event marks timer starts and stops, laps and other activity events
+type Event struct {
+	Timestamp  time.Time
+	Event      int // 0 = timer, 9 = lap, 26 = activity
+	EventType  int // 0 = start, 1 = stop, 4 = stop all
+	Data       *int
+	EventGroup *int
+}
+
+func newEvent(m *Message) Event {
+	e := Event{
+		Timestamp:  m.optTime(timestampFieldNum),
+		Data:       m.optInt(3),
+		EventGroup: m.optInt(4),
+	}
+	if v, ok := m.Int(0); ok {
+		e.Event = int(v)
+	}
+	if v, ok := m.Int(1); ok {
+		e.EventType = int(v)
+	}
+	return e
+}
+
+// DeviceInfo describes the recording device or a connected sensor
+type DeviceInfo struct {
+	Timestamp       time.Time
+	DeviceIndex     *int
+	DeviceType      *int
+	Manufacturer    *int
+	SerialNumber    *uint32
+	Product         *int
+	SoftwareVersion *float64
+	HardwareVersion *int
+	BatteryVoltage  *float64 // volts
+	BatteryStatus   *int
+	ProductName     string
+}
+
+func newDeviceInfo(m *Message) DeviceInfo {
+	d := DeviceInfo{
+		Timestamp:       m.optTime(timestampFieldNum),
+		DeviceIndex:     m.optInt(0),
+		DeviceType:      m.optInt(1),
+		Manufacturer:    m.optInt(2),
+		Product:         m.optInt(4),
+		SoftwareVersion: m.optFloat(5, 100, 0),
+		HardwareVersion: m.optInt(6),
+		BatteryVoltage:  m.optFloat(10, 256, 0),
+		BatteryStatus:   m.optInt(11),
+	}
+	if v, ok := m.Uint(3); ok {
+		serial := uint32(v)
+		d.SerialNumber = &serial
+	}
+	d.ProductName, _ = m.String(27)
+	return d
+}
+
+// HRV holds beat-to-beat intervals recorded during an activity
+type HRV struct {
+	Intervals []float64 // seconds between beats
+}
+
+func newHRV(m *Message) HRV {
+	return HRV{Intervals: m.Floats(0, 1000, 0)}
+}
+
+// Monitoring is an all-day activity tracking sample
+type Monitoring struct {
+	Timestamp      time.Time
+	DeviceIndex    *int
+	Calories       *int     // kcal
+	Distance       *float64 // meters
+	Cycles         *float64 // steps or strokes, depending on ActivityType
+	ActiveTime     *float64 // seconds
+	ActivityType   *int
+	HeartRate      *int // bpm
+	LocalTimestamp time.Time
+}
+
+func newMonitoring(m *Message) Monitoring {
+	return Monitoring{
+		Timestamp:      m.optTime(timestampFieldNum),
+		DeviceIndex:    m.optInt(0),
+		Calories:       m.optInt(1),
+		Distance:       m.optFloat(2, 100, 0),
+		Cycles:         m.optFloat(3, 2, 0),
+		ActiveTime:     m.optFloat(4, 1000, 0),
+		ActivityType:   m.optInt(5),
+		HeartRate:      m.optInt(27),
+		LocalTimestamp: m.optTime(11),
+	}
+}
+
+// Activity is the summary message closing an activity file
+type Activity struct {
+	Timestamp      time.Time
+	TotalTimerTime *float64 // seconds
+	NumSessions    int
+	LocalTimestamp time.Time
+}
+
+func newActivity(m *Message) Activity {
+	a := Activity{
+		Timestamp:      m.optTime(timestampFieldNum),
+		TotalTimerTime: m.optFloat(0, 1000, 0),
+		LocalTimestamp: m.optTime(5),
+	}
+	if v, ok := m.Int(1); ok {
+		a.NumSessions = int(v)
+	}
+	return a
+}
+
+// Course names a course file and its sport
+type Course struct {
+	Name     string
+	Sport    int
+	SubSport int
+}
+
+func newCourse(m *Message) Course {
+	c := Course{}
+	c.Name, _ = m.String(5)
+	if v, ok := m.Int(4); ok {
+		c.Sport = int(v)
+	}
+	if v, ok := m.Int(7); ok {
+		c.SubSport = int(v)
+	}
+	return c
+}
+
+// CoursePoint marks a turn, climb or other point of interest along a course
+type CoursePoint struct {
+	MessageIndex int
+	Timestamp    time.Time
+	Lat          *float64 // degrees
+	Lon          *float64 // degrees
+	Distance     *float64 // meters
+	Type         int      // 0 = generic, 1 = summit, 2 = valley, 3 = water, 4 = food, 5 = danger, 6 = left, 7 = right, 8 = straight
+	Name         string
+}
+
+func newCoursePoint(m *Message) CoursePoint {
+	p := CoursePoint{
+		Timestamp: m.optTime(1),
+		Lat:       m.optDegrees(2),
+		Lon:       m.optDegrees(3),
+		Distance:  m.optFloat(4, 100, 0),
+	}
+	if v, ok := m.Int(254); ok {
+		p.MessageIndex = int(v)
+	}
+	if v, ok := m.Int(5); ok {
+		p.Type = int(v)
+	}
+	p.Name, _ = m.String(6)
+	return p
+}
+
+// Workout names a structured workout file and its sport
+type Workout struct {
+	Name          string
+	Sport         int
+	SubSport      int
+	NumValidSteps int
+}
+
+func newWorkout(m *Message) Workout {
+	w := Workout{}
+	w.Name, _ = m.String(8)
+	if v, ok := m.Int(4); ok {
+		w.Sport = int(v)
+	}
+	if v, ok := m.Int(11); ok {
+		w.SubSport = int(v)
+	}
+	if v, ok := m.Int(6); ok {
+		w.NumValidSteps = int(v)
+	}
+	return w
+}
+
+// WorkoutStep is a single step of a structured workout. DurationValue is in
+// milliseconds for time, centimeters for distance and holds the step index to
+// repeat from for repeat steps. Target values follow the FIT profile: zone
+// numbers, or custom ranges (heart rate offset by 100 bpm, speed in mm/s,
+// power offset by 1000 W).
+type WorkoutStep struct {
+	MessageIndex          int
+	Name                  string
+	DurationType          int // 0 = time, 1 = distance, 5 = open (lap button), 6 = repeat until steps complete
+	DurationValue         uint32
+	TargetType            int // 0 = speed, 1 = heart rate, 2 = open, 3 = cadence, 4 = power
+	TargetValue           uint32
+	CustomTargetValueLow  uint32
+	CustomTargetValueHigh uint32
+	Intensity             int // 0 = active, 1 = rest, 2 = warmup, 3 = cooldown, 4 = recovery, 5 = interval
+	Notes                 string
+}
+
+func newWorkoutStep(m *Message) WorkoutStep {
+	s := WorkoutStep{}
+	if v, ok := m.Int(254); ok {
+		s.MessageIndex = int(v)
+	}
+	s.Name, _ = m.String(0)
+	if v, ok := m.Int(1); ok {
+		s.DurationType = int(v)
+	}
+	if v, ok := m.Uint(2); ok {
+		s.DurationValue = uint32(v)
+	}
+	if v, ok := m.Int(3); ok {
+		s.TargetType = int(v)
+	}
+	if v, ok := m.Uint(4); ok {
+		s.TargetValue = uint32(v)
+	}
+	if v, ok := m.Uint(5); ok {
+		s.CustomTargetValueLow = uint32(v)
+	}
+	if v, ok := m.Uint(6); ok {
+		s.CustomTargetValueHigh = uint32(v)
+	}
+	if v, ok := m.Int(7); ok {
+		s.Intensity = int(v)
+	}
+	s.Notes, _ = m.String(8)
+	return s
+}
+
+// WeightScale is a weigh-in from a scale or entered by hand
+type WeightScale struct {
+	Timestamp        time.Time
+	Weight           *float64 // kg
+	PercentFat       *float64 // percent
+	PercentHydration *float64 // percent
+	BoneMass         *float64 // kg
+	MuscleMass       *float64 // kg
+}
+
+func newWeightScale(m *Message) WeightScale {
+	return WeightScale{
+		Timestamp:        m.optTime(timestampFieldNum),
+		Weight:           m.optFloat(0, 100, 0),
+		PercentFat:       m.optFloat(1, 100, 0),
+		PercentHydration: m.optFloat(2, 100, 0),
+		BoneMass:         m.optFloat(4, 100, 0),
+		MuscleMass:       m.optFloat(5, 100, 0),
+	}
+}
@@ -0,0 +1,150 @@
+package fit
+
+import (
+	"math"
+	"time"
+)
+
+// MesgNum identifies a global FIT message type
+type MesgNum uint16
+
+// Global message numbers from the FIT profile
+const (
+	MesgNumFileID           MesgNum = 0
+	MesgNumSession          MesgNum = 18
+	MesgNumLap              MesgNum = 19
+	MesgNumRecord           MesgNum = 20
+	MesgNumEvent            MesgNum = 21
+	MesgNumDeviceInfo       MesgNum = 23
+	MesgNumWorkout          MesgNum = 26
+	MesgNumWorkoutStep      MesgNum = 27
+	MesgNumWeightScale      MesgNum = 30
+	MesgNumCourse           MesgNum = 31
+	MesgNumCoursePoint      MesgNum = 32
+	MesgNumActivity         MesgNum = 34
+	MesgNumMonitoring       MesgNum = 55
+	MesgNumHRV              MesgNum = 78
+	MesgNumFieldDescription MesgNum = 206
+	MesgNumDeveloperDataID  MesgNum = 207
+)
+
+// BaseType is the FIT base type of a field
+type BaseType byte
+
+// FIT base types
+const (
+	BaseTypeEnum    BaseType = 0x00
+	BaseTypeSint8   BaseType = 0x01
+	BaseTypeUint8   BaseType = 0x02
+	BaseTypeSint16  BaseType = 0x83
+	BaseTypeUint16  BaseType = 0x84
+	BaseTypeSint32  BaseType = 0x85
+	BaseTypeUint32  BaseType = 0x86
+	BaseTypeString  BaseType = 0x07
+	BaseTypeFloat32 BaseType = 0x88
+	BaseTypeFloat64 BaseType = 0x89
+	BaseTypeUint8z  BaseType = 0x0A
+	BaseTypeUint16z BaseType = 0x8B
+	BaseTypeUint32z BaseType = 0x8C
+	BaseTypeByte    BaseType = 0x0D
+	BaseTypeSint64  BaseType = 0x8E
+	BaseTypeUint64  BaseType = 0x8F
+	BaseTypeUint64z BaseType = 0x90
+)
+
+// baseTypes lists the base types by their number, the low five bits of the
+// base type byte
+var baseTypes = [...]BaseType{
+	BaseTypeEnum, BaseTypeSint8, BaseTypeUint8, BaseTypeSint16, BaseTypeUint16,
+	BaseTypeSint32, BaseTypeUint32, BaseTypeString, BaseTypeFloat32, BaseTypeFloat64,
+	BaseTypeUint8z, BaseTypeUint16z, BaseTypeUint32z, BaseTypeByte, BaseTypeSint64,
+	BaseTypeUint64, BaseTypeUint64z,
+}
+
+// normalizeBaseType returns the canonical base type of a definition byte.
+// Some writers leave out the endian flag (0x80) of multi-byte types, so a
+// uint16 can arrive as 0x04 instead of 0x84. Unknown numbers are kept as is.
+func normalizeBaseType(b byte) BaseType {
+	if n := int(b & 0x1F); n < len(baseTypes) {
+		return baseTypes[n]
+	}
+	return BaseType(b)
+}
+
+// Size returns the size in bytes of a single value of the base type
+func (b BaseType) Size() int {
+	switch b {
+	case BaseTypeEnum, BaseTypeSint8, BaseTypeUint8, BaseTypeUint8z, BaseTypeByte, BaseTypeString:
+		return 1
+	case BaseTypeSint16, BaseTypeUint16, BaseTypeUint16z:
+		return 2
+	case BaseTypeSint32, BaseTypeUint32, BaseTypeUint32z, BaseTypeFloat32:
+		return 4
+	case BaseTypeSint64, BaseTypeUint64, BaseTypeUint64z, BaseTypeFloat64:
+		return 8
+	}
+	return 1
+}
+
+// signed reports whether the base type holds signed integers
+func (b BaseType) signed() bool {
+	switch b {
+	case BaseTypeSint8, BaseTypeSint16, BaseTypeSint32, BaseTypeSint64:
+		return true
+	}
+	return false
+}
+
+// float reports whether the base type holds floating point values
+func (b BaseType) float() bool {
+	return b == BaseTypeFloat32 || b == BaseTypeFloat64
+}
+
+// invalid returns the raw bit pattern FIT uses to mark a missing value
+func (b BaseType) invalid() uint64 {
+	switch b {
+	case BaseTypeSint8:
+		return 0x7F
+	case BaseTypeSint16:
+		return 0x7FFF
+	case BaseTypeSint32:
+		return 0x7FFFFFFF
+	case BaseTypeSint64:
+		return 0x7FFFFFFFFFFFFFFF
+	case BaseTypeUint16:
+		return 0xFFFF
+	case BaseTypeUint32, BaseTypeFloat32:
+		return 0xFFFFFFFF
+	case BaseTypeUint64, BaseTypeFloat64:
+		return 0xFFFFFFFFFFFFFFFF
+	case BaseTypeUint8z, BaseTypeUint16z, BaseTypeUint32z, BaseTypeUint64z:
+		return 0
+	}
+	return 0xFF
+}
+
+// fitEpoch is the FIT date_time origin, 1989-12-31T00:00:00Z
+var fitEpoch = time.Date(1989, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// Time converts a FIT date_time value to time.Time
+func Time(v uint32) time.Time {
+	return fitEpoch.Add(time.Duration(v) * time.Second)
+}
+
+// FromTime converts a time.Time to a FIT date_time value
+func FromTime(t time.Time) uint32 {
+	return uint32(t.Sub(fitEpoch) / time.Second)
+}
+
+// semicirclesPerDegree converts between FIT semicircles and degrees
+const semicirclesPerDegree = (1 << 31) / 180.0
+
+// Degrees converts FIT semicircles to decimal degrees
+func Degrees(semicircles int32) float64 {
+	return float64(semicircles) / semicirclesPerDegree
+}
+
+// Semicircles converts decimal degrees to FIT semicircles
+func Semicircles(degrees float64) int32 {
+	return int32(math.Round(degrees * semicirclesPerDegree))
+}
@@ -0,0 +1,36 @@
+package fit
+
+import (
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+)
+
+// ActivityStreams converts the record messages into the columnar shape returned
+// by garmin.Client.GetActivityStreams. Only columns with at least one recorded
+// value are included; missing samples are NaN. The polyline and bounding box
+// are built from records carrying a position.
+func (f *File) ActivityStreams() *streams.ActivityStreams {
+	result := streams.New(0)
+
+	records := make([]Record, 0, len(f.Records))
+	for _, r := range f.Records {
+		if !r.Timestamp.IsZero() {
+			records = append(records, r)
+		}
+	}
+	for _, r := range records {
+		result.Timestamps = append(result.Timestamps, r.Timestamp)
+	}
+
+	result.AddColumn(streams.HeartRate, "heart_rate", "bpm", func(i int) *float64 { return streams.IntToFloat(records[i].HeartRate) })
+	result.AddColumn(streams.Speed, "speed", "mps", func(i int) *float64 { return records[i].Speed })
+	result.AddColumn(streams.Power, "power", "watt", func(i int) *float64 { return streams.IntToFloat(records[i].Power) })
+	result.AddColumn(streams.Cadence, "cadence", "rpm", func(i int) *float64 { return streams.IntToFloat(records[i].Cadence) })
+	result.AddColumn(streams.Altitude, "altitude", "meter", func(i int) *float64 { return records[i].Altitude })
+	result.AddColumn(streams.Latitude, "position_lat", "dd", func(i int) *float64 { return records[i].Lat })
+	result.AddColumn(streams.Longitude, "position_long", "dd", func(i int) *float64 { return records[i].Lon })
+	result.AddColumn(streams.Distance, "distance", "meter", func(i int) *float64 { return records[i].Distance })
+	result.AddColumn(streams.Temperature, "temperature", "celsius", func(i int) *float64 { return streams.IntToFloat(records[i].Temperature) })
+
+	result.BuildPolyline()
+	return result
+}
@@ -0,0 +1,198 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Gear status names reported by gear-service
+const (
+	GearStatusActive  = "active"
+	GearStatusRetired = "retired"
+)
+
+// Gear is a piece of equipment such as a pair of shoes or a bike
+type Gear struct {
+	GearPK          int64      `json:"gearPk"`
+	UUID            string     `json:"uuid"`
+	UserProfilePK   int64      `json:"userProfilePk"`
+	GearTypeName    string     `json:"gearTypeName"` // e.g. "Shoes", "Bike"
+	GearMakeName    string     `json:"gearMakeName"` // Brand
+	GearModelName   string     `json:"gearModelName"`
+	CustomMakeModel string     `json:"customMakeModel"`
+	DisplayName     string     `json:"displayName"`
+	GearStatusName  string     `json:"gearStatusName"` // One of the GearStatus* constants
+	DateBegin       GarminTime `json:"dateBegin"`
+	DateEnd         GarminTime `json:"dateEnd"`
+	MaximumMeters   float64    `json:"maximumMeters"` // Distance alert threshold; zero when unset
+	Notified        bool       `json:"notified"`      // Whether the distance alert has fired
+}
+
+// Name returns the display name, falling back to the custom or brand and model name
+func (g Gear) Name() string {
+	if g.DisplayName != "" {
+		return g.DisplayName
+	}
+	if g.CustomMakeModel != "" {
+		return g.CustomMakeModel
+	}
+	return strings.TrimSpace(g.GearMakeName + " " + g.GearModelName)
+}
+
+// Retired reports whether the gear has been retired
+func (g Gear) Retired() bool {
+	return strings.EqualFold(g.GearStatusName, GearStatusRetired)
+}
+
+// MaximumReached reports whether the gear's total distance has reached its
+// maximum-distance alert threshold
+func (g Gear) MaximumReached(stats *GearStats) bool {
+	return g.MaximumMeters > 0 && stats != nil && stats.TotalDistance >= g.MaximumMeters
+}
+
+// GearStats holds the usage totals of a piece of gear
+type GearStats struct {
+	GearPK          int64   `json:"gearPk"`
+	UUID            string  `json:"uuid"`
+	TotalDistance   float64 `json:"totalDistance"` // Meters
+	TotalActivities int     `json:"totalActivities"`
+	Processing      bool    `json:"processing"`
+}
+
+// GearDefault records the gear used by default for an activity type
+type GearDefault struct {
+	GearPK         int64  `json:"gearPk"`
+	UUID           string `json:"uuid"`
+	ActivityTypePK int    `json:"activityTypePk"`
+	DefaultGear    bool   `json:"defaultGear"`
+}
+
+// ListGear retrieves the current user's gear, including retired gear
+func (c *Client) ListGear(ctx context.Context) ([]Gear, error) {
+	profilePK, err := c.userProfilePK()
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("userProfilePk", strconv.FormatInt(profilePK, 10))
+	return c.filterGear(ctx, params)
+}
+
+// GetActivityGear retrieves the gear linked to an activity
+func (c *Client) GetActivityGear(ctx context.Context, activityID int) ([]Gear, error) {
+	params := url.Values{}
+	params.Set("activityId", strconv.Itoa(activityID))
+	return c.filterGear(ctx, params)
+}
+
+func (c *Client) filterGear(ctx context.Context, params url.Values) ([]Gear, error) {
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/gear-service/gear/filterGear", "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gear: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var gear []Gear
+	if err := json.Unmarshal(data, &gear); err != nil {
+		return nil, fmt.Errorf("failed to parse gear response: %w", err)
+	}
+	return gear, nil
+}
+
+// GetGearStats retrieves the total distance and activity count of a piece of gear
+func (c *Client) GetGearStats(ctx context.Context, gearUUID string) (*GearStats, error) {
+	path := fmt.Sprintf("/userstats-service/gears/%s", url.PathEscape(gearUUID))
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, resourceError(fmt.Errorf("failed to get gear stats: %w", err), "gear", gearUUID)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var stats GearStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("failed to parse gear stats response: %w", err)
+	}
+	return &stats, nil
+}
+
+// GetGearActivities retrieves the activities recorded with a piece of gear,
+// most recent first; a zero limit returns every activity
+func (c *Client) GetGearActivities(ctx context.Context, gearUUID string, limit int) ([]Activity, error) {
+	return c.FindActivities(ctx, NewActivitySearch().Gear(gearUUID).Limit(limit))
+}
+
+// LinkGear links a piece of gear to an activity
+func (c *Client) LinkGear(ctx context.Context, gearUUID string, activityID int) error {
+	path := fmt.Sprintf("/gear-service/gear/link/%s/activity/%d", url.PathEscape(gearUUID), activityID)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "PUT", nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to link gear: %w", err), "gear", gearUUID)
+	}
+	return nil
+}
+
+// UnlinkGear removes a piece of gear from an activity
+func (c *Client) UnlinkGear(ctx context.Context, gearUUID string, activityID int) error {
+	path := fmt.Sprintf("/gear-service/gear/unlink/%s/activity/%d", url.PathEscape(gearUUID), activityID)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "PUT", nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to unlink gear: %w", err), "gear", gearUUID)
+	}
+	return nil
+}
+
+// GetGearDefaults retrieves the default gear of each activity type
+func (c *Client) GetGearDefaults(ctx context.Context) ([]GearDefault, error) {
+	profilePK, err := c.userProfilePK()
+	if err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("/gear-service/gear/user/%d/activityTypes", profilePK)
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gear defaults: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var defaults []GearDefault
+	if err := json.Unmarshal(data, &defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse gear defaults response: %w", err)
+	}
+	return defaults, nil
+}
+
+// SetGearDefault makes a piece of gear the default for an activity type, or
+// clears it when isDefault is false
+func (c *Client) SetGearDefault(ctx context.Context, gearUUID string, activityTypeID int, isDefault bool) error {
+	path := fmt.Sprintf("/gear-service/gear/%s/activityType/%d", url.PathEscape(gearUUID), activityTypeID)
+	method := "DELETE"
+	if isDefault {
+		path += "/default/true"
+		method = "PUT"
+	}
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, method, nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to set default gear: %w", err), "gear", gearUUID)
+	}
+	return nil
+}
+
+// userProfilePK returns the numeric profile ID gear-service keys gear by
+func (c *Client) userProfilePK() (int64, error) {
+	settings, err := c.Client.GetUserSettings()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	return int64(settings.ID), nil
+}
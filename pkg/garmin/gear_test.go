@@ -0,0 +1,79 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGear(t *testing.T) {
+	var calls []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/userprofile-service/userprofile/user-settings":
+			w.Write([]byte(`{"id": 1234, "userData": {}}`))
+		case "/gear-service/gear/filterGear":
+			assert.Equal(t, "1234", r.URL.Query().Get("userProfilePk"))
+			w.Write([]byte(`[
+				{"gearPk": 1, "uuid": "shoe-1", "gearTypeName": "Shoes", "gearMakeName": "Brooks",
+				 "gearModelName": "Ghost 15", "gearStatusName": "active", "maximumMeters": 800000,
+				 "dateBegin": "2024-01-01T00:00:00.0", "dateEnd": null},
+				{"gearPk": 2, "uuid": "bike-1", "gearTypeName": "Bike", "displayName": "Commuter",
+				 "gearStatusName": "retired"}]`))
+		case "/userstats-service/gears/shoe-1":
+			w.Write([]byte(`{"gearPk": 1, "uuid": "shoe-1", "totalDistance": 812345.5, "totalActivities": 97}`))
+		case "/gear-service/gear/user/1234/activityTypes":
+			w.Write([]byte(`[{"gearPk": 1, "uuid": "shoe-1", "activityTypePk": 1, "defaultGear": true}]`))
+		case "/gear-service/gear/link/shoe-1/activity/42",
+			"/gear-service/gear/unlink/shoe-1/activity/42",
+			"/gear-service/gear/shoe-1/activityType/1/default/true",
+			"/gear-service/gear/shoe-1/activityType/1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	gear, err := c.ListGear(ctx)
+	require.NoError(t, err)
+	require.Len(t, gear, 2)
+	assert.Equal(t, "Brooks Ghost 15", gear[0].Name())
+	assert.Equal(t, 2024, gear[0].DateBegin.Year())
+	assert.False(t, gear[0].Retired())
+	assert.Equal(t, "Commuter", gear[1].Name())
+	assert.True(t, gear[1].Retired())
+
+	stats, err := c.GetGearStats(ctx, "shoe-1")
+	require.NoError(t, err)
+	assert.Equal(t, 97, stats.TotalActivities)
+	assert.True(t, gear[0].MaximumReached(stats))
+	assert.False(t, gear[1].MaximumReached(stats))
+
+	_, err = c.GetGearStats(ctx, "missing")
+	var notFound *garmin.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	defaults, err := c.GetGearDefaults(ctx)
+	require.NoError(t, err)
+	require.Len(t, defaults, 1)
+	assert.True(t, defaults[0].DefaultGear)
+
+	calls = nil
+	require.NoError(t, c.LinkGear(ctx, "shoe-1", 42))
+	require.NoError(t, c.UnlinkGear(ctx, "shoe-1", 42))
+	require.NoError(t, c.SetGearDefault(ctx, "shoe-1", 1, true))
+	require.NoError(t, c.SetGearDefault(ctx, "shoe-1", 1, false))
+	assert.Equal(t, []string{
+		"PUT /gear-service/gear/link/shoe-1/activity/42",
+		"PUT /gear-service/gear/unlink/shoe-1/activity/42",
+		"PUT /gear-service/gear/shoe-1/activityType/1/default/true",
+		"DELETE /gear-service/gear/shoe-1/activityType/1",
+	}, calls)
+}
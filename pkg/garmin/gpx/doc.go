@@ -0,0 +1,5 @@
+// Package gpx reads and writes GPX 1.1 files, including the Garmin
+// TrackPointExtension heart rate, cadence, temperature and speed values.
+// Tracks convert to and from streams.ActivityStreams, which is the bridge to
+// the tcx and fit packages; written files can be passed to garmin.Client.Upload.
+package gpx
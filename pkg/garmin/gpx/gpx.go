@@ -0,0 +1,209 @@
+package gpx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// XML namespaces written by the encoder
+const (
+	Namespace                    = "http://www.topografix.com/GPX/1/1"
+	TrackPointExtensionNamespace = "http://www.garmin.com/xmlschemas/TrackPointExtension/v2"
+)
+
+// DefaultCreator is written to the creator attribute when none is set
+const DefaultCreator = "go-garth"
+
+// File is a GPX 1.1 document
+type File struct {
+	XMLName   xml.Name   `xml:"gpx"`
+	Xmlns     string     `xml:"xmlns,attr,omitempty"`
+	Version   string     `xml:"version,attr"`
+	Creator   string     `xml:"creator,attr"`
+	Metadata  *Metadata  `xml:"metadata,omitempty"`
+	Waypoints []Waypoint `xml:"wpt"`
+	Routes    []Route    `xml:"rte"`
+	Tracks    []Track    `xml:"trk"`
+}
+
+// Metadata describes the document
+type Metadata struct {
+	Name        string     `xml:"name,omitempty"`
+	Description string     `xml:"desc,omitempty"`
+	Time        *time.Time `xml:"time,omitempty"`
+}
+
+// Waypoint is a GPX point, used for waypoints, route points and track points
+type Waypoint struct {
+	Lat         float64     `xml:"lat,attr"`
+	Lon         float64     `xml:"lon,attr"`
+	Elevation   *float64    `xml:"ele,omitempty"` // meters
+	Time        *time.Time  `xml:"time,omitempty"`
+	Name        string      `xml:"name,omitempty"`
+	Description string      `xml:"desc,omitempty"`
+	Symbol      string      `xml:"sym,omitempty"`
+	Type        string      `xml:"type,omitempty"`
+	Extensions  *Extensions `xml:"extensions,omitempty"`
+}
+
+// Extensions holds the point extensions the package understands
+type Extensions struct {
+	TrackPoint *TrackPointExtension `xml:"TrackPointExtension,omitempty"`
+}
+
+// TrackPointExtension is the Garmin TrackPointExtension (v1 and v2) element
+type TrackPointExtension struct {
+	Xmlns       string   `xml:"xmlns,attr,omitempty"`
+	Temperature *float64 `xml:"atemp,omitempty"` // degrees Celsius
+	WaterTemp   *float64 `xml:"wtemp,omitempty"` // degrees Celsius
+	Depth       *float64 `xml:"depth,omitempty"` // meters
+	HeartRate   *int     `xml:"hr,omitempty"`    // bpm
+	Cadence     *int     `xml:"cad,omitempty"`   // rpm
+	Speed       *float64 `xml:"speed,omitempty"` // m/s, v2 only
+}
+
+// Route is an ordered list of points leading to a destination
+type Route struct {
+	Name   string     `xml:"name,omitempty"`
+	Type   string     `xml:"type,omitempty"`
+	Points []Waypoint `xml:"rtept"`
+}
+
+// Track is a recorded path made of one or more segments
+type Track struct {
+	Name     string    `xml:"name,omitempty"`
+	Type     string    `xml:"type,omitempty"`
+	Segments []Segment `xml:"trkseg"`
+}
+
+// Segment is a continuous span of track points
+type Segment struct {
+	Points []Waypoint `xml:"trkpt"`
+}
+
+// Extension returns the point's TrackPointExtension, or nil
+func (w *Waypoint) Extension() *TrackPointExtension {
+	if w.Extensions == nil {
+		return nil
+	}
+	return w.Extensions.TrackPoint
+}
+
+// Parse reads a GPX document. Extension elements are matched by local name,
+// so both TrackPointExtension v1 and v2 are accepted.
+func Parse(r io.Reader) (*File, error) {
+	var f File
+	if err := xml.NewDecoder(r).Decode(&f); err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to parse GPX",
+				Cause:   err,
+			},
+		}
+	}
+	return &f, nil
+}
+
+// ParseBytes reads a GPX document from memory
+func ParseBytes(data []byte) (*File, error) {
+	return Parse(bytes.NewReader(data))
+}
+
+// ParseFile reads a GPX document from disk
+func ParseFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to read GPX file",
+				Cause:   err,
+			},
+		}
+	}
+	return ParseBytes(data)
+}
+
+// Write encodes the document as indented GPX 1.1
+func (f *File) Write(w io.Writer) error {
+	out := *f
+	out.Xmlns = Namespace
+	out.Version = "1.1"
+	if out.Creator == "" {
+		out.Creator = DefaultCreator
+	}
+	out.Waypoints = withNamespace(f.Waypoints)
+	out.Tracks = make([]Track, len(f.Tracks))
+	for i, trk := range f.Tracks {
+		out.Tracks[i] = trk
+		out.Tracks[i].Segments = make([]Segment, len(trk.Segments))
+		for j, seg := range trk.Segments {
+			out.Tracks[i].Segments[j].Points = withNamespace(seg.Points)
+		}
+	}
+
+	out.Routes = make([]Route, len(f.Routes))
+	for i, rte := range f.Routes {
+		out.Routes[i] = rte
+		out.Routes[i].Points = withNamespace(rte.Points)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return writeError(err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return writeError(err)
+	}
+	return nil
+}
+
+// Bytes returns the encoded document
+func (f *File) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFile encodes the document to path, ready for Client.Upload
+func (f *File) WriteFile(path string) error {
+	data, err := f.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return writeError(err)
+	}
+	return nil
+}
+
+// withNamespace copies points, declaring the extension namespace on each
+// TrackPointExtension so the unprefixed elements resolve correctly
+func withNamespace(points []Waypoint) []Waypoint {
+	out := make([]Waypoint, len(points))
+	for i, p := range points {
+		out[i] = p
+		if ext := p.Extension(); ext != nil {
+			copied := *ext
+			copied.Xmlns = TrackPointExtensionNamespace
+			out[i].Extensions = &Extensions{TrackPoint: &copied}
+		}
+	}
+	return out
+}
+
+func writeError(err error) error {
+	return &errors.IOError{
+		GarthError: errors.GarthError{
+			Message: "Failed to write GPX",
+			Cause:   err,
+		},
+	}
+}
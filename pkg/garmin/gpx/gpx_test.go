@@ -0,0 +1,117 @@
+package gpx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const garminExport = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx creator="Garmin Connect" version="1.1"
+  xmlns="http://www.topografix.com/GPX/1/1"
+  xmlns:ns3="http://www.garmin.com/xmlschemas/TrackPointExtension/v1">
+  <metadata><time>2025-03-02T07:00:00.000Z</time></metadata>
+  <trk>
+    <name>Morning Run</name>
+    <type>running</type>
+    <trkseg>
+      <trkpt lat="45.5" lon="-122.25">
+        <ele>100.0</ele>
+        <time>2025-03-02T07:00:00.000Z</time>
+        <extensions><ns3:TrackPointExtension><ns3:atemp>12.0</ns3:atemp><ns3:hr>120</ns3:hr><ns3:cad>84</ns3:cad></ns3:TrackPointExtension></extensions>
+      </trkpt>
+      <trkpt lat="45.5009" lon="-122.25">
+        <ele>102.0</ele>
+        <time>2025-03-02T07:00:30.000Z</time>
+        <extensions><ns3:TrackPointExtension><ns3:hr>130</ns3:hr></ns3:TrackPointExtension></extensions>
+      </trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestParse_GarminExport(t *testing.T) {
+	f, err := ParseBytes([]byte(garminExport))
+	require.NoError(t, err)
+	require.Len(t, f.Tracks, 1)
+	assert.Equal(t, "Morning Run", f.Tracks[0].Name)
+
+	points := f.Points()
+	require.Len(t, points, 2)
+	require.NotNil(t, points[0].Extension())
+	assert.Equal(t, 120, *points[0].Extension().HeartRate)
+	assert.Equal(t, 84, *points[0].Extension().Cadence)
+	assert.Equal(t, 12.0, *points[0].Extension().Temperature)
+
+	got := f.ActivityStreams()
+	assert.Equal(t, 2, got.Len())
+	assert.Equal(t, 30*time.Second, got.Duration())
+	assert.InDelta(t, 100, got.Values(streams.Distance)[1], 0.5)
+	assert.Equal(t, []float64{120, 130}, got.Values(streams.HeartRate))
+	assert.True(t, got.Has(streams.Temperature))
+	assert.False(t, got.Has(streams.Power))
+	assert.Len(t, got.Polyline, 2)
+}
+
+func TestFile_WriteRoundTrip(t *testing.T) {
+	src, err := ParseBytes([]byte(garminExport))
+	require.NoError(t, err)
+
+	data, err := FromActivityStreams(src.ActivityStreams(), "Morning Run").Bytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `xmlns="http://www.topografix.com/GPX/1/1"`)
+	assert.Contains(t, string(data), TrackPointExtensionNamespace)
+
+	f, err := ParseBytes(data)
+	require.NoError(t, err)
+	points := f.Points()
+	require.Len(t, points, 2)
+	assert.Equal(t, 45.5009, points[1].Lat)
+	assert.Equal(t, 102.0, *points[1].Elevation)
+	assert.Equal(t, 130, *points[1].Extension().HeartRate)
+	assert.Nil(t, points[1].Extension().Cadence)
+}
+
+func TestFile_WriteExtensionNamespace(t *testing.T) {
+	hr := 140
+	ext := &Extensions{TrackPoint: &TrackPointExtension{HeartRate: &hr}}
+	f := &File{
+		Waypoints: []Waypoint{{Lat: 45.5, Lon: -122.25, Name: "Summit", Extensions: ext}},
+		Routes:    []Route{{Points: []Waypoint{{Lat: 45.5, Lon: -122.25, Extensions: ext}}}},
+		Tracks:    []Track{{Segments: []Segment{{Points: []Waypoint{{Lat: 45.5, Lon: -122.25, Extensions: ext}}}}}},
+	}
+	data, err := f.Bytes()
+	require.NoError(t, err)
+
+	found := 0
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if start, ok := tok.(xml.StartElement); ok && start.Name.Local == "hr" {
+			assert.Equal(t, TrackPointExtensionNamespace, start.Name.Space)
+			found++
+		}
+	}
+	assert.Equal(t, 3, found, "waypoint, route point and track point extensions")
+	assert.Empty(t, f.Waypoints[0].Extension().Xmlns, "the caller's points are not modified")
+}
+
+func TestFile_AssignTimes(t *testing.T) {
+	f := &File{Routes: []Route{{Points: []Waypoint{{Lat: 45.5, Lon: -122.25}, {Lat: 45.5009, Lon: -122.25}}}}}
+	start := time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC)
+	f.AssignTimes(start, 5)
+
+	got := f.ActivityStreams()
+	require.Equal(t, 2, got.Len())
+	assert.InDelta(t, 20, got.Duration().Seconds(), 0.2)
+}
@@ -0,0 +1,146 @@
+package gpx
+
+import (
+	"math"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+)
+
+// earthRadius is the mean Earth radius in meters used for track distances
+const earthRadius = 6371008.8
+
+// Points returns the track points of every track and segment in order, or the
+// route points when the document has no tracks
+func (f *File) Points() []Waypoint {
+	var points []Waypoint
+	for _, trk := range f.Tracks {
+		for _, seg := range trk.Segments {
+			points = append(points, seg.Points...)
+		}
+	}
+	if len(points) == 0 {
+		for _, rte := range f.Routes {
+			points = append(points, rte.Points...)
+		}
+	}
+	return points
+}
+
+// AssignTimes sets the time of every point that has none, as if the track were
+// travelled from start at a constant speed in m/s. Planned routes carry no
+// times, but activity streams and FIT courses need them.
+func (f *File) AssignTimes(start time.Time, speed float64) {
+	assign := func(points []Waypoint, distance *float64, prev **Waypoint) {
+		for i := range points {
+			p := &points[i]
+			if *prev != nil {
+				*distance += haversine((*prev).Lat, (*prev).Lon, p.Lat, p.Lon)
+			}
+			*prev = p
+			if p.Time == nil && speed > 0 {
+				t := start.Add(time.Duration(*distance / speed * float64(time.Second)))
+				p.Time = &t
+			}
+		}
+	}
+
+	var distance float64
+	var prev *Waypoint
+	if len(f.Tracks) == 0 {
+		for i := range f.Routes {
+			assign(f.Routes[i].Points, &distance, &prev)
+		}
+		return
+	}
+	for i := range f.Tracks {
+		for j := range f.Tracks[i].Segments {
+			assign(f.Tracks[i].Segments[j].Points, &distance, &prev)
+		}
+	}
+}
+
+// ActivityStreams converts the track into the columnar shape returned by
+// garmin.Client.GetActivityStreams. Points without a time are skipped (see
+// AssignTimes). The distance column is accumulated from the positions.
+func (f *File) ActivityStreams() *streams.ActivityStreams {
+	result := streams.New(0)
+
+	var points []Waypoint
+	for _, p := range f.Points() {
+		if p.Time != nil {
+			points = append(points, p)
+			result.Timestamps = append(result.Timestamps, *p.Time)
+		}
+	}
+
+	distances := make([]float64, len(points))
+	for i := 1; i < len(points); i++ {
+		distances[i] = distances[i-1] + haversine(points[i-1].Lat, points[i-1].Lon, points[i].Lat, points[i].Lon)
+	}
+
+	ext := func(i int) TrackPointExtension {
+		if e := points[i].Extension(); e != nil {
+			return *e
+		}
+		return TrackPointExtension{}
+	}
+	result.AddColumn(streams.Latitude, "lat", "dd", func(i int) *float64 { return &points[i].Lat })
+	result.AddColumn(streams.Longitude, "lon", "dd", func(i int) *float64 { return &points[i].Lon })
+	result.AddColumn(streams.Altitude, "ele", "meter", func(i int) *float64 { return points[i].Elevation })
+	result.AddColumn(streams.Distance, "distance", "meter", func(i int) *float64 { return &distances[i] })
+	result.AddColumn(streams.HeartRate, "hr", "bpm", func(i int) *float64 { return streams.IntToFloat(ext(i).HeartRate) })
+	result.AddColumn(streams.Cadence, "cad", "rpm", func(i int) *float64 { return streams.IntToFloat(ext(i).Cadence) })
+	result.AddColumn(streams.Temperature, "atemp", "celsius", func(i int) *float64 { return ext(i).Temperature })
+	result.AddColumn(streams.Speed, "speed", "mps", func(i int) *float64 { return ext(i).Speed })
+
+	result.BuildPolyline()
+	return result
+}
+
+// FromActivityStreams builds a single-track document from activity streams.
+// Samples without a position are skipped, as GPX points require one.
+func FromActivityStreams(s *streams.ActivityStreams, name string) *File {
+	lat, lon := s.Values(streams.Latitude), s.Values(streams.Longitude)
+
+	var segment Segment
+	if lat != nil && lon != nil {
+		for i, t := range s.Timestamps {
+			if math.IsNaN(lat[i]) || math.IsNaN(lon[i]) {
+				continue
+			}
+			ts := t
+			point := Waypoint{Lat: lat[i], Lon: lon[i], Time: &ts, Elevation: s.Float(streams.Altitude, i)}
+			ext := TrackPointExtension{
+				Temperature: s.Float(streams.Temperature, i),
+				HeartRate:   s.Int(streams.HeartRate, i),
+				Cadence:     s.Int(streams.Cadence, i),
+				Speed:       s.Float(streams.Speed, i),
+			}
+			if ext != (TrackPointExtension{}) {
+				point.Extensions = &Extensions{TrackPoint: &ext}
+			}
+			segment.Points = append(segment.Points, point)
+		}
+	}
+
+	f := &File{
+		Version: "1.1",
+		Creator: DefaultCreator,
+		Tracks:  []Track{{Name: name, Segments: []Segment{segment}}},
+	}
+	if start := s.StartTime(); !start.IsZero() {
+		f.Metadata = &Metadata{Name: name, Time: &start}
+	}
+	return f
+}
+
+// haversine returns the great-circle distance in meters between two points
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
@@ -3,10 +3,11 @@ package garmin
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"time"
 
-	internalClient "github.com/sstent/go-garth/pkg/garth/client"
 	"github.com/sstent/go-garth/internal/models/types"
+	internalClient "github.com/sstent/go-garth/pkg/garth/client"
 )
 
 // GetDailyHRVData retrieves comprehensive daily HRV data for the given date.
@@ -16,11 +17,11 @@ func (c *Client) GetDailyHRVData(date time.Time) (*types.DailyHRVData, error) {
 }
 
 func getDailyHRVData(day time.Time, client *internalClient.Client) (*types.DailyHRVData, error) {
-	dateStr := day.Format("2006-01-02")
-	path := fmt.Sprintf("/wellness-service/wellness/dailyHrvData/%s?date=%s",
-		client.Username, dateStr)
+	path := fmt.Sprintf("/wellness-service/wellness/dailyHrvData/%s", client.Username)
+	params := url.Values{}
+	params.Set("date", day.Format("2006-01-02"))
 
-	data, err := client.ConnectAPI(path, "GET", nil, nil)
+	data, err := client.ConnectAPI(path, "GET", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get HRV data: %w", err)
 	}
@@ -51,11 +52,12 @@ func (c *Client) GetDetailedSleepData(date time.Time) (*types.DetailedSleepData,
 }
 
 func getDetailedSleepData(day time.Time, client *internalClient.Client) (*types.DetailedSleepData, error) {
-	dateStr := day.Format("2006-01-02")
-	path := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s?date=%s&nonSleepBufferMinutes=60",
-		client.Username, dateStr)
+	path := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s", client.Username)
+	params := url.Values{}
+	params.Set("date", day.Format("2006-01-02"))
+	params.Set("nonSleepBufferMinutes", "60")
 
-	data, err := client.ConnectAPI(path, "GET", nil, nil)
+	data, err := client.ConnectAPI(path, "GET", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get detailed sleep data: %w", err)
 	}
@@ -65,16 +67,16 @@ func getDetailedSleepData(day time.Time, client *internalClient.Client) (*types.
 	}
 
 	var response struct {
-		DailySleepDTO                       *types.DetailedSleepData `json:"dailySleepDTO"`
-		SleepMovement                       []types.SleepMovement    `json:"sleepMovement"`
-		RemSleepData                        bool                     `json:"remSleepData"`
-		SleepLevels                         []types.SleepLevel       `json:"sleepLevels"`
-		SleepRestlessMoments                []interface{}            `json:"sleepRestlessMoments"`
-		RestlessMomentsCount                int                      `json:"restlessMomentsCount"`
-		WellnessSpO2SleepSummaryDTO         interface{}              `json:"wellnessSpO2SleepSummaryDTO"`
-		WellnessEpochSPO2DataDTOList        []interface{}            `json:"wellnessEpochSPO2DataDTOList"`
-		WellnessEpochRespirationDataDTOList []interface{}            `json:"wellnessEpochRespirationDataDTOList"`
-		SleepStress                         interface{}              `json:"sleepStress"`
+		DailySleepDTO                       *types.DetailedSleepData    `json:"dailySleepDTO"`
+		SleepMovement                       []types.SleepMovement       `json:"sleepMovement"`
+		RemSleepData                        bool                        `json:"remSleepData"`
+		SleepLevels                         []types.SleepLevel          `json:"sleepLevels"`
+		SleepRestlessMoments                []types.SleepRestlessMoment `json:"sleepRestlessMoments"`
+		RestlessMomentsCount                int                         `json:"restlessMomentsCount"`
+		WellnessSpO2SleepSummaryDTO         *types.SpO2SleepSummary     `json:"wellnessSpO2SleepSummaryDTO"`
+		WellnessEpochSPO2DataDTOList        []types.SpO2Epoch           `json:"wellnessEpochSPO2DataDTOList"`
+		WellnessEpochRespirationDataDTOList []types.RespirationEpoch    `json:"wellnessEpochRespirationDataDTOList"`
+		SleepStress                         []types.SleepStressEpoch    `json:"sleepStress"`
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
@@ -88,6 +90,12 @@ func getDetailedSleepData(day time.Time, client *internalClient.Client) (*types.
 	// Populate additional data
 	response.DailySleepDTO.SleepMovement = response.SleepMovement
 	response.DailySleepDTO.SleepLevels = response.SleepLevels
+	response.DailySleepDTO.SpO2Summary = response.WellnessSpO2SleepSummaryDTO
+	response.DailySleepDTO.SpO2Epochs = response.WellnessEpochSPO2DataDTOList
+	response.DailySleepDTO.RespirationEpochs = response.WellnessEpochRespirationDataDTOList
+	response.DailySleepDTO.StressEpochs = response.SleepStress
+	response.DailySleepDTO.RestlessMoments = response.SleepRestlessMoments
+	response.DailySleepDTO.RestlessCount = response.RestlessMomentsCount
 
 	return response.DailySleepDTO, nil
 }
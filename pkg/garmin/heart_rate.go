@@ -0,0 +1,132 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// HeartRateSample is an all-day heart rate reading
+type HeartRateSample struct {
+	Time time.Time
+	BPM  int
+}
+
+// DailyHeartRate is the all-day heart rate of a day, in beats per minute
+type DailyHeartRate struct {
+	Date               string // YYYY-MM-DD
+	Min                int
+	Max                int
+	Resting            int
+	SevenDayRestingAvg int
+	Samples            []HeartRateSample // Oldest first
+	StartTime, EndTime time.Time         // Day boundaries, UTC
+}
+
+// dailyHeartRateDTO mirrors the wellness-service daily heart rate response
+type dailyHeartRateDTO struct {
+	CalendarDate                     string       `json:"calendarDate"`
+	StartTimestampGMT                GarminTime   `json:"startTimestampGMT"`
+	EndTimestampGMT                  GarminTime   `json:"endTimestampGMT"`
+	MaxHeartRate                     *int         `json:"maxHeartRate"`
+	MinHeartRate                     *int         `json:"minHeartRate"`
+	RestingHeartRate                 *int         `json:"restingHeartRate"`
+	LastSevenDaysAvgRestingHeartRate *int         `json:"lastSevenDaysAvgRestingHeartRate"`
+	HeartRateValues                  [][]*float64 `json:"heartRateValues"`
+}
+
+// GetDailyHeartRate retrieves the all-day heart rate of a day. It returns
+// nil when the device recorded no readings.
+func (c *Client) GetDailyHeartRate(ctx context.Context, date time.Time) (*DailyHeartRate, error) {
+	path := fmt.Sprintf("/wellness-service/wellness/dailyHeartRate/%s", c.Client.Username)
+	params := url.Values{}
+	params.Set("date", date.Format("2006-01-02"))
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get heart rate data: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dto dailyHeartRateDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse heart rate response: %w", err)
+	}
+	if dto.MaxHeartRate == nil && len(dto.HeartRateValues) == 0 {
+		return nil, nil
+	}
+
+	hr := &DailyHeartRate{
+		Date:      dto.CalendarDate,
+		StartTime: dto.StartTimestampGMT.Time,
+		EndTime:   dto.EndTimestampGMT.Time,
+	}
+	for _, v := range parseSeries(dto.HeartRateValues) {
+		hr.Samples = append(hr.Samples, HeartRateSample{Time: v.Time, BPM: int(v.Value)})
+	}
+	hr.Min = intOf(dto.MinHeartRate)
+	hr.Max = intOf(dto.MaxHeartRate)
+	hr.Resting = intOf(dto.RestingHeartRate)
+	hr.SevenDayRestingAvg = intOf(dto.LastSevenDaysAvgRestingHeartRate)
+	if dto.MaxHeartRate == nil {
+		for i, s := range hr.Samples {
+			if i == 0 || s.BPM < hr.Min {
+				hr.Min = s.BPM
+			}
+			if i == 0 || s.BPM > hr.Max {
+				hr.Max = s.BPM
+			}
+		}
+	}
+	return hr, nil
+}
+
+// restingHeartRateMetric is the userstats-service metric ID of resting heart rate
+const restingHeartRateMetric = "60"
+
+// GetRestingHeartRateHistory retrieves the daily resting heart rate between
+// two dates, inclusive, oldest first. Each value is stamped with its calendar
+// date at midnight UTC; days without a reading are omitted.
+func (c *Client) GetRestingHeartRateHistory(ctx context.Context, startDate, endDate time.Time) ([]TimedValue, error) {
+	path := fmt.Sprintf("/userstats-service/wellness/daily/%s", c.Client.Username)
+	params := url.Values{}
+	params.Set("fromDate", startDate.Format("2006-01-02"))
+	params.Set("untilDate", endDate.Format("2006-01-02"))
+	params.Set("metricId", restingHeartRateMetric)
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resting heart rate history: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var response struct {
+		AllMetrics struct {
+			MetricsMap map[string][]struct {
+				CalendarDate string   `json:"calendarDate"`
+				Value        *float64 `json:"value"`
+			} `json:"metricsMap"`
+		} `json:"allMetrics"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse resting heart rate response: %w", err)
+	}
+
+	var history []TimedValue
+	for _, m := range response.AllMetrics.MetricsMap["WELLNESS_RESTING_HEART_RATE"] {
+		day, err := time.Parse("2006-01-02", m.CalendarDate)
+		if err != nil || m.Value == nil {
+			continue
+		}
+		history = append(history, TimedValue{Time: day, Value: *m.Value})
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Time.Before(history[j].Time) })
+	return history, nil
+}
@@ -0,0 +1,51 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDailyHeartRate(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wellness-service/wellness/dailyHeartRate/testuser":
+			assert.Equal(t, "2025-03-01", r.URL.Query().Get("date"))
+			w.Write([]byte(`{"calendarDate": "2025-03-01",
+				"startTimestampGMT": "2025-03-01T00:00:00.0", "endTimestampGMT": "2025-03-02T00:00:00.0",
+				"maxHeartRate": 151, "minHeartRate": 47, "restingHeartRate": 52, "lastSevenDaysAvgRestingHeartRate": 54,
+				"heartRateValues": [[1740787200000, 58], [1740787320000, null], [1740787440000, 61]]}`))
+		case "/userstats-service/wellness/daily/testuser":
+			assert.Equal(t, "60", r.URL.Query().Get("metricId"))
+			assert.Equal(t, "2025-03-03", r.URL.Query().Get("untilDate"))
+			w.Write([]byte(`{"allMetrics": {"metricsMap": {"WELLNESS_RESTING_HEART_RATE": [
+				{"calendarDate": "2025-03-03", "value": 51.0},
+				{"calendarDate": "2025-03-02", "value": null},
+				{"calendarDate": "2025-03-01", "value": 52.0}]}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	hr, err := c.GetDailyHeartRate(ctx, day)
+	require.NoError(t, err)
+	assert.Equal(t, 47, hr.Min)
+	assert.Equal(t, 151, hr.Max)
+	assert.Equal(t, 52, hr.Resting)
+	assert.Equal(t, 54, hr.SevenDayRestingAvg)
+	require.Len(t, hr.Samples, 2)
+	assert.Equal(t, 61, hr.Samples[1].BPM)
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 4, 0, 0, time.UTC), hr.Samples[1].Time)
+
+	history, err := c.GetRestingHeartRateHistory(ctx, day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, day, history[0].Time)
+	assert.Equal(t, 51.0, history[1].Value)
+}
@@ -0,0 +1,33 @@
+package garmin
+
+import "github.com/sstent/go-garth/internal/data"
+
+// HRVMetrics holds time-domain HRV metrics derived from RR intervals, with
+// a status from the baseline bands alongside Garmin's
+type HRVMetrics = data.HRVMetrics
+
+// HRV statuses, as reported by Garmin and computed by HRVStatusFor
+const (
+	HRVStatusBalanced   = data.HRVStatusBalanced
+	HRVStatusUnbalanced = data.HRVStatusUnbalanced
+	HRVStatusLow        = data.HRVStatusLow
+	HRVStatusNone       = data.HRVStatusNone
+)
+
+// ComputeHRVMetrics computes RMSSD, SDNN, pNN50, mean RR and mean heart rate
+// from the RR intervals of the readings after artifact filtering
+func ComputeHRVMetrics(readings []HRVReading) HRVMetrics {
+	return data.ComputeHRVMetrics(readings)
+}
+
+// HRVStatusFor classifies an HRV value in milliseconds against the baseline
+// bands
+func HRVStatusFor(value float64, baseline HRVBaseline) string {
+	return data.HRVStatusFor(value, baseline)
+}
+
+// AnalyzeHRV computes HRV metrics for a day of HRV data, such as the one
+// returned by GetDailyHRVData
+func AnalyzeHRV(hrv *DailyHRVData) HRVMetrics {
+	return (&data.DailyHRVDataWithMethods{DailyHRVData: *hrv}).Metrics()
+}
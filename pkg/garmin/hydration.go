@@ -0,0 +1,222 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// HydrationUnit is a unit of fluid volume, as used by
+// UserSettings.UserData.HydrationMeasurementUnit
+type HydrationUnit string
+
+// Hydration units; ounces and cups are US customary
+const (
+	Milliliters HydrationUnit = "milliliter"
+	Ounces      HydrationUnit = "ounce"
+	Cups        HydrationUnit = "cup"
+)
+
+// Milliliters per unit of each non-metric hydration unit
+const (
+	millilitersPerOunce = 29.5735295625
+	millilitersPerCup   = 236.5882365
+)
+
+// ConvertHydration converts a volume in milliliters to the given unit
+func ConvertHydration(ml float64, unit HydrationUnit) float64 {
+	switch unit {
+	case Ounces:
+		return ml / millilitersPerOunce
+	case Cups:
+		return ml / millilitersPerCup
+	}
+	return ml
+}
+
+// toMilliliters converts a volume in the given unit to milliliters
+func toMilliliters(value float64, unit HydrationUnit) float64 {
+	switch unit {
+	case Ounces:
+		return value * millilitersPerOunce
+	case Cups:
+		return value * millilitersPerCup
+	}
+	return value
+}
+
+// HydrationContainer is a container preset from the user's settings
+type HydrationContainer struct {
+	Name   string // Empty for unnamed presets
+	Volume float64
+	Unit   HydrationUnit
+}
+
+// Label returns the container name, or its volume for unnamed presets
+func (hc HydrationContainer) Label() string {
+	if hc.Name != "" {
+		return hc.Name
+	}
+	return fmt.Sprintf("%g %s", hc.Volume, hc.Unit)
+}
+
+// Milliliters returns the container volume in milliliters
+func (hc HydrationContainer) Milliliters() float64 {
+	return toMilliliters(hc.Volume, hc.Unit)
+}
+
+// FindHydrationContainer returns the container whose label matches name,
+// ignoring case
+func FindHydrationContainer(containers []HydrationContainer, name string) (HydrationContainer, bool) {
+	for _, hc := range containers {
+		if strings.EqualFold(hc.Label(), name) {
+			return hc, true
+		}
+	}
+	return HydrationContainer{}, false
+}
+
+// Hydration is the hydration summary of a day; volumes are in milliliters
+type Hydration struct {
+	Date           string // YYYY-MM-DD
+	Goal           float64
+	Intake         float64
+	SweatLoss      float64 // Estimated from the day's activities
+	ActivityIntake float64 // Intake logged during activities
+	DailyAverage   float64 // Zero when unknown
+	LastEntry      time.Time
+}
+
+// Remaining returns the intake still needed to reach the goal, never negative
+func (h *Hydration) Remaining() float64 {
+	return math.Max(h.Goal-h.Intake, 0)
+}
+
+// hydrationDTO mirrors a usersummary-service hydration summary
+type hydrationDTO struct {
+	CalendarDate            string     `json:"calendarDate"`
+	ValueInML               *float64   `json:"valueInML"`
+	GoalInML                *float64   `json:"goalInML"`
+	DailyAverageInML        *float64   `json:"dailyAverageinML"`
+	LastEntryTimestampLocal GarminTime `json:"lastEntryTimestampLocal"`
+	SweatLossInML           *float64   `json:"sweatLossInML"`
+	ActivityIntakeInML      *float64   `json:"activityIntakeInML"`
+}
+
+func (d *hydrationDTO) hydration() *Hydration {
+	return &Hydration{
+		Date:           d.CalendarDate,
+		Goal:           valueOf(d.GoalInML),
+		Intake:         valueOf(d.ValueInML),
+		SweatLoss:      valueOf(d.SweatLossInML),
+		ActivityIntake: valueOf(d.ActivityIntakeInML),
+		DailyAverage:   valueOf(d.DailyAverageInML),
+		LastEntry:      d.LastEntryTimestampLocal.Time,
+	}
+}
+
+// parseHydration decodes a hydration summary response
+func parseHydration(data []byte) (*Hydration, error) {
+	var dto hydrationDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse hydration response: %w", err)
+	}
+	return dto.hydration(), nil
+}
+
+// GetHydration retrieves the hydration goal, intake and sweat loss of a day
+func (c *Client) GetHydration(ctx context.Context, date time.Time) (*Hydration, error) {
+	path := fmt.Sprintf("/usersummary-service/usersummary/hydration/daily/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hydration: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return parseHydration(data)
+}
+
+// LogHydration adds ml milliliters to the intake of the day of date, which
+// also sets the entry time; a negative value removes intake. It returns the
+// updated summary.
+func (c *Client) LogHydration(ctx context.Context, date time.Time, ml float64) (*Hydration, error) {
+	if ml == 0 || math.Abs(ml) > 10000 || math.IsNaN(ml) {
+		return nil, &errors.ValidationError{
+			GarthError: errors.GarthError{Message: "intake must be non-zero and at most 10000 ml"},
+			Field:      "ml",
+		}
+	}
+	profilePK, err := c.userProfilePK()
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"calendarDate":   date.Format("2006-01-02"),
+		"timestampLocal": date.Format("2006-01-02T15:04:05.000"),
+		"valueInML":      ml,
+		"userProfileId":  profilePK,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hydration: %w", err)
+	}
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/usersummary-service/usersummary/hydration/log", "PUT", nil, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to log hydration: %w", err)
+	}
+	if len(data) == 0 {
+		return c.GetHydration(ctx, date)
+	}
+	return parseHydration(data)
+}
+
+// LogHydrationContainer logs one of the user's container presets, selected
+// by label as with FindHydrationContainer
+func (c *Client) LogHydrationContainer(ctx context.Context, date time.Time, name string) (*Hydration, error) {
+	containers, err := c.HydrationContainers()
+	if err != nil {
+		return nil, err
+	}
+	container, ok := FindHydrationContainer(containers, name)
+	if !ok {
+		return nil, &errors.NotFoundError{Resource: "hydration container", ID: name}
+	}
+	return c.LogHydration(ctx, date, container.Milliliters())
+}
+
+// HydrationContainers returns the user's hydration container presets
+func (c *Client) HydrationContainers() ([]HydrationContainer, error) {
+	settings, err := c.Client.GetUserSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user settings: %w", err)
+	}
+	containers := make([]HydrationContainer, 0, len(settings.UserData.HydrationContainers))
+	for _, hc := range settings.UserData.HydrationContainers {
+		unit := HydrationUnit(hc.Unit)
+		if unit == "" {
+			unit = HydrationUnit(settings.UserData.HydrationMeasurementUnit)
+		}
+		containers = append(containers, HydrationContainer{Name: hc.Name, Volume: hc.Volume, Unit: unit})
+	}
+	return containers, nil
+}
+
+// PreferredHydrationUnit returns the user's hydration unit, milliliters by default
+func (c *Client) PreferredHydrationUnit() (HydrationUnit, error) {
+	settings, err := c.Client.GetUserSettings()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user settings: %w", err)
+	}
+	if unit := HydrationUnit(settings.UserData.HydrationMeasurementUnit); unit != "" {
+		return unit, nil
+	}
+	return Milliliters, nil
+}
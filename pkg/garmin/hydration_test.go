@@ -0,0 +1,85 @@
+package garmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHydration(t *testing.T) {
+	var logged []map[string]interface{}
+	intake := 1500.0
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /userprofile-service/userprofile/user-settings":
+			w.Write([]byte(`{"id": 42, "userData": {"hydrationMeasurementUnit": "ounce", "hydrationContainers": [
+				{"name": "Bottle", "volume": 24, "unit": "ounce"},
+				{"name": null, "volume": 8}]}}`))
+		case "GET /usersummary-service/usersummary/hydration/daily/2025-03-01":
+			w.Write([]byte(`{"calendarDate": "2025-03-01", "valueInML": 1500.0, "goalInML": 2500.0,
+				"dailyAverageinML": null, "sweatLossInML": 620.0, "activityIntakeInML": 250.0,
+				"lastEntryTimestampLocal": "2025-03-01T14:00:00.0"}`))
+		case "PUT /usersummary-service/usersummary/hydration/log":
+			var body map[string]interface{}
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			logged = append(logged, body)
+			intake += body["valueInML"].(float64)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"calendarDate": body["calendarDate"], "valueInML": intake, "goalInML": 2500.0,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	date := time.Date(2025, 3, 1, 18, 30, 0, 0, time.UTC)
+
+	h, err := c.GetHydration(ctx, date)
+	require.NoError(t, err)
+	assert.Equal(t, 2500.0, h.Goal)
+	assert.Equal(t, 620.0, h.SweatLoss)
+	assert.Equal(t, 250.0, h.ActivityIntake)
+	assert.Zero(t, h.DailyAverage)
+	assert.Equal(t, 1000.0, h.Remaining())
+	assert.Equal(t, 14, h.LastEntry.Hour())
+
+	h, err = c.LogHydration(ctx, date, -200)
+	require.NoError(t, err)
+	assert.Equal(t, 1300.0, h.Intake)
+	assert.Equal(t, float64(42), logged[0]["userProfileId"])
+	assert.Equal(t, "2025-03-01T18:30:00.000", logged[0]["timestampLocal"])
+
+	unit, err := c.PreferredHydrationUnit()
+	require.NoError(t, err)
+	assert.Equal(t, garmin.Ounces, unit)
+
+	containers, err := c.HydrationContainers()
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+	assert.Equal(t, "8 ounce", containers[1].Label(), "unnamed presets use the preferred unit")
+
+	_, err = c.LogHydrationContainer(ctx, date, "bottle")
+	require.NoError(t, err)
+	assert.InDelta(t, 709.76, logged[1]["valueInML"], 0.01)
+
+	var notFound *garmin.NotFoundError
+	_, err = c.LogHydrationContainer(ctx, date, "Mug")
+	assert.ErrorAs(t, err, &notFound)
+
+	var invalid *garmin.ValidationError
+	_, err = c.LogHydration(ctx, date, 0)
+	assert.ErrorAs(t, err, &invalid)
+	assert.Len(t, logged, 2)
+
+	assert.InDelta(t, 2.0, garmin.ConvertHydration(473.176473, garmin.Cups), 1e-6)
+}
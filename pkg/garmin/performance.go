@@ -0,0 +1,453 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// TrainingReadinessLevel is Garmin's rating of a training readiness score
+type TrainingReadinessLevel string
+
+// Training readiness levels, from least to most ready
+const (
+	TrainingReadinessPoor     TrainingReadinessLevel = "POOR"
+	TrainingReadinessLow      TrainingReadinessLevel = "LOW"
+	TrainingReadinessModerate TrainingReadinessLevel = "MODERATE"
+	TrainingReadinessHigh     TrainingReadinessLevel = "HIGH"
+	TrainingReadinessPrime    TrainingReadinessLevel = "PRIME"
+)
+
+// ReadinessFactor is one input to the training readiness score
+type ReadinessFactor struct {
+	Percent  int    // How favourable the factor is, 0-100
+	Feedback string // e.g. "GOOD", "MODERATE", "POOR"
+}
+
+// TrainingReadinessFactors are the factors contributing to training readiness
+type TrainingReadinessFactors struct {
+	Sleep         ReadinessFactor // Last night's sleep score
+	SleepHistory  ReadinessFactor
+	RecoveryTime  ReadinessFactor
+	AcuteLoad     ReadinessFactor // Acute to chronic workload ratio
+	HRV           ReadinessFactor
+	StressHistory ReadinessFactor
+}
+
+// TrainingReadiness is a training readiness assessment
+type TrainingReadiness struct {
+	Date             string // YYYY-MM-DD
+	Time             time.Time
+	Score            int // 0-100
+	Level            TrainingReadinessLevel
+	FeedbackShort    string
+	FeedbackLong     string
+	InputContext     string // What triggered the assessment, e.g. "AFTER_WAKEUP_RESET"
+	SleepScore       int
+	RecoveryTime     time.Duration
+	AcuteLoad        int
+	HRVWeeklyAverage int // Milliseconds
+	Factors          TrainingReadinessFactors
+}
+
+// trainingReadinessDTO mirrors an entry of the metrics-service training
+// readiness response
+type trainingReadinessDTO struct {
+	CalendarDate                string     `json:"calendarDate"`
+	Timestamp                   GarminTime `json:"timestamp"`
+	Level                       string     `json:"level"`
+	FeedbackLong                string     `json:"feedbackLong"`
+	FeedbackShort               string     `json:"feedbackShort"`
+	Score                       *int       `json:"score"`
+	SleepScore                  int        `json:"sleepScore"`
+	SleepScoreFactorPercent     int        `json:"sleepScoreFactorPercent"`
+	SleepScoreFactorFeedback    string     `json:"sleepScoreFactorFeedback"`
+	RecoveryTime                int        `json:"recoveryTime"` // Minutes
+	RecoveryTimeFactorPercent   int        `json:"recoveryTimeFactorPercent"`
+	RecoveryTimeFactorFeedback  string     `json:"recoveryTimeFactorFeedback"`
+	AcwrFactorPercent           int        `json:"acwrFactorPercent"`
+	AcwrFactorFeedback          string     `json:"acwrFactorFeedback"`
+	AcuteLoad                   int        `json:"acuteLoad"`
+	StressHistoryFactorPercent  int        `json:"stressHistoryFactorPercent"`
+	StressHistoryFactorFeedback string     `json:"stressHistoryFactorFeedback"`
+	HRVFactorPercent            int        `json:"hrvFactorPercent"`
+	HRVFactorFeedback           string     `json:"hrvFactorFeedback"`
+	HRVWeeklyAverage            int        `json:"hrvWeeklyAverage"`
+	SleepHistoryFactorPercent   int        `json:"sleepHistoryFactorPercent"`
+	SleepHistoryFactorFeedback  string     `json:"sleepHistoryFactorFeedback"`
+	InputContext                string     `json:"inputContext"`
+}
+
+func (d trainingReadinessDTO) toTrainingReadiness() TrainingReadiness {
+	return TrainingReadiness{
+		Date:             d.CalendarDate,
+		Time:             d.Timestamp.Time,
+		Score:            *d.Score,
+		Level:            TrainingReadinessLevel(d.Level),
+		FeedbackShort:    d.FeedbackShort,
+		FeedbackLong:     d.FeedbackLong,
+		InputContext:     d.InputContext,
+		SleepScore:       d.SleepScore,
+		RecoveryTime:     time.Duration(d.RecoveryTime) * time.Minute,
+		AcuteLoad:        d.AcuteLoad,
+		HRVWeeklyAverage: d.HRVWeeklyAverage,
+		Factors: TrainingReadinessFactors{
+			Sleep:         ReadinessFactor{d.SleepScoreFactorPercent, d.SleepScoreFactorFeedback},
+			SleepHistory:  ReadinessFactor{d.SleepHistoryFactorPercent, d.SleepHistoryFactorFeedback},
+			RecoveryTime:  ReadinessFactor{d.RecoveryTimeFactorPercent, d.RecoveryTimeFactorFeedback},
+			AcuteLoad:     ReadinessFactor{d.AcwrFactorPercent, d.AcwrFactorFeedback},
+			HRV:           ReadinessFactor{d.HRVFactorPercent, d.HRVFactorFeedback},
+			StressHistory: ReadinessFactor{d.StressHistoryFactorPercent, d.StressHistoryFactorFeedback},
+		},
+	}
+}
+
+// GetTrainingReadiness retrieves the latest training readiness assessment of
+// a day. It returns nil when there is none.
+func (c *Client) GetTrainingReadiness(ctx context.Context, date time.Time) (*TrainingReadiness, error) {
+	path := fmt.Sprintf("/metrics-service/metrics/trainingreadiness/%s", date.Format("2006-01-02"))
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get training readiness: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var entries []trainingReadinessDTO
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse training readiness response: %w", err)
+	}
+
+	var latest *TrainingReadiness
+	for _, e := range entries {
+		if e.Score == nil {
+			continue
+		}
+		if r := e.toTrainingReadiness(); latest == nil || r.Time.After(latest.Time) {
+			latest = &r
+		}
+	}
+	return latest, nil
+}
+
+// GetTrainingReadinessRange retrieves the latest training readiness
+// assessment of each day between two dates, inclusive, oldest first. Days
+// without an assessment are omitted.
+func (c *Client) GetTrainingReadinessRange(ctx context.Context, startDate, endDate time.Time) ([]TrainingReadiness, error) {
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	var readiness []TrainingReadiness
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		r, err := c.GetTrainingReadiness(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			readiness = append(readiness, *r)
+		}
+	}
+	return readiness, nil
+}
+
+// EnduranceClassification is Garmin's rating of an endurance score
+type EnduranceClassification int
+
+// Endurance classifications, from lowest to highest
+const (
+	EnduranceRecreational EnduranceClassification = iota + 1
+	EnduranceIntermediate
+	EnduranceTrained
+	EnduranceWellTrained
+	EnduranceExpert
+	EnduranceSuperior
+	EnduranceElite
+)
+
+// String returns a readable classification name
+func (c EnduranceClassification) String() string {
+	switch c {
+	case EnduranceRecreational:
+		return "Recreational"
+	case EnduranceIntermediate:
+		return "Intermediate"
+	case EnduranceTrained:
+		return "Trained"
+	case EnduranceWellTrained:
+		return "Well-Trained"
+	case EnduranceExpert:
+		return "Expert"
+	case EnduranceSuperior:
+		return "Superior"
+	case EnduranceElite:
+		return "Elite"
+	}
+	return fmt.Sprintf("EnduranceClassification(%d)", int(c))
+}
+
+// EnduranceContributor is the share of an endurance score earned by an
+// activity type
+type EnduranceContributor struct {
+	ActivityTypeID int
+	Group          int     // Activity group when ActivityTypeID is zero
+	Contribution   float64 // Percent
+}
+
+// EnduranceScore is an endurance score assessment
+type EnduranceScore struct {
+	Date           string // YYYY-MM-DD
+	Score          int
+	Classification EnduranceClassification
+	// Lower score limit of each classification above recreational, for the
+	// user's age and gender
+	Limits       map[EnduranceClassification]int
+	Contributors []EnduranceContributor
+}
+
+// enduranceScoreDTO mirrors the metrics-service endurance score response
+type enduranceScoreDTO struct {
+	CalendarDate                         string `json:"calendarDate"`
+	OverallScore                         *int   `json:"overallScore"`
+	Classification                       int    `json:"classification"`
+	ClassificationLowerLimitIntermediate int    `json:"classificationLowerLimitIntermediate"`
+	ClassificationLowerLimitTrained      int    `json:"classificationLowerLimitTrained"`
+	ClassificationLowerLimitWellTrained  int    `json:"classificationLowerLimitWellTrained"`
+	ClassificationLowerLimitExpert       int    `json:"classificationLowerLimitExpert"`
+	ClassificationLowerLimitSuperior     int    `json:"classificationLowerLimitSuperior"`
+	ClassificationLowerLimitElite        int    `json:"classificationLowerLimitElite"`
+	Contributors                         []struct {
+		ActivityTypeID *int    `json:"activityTypeId"`
+		Group          *int    `json:"group"`
+		Contribution   float64 `json:"contribution"`
+	} `json:"contributors"`
+}
+
+func (d *enduranceScoreDTO) toEnduranceScore() *EnduranceScore {
+	if d == nil || d.OverallScore == nil {
+		return nil
+	}
+	score := &EnduranceScore{
+		Date:           d.CalendarDate,
+		Score:          *d.OverallScore,
+		Classification: EnduranceClassification(d.Classification),
+		Limits: map[EnduranceClassification]int{
+			EnduranceIntermediate: d.ClassificationLowerLimitIntermediate,
+			EnduranceTrained:      d.ClassificationLowerLimitTrained,
+			EnduranceWellTrained:  d.ClassificationLowerLimitWellTrained,
+			EnduranceExpert:       d.ClassificationLowerLimitExpert,
+			EnduranceSuperior:     d.ClassificationLowerLimitSuperior,
+			EnduranceElite:        d.ClassificationLowerLimitElite,
+		},
+	}
+	for _, c := range d.Contributors {
+		contributor := EnduranceContributor{Contribution: c.Contribution}
+		if c.ActivityTypeID != nil {
+			contributor.ActivityTypeID = *c.ActivityTypeID
+		}
+		if c.Group != nil {
+			contributor.Group = *c.Group
+		}
+		score.Contributors = append(score.Contributors, contributor)
+	}
+	return score
+}
+
+// NextClassification returns the classification above the current one and
+// the score needed to reach it; ok is false at the top classification or
+// when the limits are unknown
+func (s *EnduranceScore) NextClassification() (next EnduranceClassification, score int, ok bool) {
+	next = s.Classification + 1
+	score, ok = s.Limits[next]
+	return next, score, ok && score > 0
+}
+
+// GetEnduranceScore retrieves the endurance score of a day. It returns nil
+// when there is none.
+func (c *Client) GetEnduranceScore(ctx context.Context, date time.Time) (*EnduranceScore, error) {
+	params := url.Values{}
+	params.Set("calendarDate", date.Format("2006-01-02"))
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/metrics-service/metrics/endurancescore", "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endurance score: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dto enduranceScoreDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse endurance score response: %w", err)
+	}
+	return dto.toEnduranceScore(), nil
+}
+
+// EnduranceScoreWeek is the endurance score of a week
+type EnduranceScoreWeek struct {
+	Start   string // YYYY-MM-DD
+	Average int
+	Max     int
+}
+
+// EnduranceScoreStats is the endurance score over a date range
+type EnduranceScoreStats struct {
+	Average int
+	Max     int
+	Weeks   []EnduranceScoreWeek // Oldest first
+	Latest  *EnduranceScore      // Most recent assessment in the range
+}
+
+// GetEnduranceScoreRange retrieves weekly endurance scores between two
+// dates, inclusive
+func (c *Client) GetEnduranceScoreRange(ctx context.Context, startDate, endDate time.Time) (*EnduranceScoreStats, error) {
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("startDate", startDate.Format("2006-01-02"))
+	params.Set("endDate", endDate.Format("2006-01-02"))
+	params.Set("aggregation", "weekly")
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/metrics-service/metrics/endurancescore/stats", "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get endurance score stats: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var response struct {
+		Avg      *float64 `json:"avg"`
+		Max      *float64 `json:"max"`
+		GroupMap map[string]struct {
+			GroupAverage *float64 `json:"groupAverage"`
+			GroupMax     *float64 `json:"groupMax"`
+		} `json:"groupMap"`
+		EnduranceScoreDTO *enduranceScoreDTO `json:"enduranceScoreDTO"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse endurance score stats response: %w", err)
+	}
+
+	stats := &EnduranceScoreStats{
+		Average: int(valueOf(response.Avg)),
+		Max:     int(valueOf(response.Max)),
+		Latest:  response.EnduranceScoreDTO.toEnduranceScore(),
+	}
+	for start, week := range response.GroupMap {
+		if week.GroupAverage == nil {
+			continue
+		}
+		stats.Weeks = append(stats.Weeks, EnduranceScoreWeek{
+			Start:   start,
+			Average: int(*week.GroupAverage),
+			Max:     int(valueOf(week.GroupMax)),
+		})
+	}
+	sort.Slice(stats.Weeks, func(i, j int) bool { return stats.Weeks[i].Start < stats.Weeks[j].Start })
+	return stats, nil
+}
+
+// HillScore is a hill score assessment, combining hill strength and hill
+// endurance
+type HillScore struct {
+	Date               string // YYYY-MM-DD
+	Overall            int
+	Strength           int
+	Endurance          int
+	ClassificationID   int
+	FeedbackPhraseID   int
+	VO2Max             float64
+	VO2MaxPreciseValue float64
+}
+
+// hillScoreDTO mirrors the metrics-service hill score response
+type hillScoreDTO struct {
+	CalendarDate              string   `json:"calendarDate"`
+	OverallScore              *int     `json:"overallScore"`
+	StrengthScore             int      `json:"strengthScore"`
+	EnduranceScore            int      `json:"enduranceScore"`
+	HillScoreClassificationID int      `json:"hillScoreClassificationId"`
+	HillScoreFeedbackPhraseID int      `json:"hillScoreFeedbackPhraseId"`
+	VO2Max                    *float64 `json:"vo2Max"`
+	VO2MaxPreciseValue        *float64 `json:"vo2MaxPreciseValue"`
+}
+
+func (d *hillScoreDTO) toHillScore() *HillScore {
+	if d == nil || d.OverallScore == nil {
+		return nil
+	}
+	return &HillScore{
+		Date:               d.CalendarDate,
+		Overall:            *d.OverallScore,
+		Strength:           d.StrengthScore,
+		Endurance:          d.EnduranceScore,
+		ClassificationID:   d.HillScoreClassificationID,
+		FeedbackPhraseID:   d.HillScoreFeedbackPhraseID,
+		VO2Max:             valueOf(d.VO2Max),
+		VO2MaxPreciseValue: valueOf(d.VO2MaxPreciseValue),
+	}
+}
+
+// GetHillScore retrieves the hill score of a day. It returns nil when there
+// is none.
+func (c *Client) GetHillScore(ctx context.Context, date time.Time) (*HillScore, error) {
+	params := url.Values{}
+	params.Set("calendarDate", date.Format("2006-01-02"))
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/metrics-service/metrics/hillscore", "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hill score: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dto hillScoreDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse hill score response: %w", err)
+	}
+	return dto.toHillScore(), nil
+}
+
+// GetHillScoreRange retrieves the daily hill scores between two dates,
+// inclusive, oldest first. Days without a score are omitted.
+func (c *Client) GetHillScoreRange(ctx context.Context, startDate, endDate time.Time) ([]HillScore, error) {
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("startDate", startDate.Format("2006-01-02"))
+	params.Set("endDate", endDate.Format("2006-01-02"))
+	params.Set("aggregation", "daily")
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/metrics-service/metrics/hillscore/stats", "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hill score stats: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var response struct {
+		HillScoreDTOList []hillScoreDTO `json:"hillScoreDTOList"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse hill score stats response: %w", err)
+	}
+
+	var scores []HillScore
+	for i := range response.HillScoreDTOList {
+		if s := response.HillScoreDTOList[i].toHillScore(); s != nil {
+			scores = append(scores, *s)
+		}
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Date < scores[j].Date })
+	return scores, nil
+}
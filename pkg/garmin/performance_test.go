@@ -0,0 +1,183 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrainingReadiness(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics-service/metrics/trainingreadiness/2025-03-01":
+			w.Write([]byte(`[
+				{"calendarDate": "2025-03-01", "timestamp": "2025-03-01T12:30:00.0", "level": "MODERATE", "score": 58,
+					"inputContext": "UPDATE_REALTIME_VARIABLES", "recoveryTime": 540, "recoveryTimeFactorPercent": 60},
+				{"calendarDate": "2025-03-01", "timestamp": "2025-03-01T06:00:00.0", "level": "HIGH", "score": 76,
+					"feedbackShort": "WELL_RECOVERED", "inputContext": "AFTER_WAKEUP_RESET",
+					"sleepScore": 84, "sleepScoreFactorPercent": 84, "sleepScoreFactorFeedback": "GOOD",
+					"recoveryTime": 120, "recoveryTimeFactorPercent": 94, "recoveryTimeFactorFeedback": "GOOD",
+					"acwrFactorPercent": 91, "acwrFactorFeedback": "VERY_GOOD", "acuteLoad": 412,
+					"hrvFactorPercent": 100, "hrvFactorFeedback": "GOOD", "hrvWeeklyAverage": 61,
+					"stressHistoryFactorPercent": 78, "stressHistoryFactorFeedback": "GOOD",
+					"sleepHistoryFactorPercent": 82, "sleepHistoryFactorFeedback": "GOOD"}]`))
+		case "/metrics-service/metrics/trainingreadiness/2025-03-02":
+			w.Write([]byte(`[]`))
+		case "/metrics-service/metrics/trainingreadiness/2025-03-03":
+			w.Write([]byte(`[{"calendarDate": "2025-03-03", "timestamp": "2025-03-03T06:10:00.0", "level": "PRIME", "score": 95}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	readiness, err := c.GetTrainingReadiness(ctx, day)
+	require.NoError(t, err)
+	assert.Equal(t, 58, readiness.Score, "latest assessment of the day")
+	assert.Equal(t, garmin.TrainingReadinessModerate, readiness.Level)
+	assert.Equal(t, 9*time.Hour, readiness.RecoveryTime)
+	assert.Equal(t, 60, readiness.Factors.RecoveryTime.Percent)
+
+	missing, err := c.GetTrainingReadiness(ctx, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	history, err := c.GetTrainingReadinessRange(ctx, day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "2025-03-01", history[0].Date)
+	assert.Equal(t, garmin.TrainingReadinessPrime, history[1].Level)
+
+	var invalid *garmin.ValidationError
+	_, err = c.GetTrainingReadinessRange(ctx, day, day.AddDate(0, 0, -1))
+	assert.ErrorAs(t, err, &invalid)
+}
+
+func TestGetTrainingReadinessFactors(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"calendarDate": "2025-03-01", "timestamp": "2025-03-01T06:00:00.0", "level": "HIGH", "score": 76,
+			"sleepScore": 84, "sleepScoreFactorPercent": 84, "sleepScoreFactorFeedback": "GOOD",
+			"acwrFactorPercent": 91, "acwrFactorFeedback": "VERY_GOOD", "acuteLoad": 412,
+			"hrvFactorPercent": 100, "hrvFactorFeedback": "GOOD", "hrvWeeklyAverage": 61,
+			"stressHistoryFactorPercent": 78, "stressHistoryFactorFeedback": "GOOD",
+			"sleepHistoryFactorPercent": 82, "sleepHistoryFactorFeedback": "MODERATE"}]`))
+	}))
+
+	readiness, err := c.GetTrainingReadiness(context.Background(), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 84, readiness.SleepScore)
+	assert.Equal(t, 412, readiness.AcuteLoad)
+	assert.Equal(t, 61, readiness.HRVWeeklyAverage)
+	assert.Equal(t, garmin.TrainingReadinessFactors{
+		Sleep:         garmin.ReadinessFactor{Percent: 84, Feedback: "GOOD"},
+		SleepHistory:  garmin.ReadinessFactor{Percent: 82, Feedback: "MODERATE"},
+		AcuteLoad:     garmin.ReadinessFactor{Percent: 91, Feedback: "VERY_GOOD"},
+		HRV:           garmin.ReadinessFactor{Percent: 100, Feedback: "GOOD"},
+		StressHistory: garmin.ReadinessFactor{Percent: 78, Feedback: "GOOD"},
+	}, readiness.Factors)
+}
+
+func TestGetEnduranceScore(t *testing.T) {
+	const dto = `{"calendarDate": "2025-03-01", "overallScore": 6420, "classification": 3,
+		"classificationLowerLimitIntermediate": 4600, "classificationLowerLimitTrained": 5500,
+		"classificationLowerLimitWellTrained": 6500, "classificationLowerLimitExpert": 7400,
+		"classificationLowerLimitSuperior": 8200, "classificationLowerLimitElite": 9000,
+		"contributors": [{"activityTypeId": 1, "group": null, "contribution": 72.5},
+			{"activityTypeId": null, "group": 8, "contribution": 27.5}]}`
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics-service/metrics/endurancescore":
+			if r.URL.Query().Get("calendarDate") == "2025-03-02" {
+				w.Write([]byte(`{"calendarDate": "2025-03-02", "overallScore": null}`))
+				return
+			}
+			w.Write([]byte(dto))
+		case "/metrics-service/metrics/endurancescore/stats":
+			assert.Equal(t, "weekly", r.URL.Query().Get("aggregation"))
+			assert.Equal(t, "2025-02-17", r.URL.Query().Get("startDate"))
+			w.Write([]byte(`{"avg": 6380, "max": 6450, "enduranceScoreDTO": ` + dto + `, "groupMap": {
+				"2025-02-24": {"groupAverage": 6410, "groupMax": 6450},
+				"2025-02-17": {"groupAverage": 6350, "groupMax": 6390},
+				"2025-02-10": {"groupAverage": null, "groupMax": null}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	score, err := c.GetEnduranceScore(ctx, day)
+	require.NoError(t, err)
+	assert.Equal(t, 6420, score.Score)
+	assert.Equal(t, garmin.EnduranceTrained, score.Classification)
+	assert.Equal(t, "Trained", score.Classification.String())
+	next, target, ok := score.NextClassification()
+	assert.True(t, ok)
+	assert.Equal(t, garmin.EnduranceWellTrained, next)
+	assert.Equal(t, 6500, target)
+	assert.Equal(t, []garmin.EnduranceContributor{
+		{ActivityTypeID: 1, Contribution: 72.5},
+		{Group: 8, Contribution: 27.5},
+	}, score.Contributors)
+
+	missing, err := c.GetEnduranceScore(ctx, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	stats, err := c.GetEnduranceScoreRange(ctx, time.Date(2025, 2, 17, 0, 0, 0, 0, time.UTC), day)
+	require.NoError(t, err)
+	assert.Equal(t, 6380, stats.Average)
+	assert.Equal(t, 6450, stats.Max)
+	assert.Equal(t, []garmin.EnduranceScoreWeek{
+		{Start: "2025-02-17", Average: 6350, Max: 6390},
+		{Start: "2025-02-24", Average: 6410, Max: 6450},
+	}, stats.Weeks)
+	require.NotNil(t, stats.Latest)
+	assert.Equal(t, 6420, stats.Latest.Score)
+
+	top := garmin.EnduranceScore{Classification: garmin.EnduranceElite, Limits: score.Limits}
+	_, _, ok = top.NextClassification()
+	assert.False(t, ok)
+}
+
+func TestGetHillScore(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics-service/metrics/hillscore":
+			assert.Equal(t, "2025-03-01", r.URL.Query().Get("calendarDate"))
+			w.Write([]byte(`{"calendarDate": "2025-03-01", "overallScore": 64, "strengthScore": 58,
+				"enduranceScore": 70, "hillScoreClassificationId": 3, "hillScoreFeedbackPhraseId": 12,
+				"vo2Max": 52.0, "vo2MaxPreciseValue": 52.4}`))
+		case "/metrics-service/metrics/hillscore/stats":
+			assert.Equal(t, "daily", r.URL.Query().Get("aggregation"))
+			w.Write([]byte(`{"hillScoreDTOList": [
+				{"calendarDate": "2025-03-01", "overallScore": 64, "strengthScore": 58, "enduranceScore": 70},
+				{"calendarDate": "2025-02-28", "overallScore": 63, "strengthScore": 57, "enduranceScore": 69},
+				{"calendarDate": "2025-02-27", "overallScore": null}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	score, err := c.GetHillScore(ctx, day)
+	require.NoError(t, err)
+	assert.Equal(t, garmin.HillScore{
+		Date: "2025-03-01", Overall: 64, Strength: 58, Endurance: 70,
+		ClassificationID: 3, FeedbackPhraseID: 12, VO2Max: 52, VO2MaxPreciseValue: 52.4,
+	}, *score)
+
+	history, err := c.GetHillScoreRange(ctx, day.AddDate(0, 0, -2), day)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "2025-02-28", history[0].Date)
+	assert.Equal(t, 70, history[1].Endurance)
+}
@@ -0,0 +1,258 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// RacePrediction is a set of predicted race times
+type RacePrediction struct {
+	Date         string // YYYY-MM-DD
+	FiveK        time.Duration
+	TenK         time.Duration
+	HalfMarathon time.Duration
+	Marathon     time.Duration
+}
+
+// racePredictionDTO mirrors the metrics-service race predictions response;
+// times are in seconds
+type racePredictionDTO struct {
+	CalendarDate     string   `json:"calendarDate"`
+	Time5K           *float64 `json:"time5K"`
+	Time10K          *float64 `json:"time10K"`
+	TimeHalfMarathon *float64 `json:"timeHalfMarathon"`
+	TimeMarathon     *float64 `json:"timeMarathon"`
+}
+
+func (d racePredictionDTO) toRacePrediction() RacePrediction {
+	seconds := func(v *float64) time.Duration {
+		return time.Duration(valueOf(v) * float64(time.Second))
+	}
+	return RacePrediction{
+		Date:         d.CalendarDate,
+		FiveK:        seconds(d.Time5K),
+		TenK:         seconds(d.Time10K),
+		HalfMarathon: seconds(d.TimeHalfMarathon),
+		Marathon:     seconds(d.TimeMarathon),
+	}
+}
+
+// GetRacePredictions retrieves the latest race predictions. It returns nil
+// when Garmin has not predicted any race times.
+func (c *Client) GetRacePredictions(ctx context.Context) (*RacePrediction, error) {
+	path := fmt.Sprintf("/metrics-service/metrics/racepredictions/latest/%s", c.Client.Username)
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get race predictions: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dto racePredictionDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse race predictions response: %w", err)
+	}
+	if dto.Time5K == nil && dto.TimeMarathon == nil {
+		return nil, nil
+	}
+	prediction := dto.toRacePrediction()
+	return &prediction, nil
+}
+
+// GetRacePredictionHistory retrieves the daily race predictions between two
+// dates, inclusive, oldest first
+func (c *Client) GetRacePredictionHistory(ctx context.Context, startDate, endDate time.Time) ([]RacePrediction, error) {
+	if err := validateDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/metrics-service/metrics/racepredictions/daily/%s", c.Client.Username)
+	params := url.Values{}
+	params.Set("fromCalendarDate", startDate.Format("2006-01-02"))
+	params.Set("toCalendarDate", endDate.Format("2006-01-02"))
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get race prediction history: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dtos []racePredictionDTO
+	if err := json.Unmarshal(data, &dtos); err != nil {
+		return nil, fmt.Errorf("failed to parse race prediction history response: %w", err)
+	}
+
+	history := make([]RacePrediction, 0, len(dtos))
+	for _, d := range dtos {
+		history = append(history, d.toRacePrediction())
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Date < history[j].Date })
+	return history, nil
+}
+
+// PersonalRecordUnit is the unit of a personal record value
+type PersonalRecordUnit string
+
+// Personal record units
+const (
+	PersonalRecordDuration PersonalRecordUnit = "duration" // Seconds
+	PersonalRecordDistance PersonalRecordUnit = "distance" // Meters
+	PersonalRecordAscent   PersonalRecordUnit = "ascent"   // Meters
+	PersonalRecordPower    PersonalRecordUnit = "power"    // Watts
+	PersonalRecordSteps    PersonalRecordUnit = "steps"
+	PersonalRecordDays     PersonalRecordUnit = "days"
+)
+
+// PersonalRecordType is a kind of personal record
+type PersonalRecordType struct {
+	ID   int
+	Name string
+	Unit PersonalRecordUnit
+}
+
+// personalRecordTypes maps Garmin's personal record type IDs to their names
+// and units
+var personalRecordTypes = map[int]PersonalRecordType{
+	1:  {1, "Fastest 1K", PersonalRecordDuration},
+	2:  {2, "Fastest Mile", PersonalRecordDuration},
+	3:  {3, "Fastest 5K", PersonalRecordDuration},
+	4:  {4, "Fastest 10K", PersonalRecordDuration},
+	5:  {5, "Fastest Half Marathon", PersonalRecordDuration},
+	6:  {6, "Fastest Marathon", PersonalRecordDuration},
+	7:  {7, "Longest Run", PersonalRecordDistance},
+	8:  {8, "Longest Ride", PersonalRecordDistance},
+	9:  {9, "Most Ascent in a Ride", PersonalRecordAscent},
+	10: {10, "Best 20 Minute Power", PersonalRecordPower},
+	11: {11, "Fastest 40K Ride", PersonalRecordDuration},
+	12: {12, "Most Steps in a Day", PersonalRecordSteps},
+	13: {13, "Most Steps in a Week", PersonalRecordSteps},
+	14: {14, "Most Steps in a Month", PersonalRecordSteps},
+	15: {15, "Longest Step Goal Streak", PersonalRecordDays},
+}
+
+// PersonalRecordTypeByID returns the personal record type with the given ID.
+// Unknown types are named after their ID and have no unit.
+func PersonalRecordTypeByID(id int) PersonalRecordType {
+	if t, ok := personalRecordTypes[id]; ok {
+		return t
+	}
+	return PersonalRecordType{ID: id, Name: fmt.Sprintf("Personal Record %d", id)}
+}
+
+// PersonalRecordTypes returns the known personal record types, ordered by ID
+func PersonalRecordTypes() []PersonalRecordType {
+	types := make([]PersonalRecordType, 0, len(personalRecordTypes))
+	for _, t := range personalRecordTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].ID < types[j].ID })
+	return types
+}
+
+// Format renders a record value in the type's unit, e.g. "21:03" for a
+// duration or "42.20 km" for a distance
+func (t PersonalRecordType) Format(value float64) string {
+	switch t.Unit {
+	case PersonalRecordDuration:
+		return formatRecordDuration(time.Duration(value * float64(time.Second)))
+	case PersonalRecordDistance:
+		return fmt.Sprintf("%.2f km", value/1000)
+	case PersonalRecordAscent:
+		return fmt.Sprintf("%.0f m", value)
+	case PersonalRecordPower:
+		return fmt.Sprintf("%.0f W", value)
+	case PersonalRecordSteps:
+		return fmt.Sprintf("%.0f steps", value)
+	case PersonalRecordDays:
+		return fmt.Sprintf("%.0f days", value)
+	}
+	return fmt.Sprintf("%g", value)
+}
+
+// formatRecordDuration renders a duration as m:ss, or h:mm:ss from an hour
+func formatRecordDuration(d time.Duration) string {
+	s := int(d.Round(time.Second).Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// PersonalRecord is a personal best
+type PersonalRecord struct {
+	ID           int64
+	Type         PersonalRecordType
+	Value        float64 // In the type's unit
+	ActivityID   int64   // Zero for records not set by one activity, such as steps
+	ActivityName string
+	Date         string    // YYYY-MM-DD, local
+	Time         time.Time // Start of the record, UTC
+}
+
+// FormattedValue renders the value in the record type's unit
+func (r PersonalRecord) FormattedValue() string {
+	return r.Type.Format(r.Value)
+}
+
+// String describes the record, e.g. "Fastest 5K: 21:03 on 2025-03-02"
+func (r PersonalRecord) String() string {
+	return fmt.Sprintf("%s: %s on %s", r.Type.Name, r.FormattedValue(), r.Date)
+}
+
+// personalRecordDTO mirrors an entry of the personal record service response
+type personalRecordDTO struct {
+	ID               int64      `json:"id"`
+	TypeID           int        `json:"typeId"`
+	ActivityID       *int64     `json:"activityId"`
+	ActivityName     *string    `json:"activityName"`
+	Value            float64    `json:"value"`
+	PRStartTimeGMT   GarminTime `json:"prStartTimeGmt"`
+	PRStartTimeLocal GarminTime `json:"prStartTimeLocal"`
+}
+
+// GetPersonalRecords retrieves the user's personal records, ordered by type
+func (c *Client) GetPersonalRecords(ctx context.Context) ([]PersonalRecord, error) {
+	path := fmt.Sprintf("/personalrecord-service/personalrecord/prs/%s", c.Client.Username)
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get personal records: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dtos []personalRecordDTO
+	if err := json.Unmarshal(data, &dtos); err != nil {
+		return nil, fmt.Errorf("failed to parse personal records response: %w", err)
+	}
+
+	records := make([]PersonalRecord, 0, len(dtos))
+	for _, d := range dtos {
+		r := PersonalRecord{
+			ID:    d.ID,
+			Type:  PersonalRecordTypeByID(d.TypeID),
+			Value: d.Value,
+			Time:  d.PRStartTimeGMT.Time,
+		}
+		if d.ActivityID != nil {
+			r.ActivityID = *d.ActivityID
+		}
+		if d.ActivityName != nil {
+			r.ActivityName = *d.ActivityName
+		}
+		if !d.PRStartTimeLocal.IsZero() {
+			r.Date = d.PRStartTimeLocal.Format("2006-01-02")
+		}
+		records = append(records, r)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Type.ID < records[j].Type.ID })
+	return records, nil
+}
@@ -0,0 +1,100 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRacePredictions(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics-service/metrics/racepredictions/latest/testuser":
+			w.Write([]byte(`{"userId": 1, "calendarDate": "2025-03-02", "time5K": 1263,
+				"time10K": 2650, "timeHalfMarathon": 5902, "timeMarathon": 12510}`))
+		case "/metrics-service/metrics/racepredictions/daily/testuser":
+			assert.Equal(t, "2025-03-01", r.URL.Query().Get("fromCalendarDate"))
+			assert.Equal(t, "2025-03-02", r.URL.Query().Get("toCalendarDate"))
+			w.Write([]byte(`[{"calendarDate": "2025-03-02", "time5K": 1263, "time10K": 2650},
+				{"calendarDate": "2025-03-01", "time5K": 1270, "time10K": 2661}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	prediction, err := c.GetRacePredictions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, garmin.RacePrediction{
+		Date:         "2025-03-02",
+		FiveK:        21*time.Minute + 3*time.Second,
+		TenK:         44*time.Minute + 10*time.Second,
+		HalfMarathon: time.Hour + 38*time.Minute + 22*time.Second,
+		Marathon:     3*time.Hour + 28*time.Minute + 30*time.Second,
+	}, *prediction)
+
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	history, err := c.GetRacePredictionHistory(ctx, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, "2025-03-01", history[0].Date)
+	assert.Equal(t, 21*time.Minute+10*time.Second, history[0].FiveK)
+
+	var invalid *garmin.ValidationError
+	_, err = c.GetRacePredictionHistory(ctx, day, day.AddDate(0, 0, -1))
+	assert.ErrorAs(t, err, &invalid)
+}
+
+func TestGetPersonalRecords(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/personalrecord-service/personalrecord/prs/testuser" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[
+			{"id": 12, "typeId": 12, "activityId": null, "value": 31874.0,
+				"prStartTimeGmt": 1740787200000, "prStartTimeLocal": 1740783600000},
+			{"id": 3, "typeId": 3, "activityId": 18000000001, "activityName": "Parkrun", "value": 1263.2,
+				"prStartTimeGmt": 1740902400000, "prStartTimeLocal": 1740906000000},
+			{"id": 7, "typeId": 7, "activityId": 18000000002, "value": 30512.4,
+				"prStartTimeGmt": 1740902400000, "prStartTimeLocal": 1740906000000},
+			{"id": 99, "typeId": 99, "value": 5,
+				"prStartTimeGmt": 1740902400000, "prStartTimeLocal": 1740906000000}]`))
+	}))
+
+	records, err := c.GetPersonalRecords(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	fiveK := records[0]
+	assert.Equal(t, "Fastest 5K", fiveK.Type.Name)
+	assert.Equal(t, int64(18000000001), fiveK.ActivityID)
+	assert.Equal(t, "Parkrun", fiveK.ActivityName)
+	assert.Equal(t, time.Date(2025, 3, 2, 8, 0, 0, 0, time.UTC), fiveK.Time)
+	assert.Equal(t, "Fastest 5K: 21:03 on 2025-03-02", fiveK.String())
+
+	assert.Equal(t, "Longest Run: 30.51 km on 2025-03-02", records[1].String())
+	assert.Equal(t, int64(0), records[2].ActivityID)
+	assert.Equal(t, "Most Steps in a Day: 31874 steps on 2025-02-28", records[2].String())
+	assert.Equal(t, "Personal Record 99: 5 on 2025-03-02", records[3].String())
+}
+
+func TestPersonalRecordTypes(t *testing.T) {
+	types := garmin.PersonalRecordTypes()
+	require.NotEmpty(t, types)
+	assert.Equal(t, 1, types[0].ID)
+	for i := 1; i < len(types); i++ {
+		assert.Less(t, types[i-1].ID, types[i].ID)
+	}
+
+	marathon := garmin.PersonalRecordTypeByID(6)
+	assert.Equal(t, garmin.PersonalRecordDuration, marathon.Unit)
+	assert.Equal(t, "3:28:30", marathon.Format(12510))
+	assert.Equal(t, "250 W", garmin.PersonalRecordTypeByID(10).Format(250))
+}
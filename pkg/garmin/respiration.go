@@ -0,0 +1,89 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DailyRespiration is the all-day respiration rate of a day, in breaths
+// per minute
+type DailyRespiration struct {
+	Date         string // YYYY-MM-DD
+	Lowest       float64
+	Highest      float64
+	WakingAvg    float64
+	SleepAvg     float64
+	Acclimation  bool                 // Readings were taken for altitude acclimation
+	Readings     []TimedValue         // Oldest first
+	HourlyRanges []RespirationAverage // Oldest first
+}
+
+// RespirationAverage summarizes the respiration rate over an interval
+type RespirationAverage struct {
+	Time    time.Time
+	Average float64
+	High    float64
+	Low     float64
+}
+
+// dailyRespirationDTO mirrors the wellness-service daily respiration response
+type dailyRespirationDTO struct {
+	CalendarDate                   string       `json:"calendarDate"`
+	LowestRespirationValue         *float64     `json:"lowestRespirationValue"`
+	HighestRespirationValue        *float64     `json:"highestRespirationValue"`
+	AvgWakingRespirationValue      *float64     `json:"avgWakingRespirationValue"`
+	AvgSleepRespirationValue       *float64     `json:"avgSleepRespirationValue"`
+	AcclimationFlag                *bool        `json:"acclimationFlag"`
+	RespirationValuesArray         [][]*float64 `json:"respirationValuesArray"`
+	RespirationAveragesValuesArray [][]*float64 `json:"respirationAveragesValuesArray"`
+}
+
+// GetDailyRespiration retrieves the all-day respiration rate of a day. It
+// returns nil when the device recorded no readings.
+func (c *Client) GetDailyRespiration(ctx context.Context, date time.Time) (*DailyRespiration, error) {
+	path := fmt.Sprintf("/wellness-service/wellness/daily/respiration/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get respiration data: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dto dailyRespirationDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse respiration response: %w", err)
+	}
+	if dto.LowestRespirationValue == nil && len(dto.RespirationValuesArray) == 0 {
+		return nil, nil
+	}
+
+	resp := &DailyRespiration{
+		Date:        dto.CalendarDate,
+		Lowest:      valueOf(dto.LowestRespirationValue),
+		Highest:     valueOf(dto.HighestRespirationValue),
+		WakingAvg:   valueOf(dto.AvgWakingRespirationValue),
+		SleepAvg:    valueOf(dto.AvgSleepRespirationValue),
+		Acclimation: dto.AcclimationFlag != nil && *dto.AcclimationFlag,
+		Readings:    parseSeries(dto.RespirationValuesArray),
+	}
+	if dto.LowestRespirationValue == nil {
+		resp.Lowest, resp.Highest = seriesRange(resp.Readings)
+	}
+
+	// Averages arrive as [epochMillis, average, high, low]
+	for _, entry := range dto.RespirationAveragesValuesArray {
+		if len(entry) < 4 || entry[0] == nil || entry[1] == nil {
+			continue
+		}
+		resp.HourlyRanges = append(resp.HourlyRanges, RespirationAverage{
+			Time:    time.UnixMilli(int64(*entry[0])).UTC(),
+			Average: *entry[1],
+			High:    valueOf(entry[2]),
+			Low:     valueOf(entry[3]),
+		})
+	}
+	return resp, nil
+}
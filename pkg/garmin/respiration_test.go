@@ -0,0 +1,37 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDailyRespiration(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wellness-service/wellness/daily/respiration/2025-03-01" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"calendarDate": "2025-03-01", "lowestRespirationValue": 9.0,
+			"highestRespirationValue": 21.0, "avgWakingRespirationValue": 15.0, "avgSleepRespirationValue": 12.0,
+			"respirationValuesArray": [[1740787200000, 12.0], [1740787320000, -1.0], [1740787440000, 13.0]],
+			"respirationAveragesValuesArray": [[1740787200000, 12.5, 14.0, 11.0]]}`))
+	}))
+
+	resp, err := c.GetDailyRespiration(context.Background(), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 9.0, resp.Lowest)
+	assert.Equal(t, 21.0, resp.Highest)
+	assert.Equal(t, 15.0, resp.WakingAvg)
+	assert.Equal(t, 12.0, resp.SleepAvg)
+	assert.False(t, resp.Acclimation)
+	require.Len(t, resp.Readings, 2, "placeholder readings are skipped")
+	assert.Equal(t, time.Date(2025, 3, 1, 0, 4, 0, 0, time.UTC), resp.Readings[1].Time)
+	require.Len(t, resp.HourlyRanges, 1)
+	assert.Equal(t, 14.0, resp.HourlyRanges[0].High)
+	assert.Equal(t, 11.0, resp.HourlyRanges[0].Low)
+}
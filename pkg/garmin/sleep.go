@@ -0,0 +1,44 @@
+package garmin
+
+import (
+	"time"
+
+	"github.com/sstent/go-garth/internal/data"
+)
+
+// Hypnogram is the stage-by-stage structure of a night's sleep with latency,
+// WASO, awakening, transition and cycle metrics
+type Hypnogram = data.Hypnogram
+
+// SleepSegment is a contiguous span of one sleep stage
+type SleepSegment = data.SleepSegment
+
+// SleepCycle is a sleep cycle ending with a REM episode
+type SleepCycle = data.SleepCycle
+
+// SleepStage is a stage of a hypnogram
+type SleepStage = data.SleepStage
+
+// Sleep stages as reported by Garmin
+const (
+	SleepStageDeep         = data.SleepStageDeep
+	SleepStageLight        = data.SleepStageLight
+	SleepStageREM          = data.SleepStageREM
+	SleepStageAwake        = data.SleepStageAwake
+	SleepStageUnmeasurable = data.SleepStageUnmeasurable
+)
+
+// NewHypnogram builds the hypnogram of sleep data returned by GetSleepData
+func NewHypnogram(sleep *DetailedSleepData) *Hypnogram {
+	return data.NewHypnogram(sleep)
+}
+
+// GetSleepHypnogram retrieves the sleep of the night ending on date as a
+// hypnogram. It returns nil when no sleep was recorded.
+func (c *Client) GetSleepHypnogram(date time.Time) (*Hypnogram, error) {
+	sleep, err := c.GetSleepData(date)
+	if err != nil || sleep == nil {
+		return nil, err
+	}
+	return NewHypnogram(sleep), nil
+}
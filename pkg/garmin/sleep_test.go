@@ -0,0 +1,82 @@
+package garmin_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sleepBody = `{
+	"dailySleepDTO": {"userProfilePK": 1, "calendarDate": "2025-03-01",
+		"sleepStartTimestampGMT": 1740801600000, "sleepEndTimestampGMT": 1740820500000,
+		"sleepStartTimestampLocal": 1740783600000, "sleepEndTimestampLocal": 1740802500000,
+		"sleepScores": {
+			"overall": {"value": 82, "qualifierKey": "GOOD"},
+			"remPercentage": {"value": 18, "qualifierKey": "FAIR", "optimalStart": 21, "optimalEnd": 31}}},
+	"sleepLevels": [
+		{"startGMT": "2025-03-01T04:00:00.0", "endGMT": "2025-03-01T04:20:00.0", "activityLevel": 3.0},
+		{"startGMT": "2025-03-01T04:20:00.0", "endGMT": "2025-03-01T05:00:00.0", "activityLevel": 1.0},
+		{"startGMT": "2025-03-01T05:00:00.0", "endGMT": "2025-03-01T05:40:00.0", "activityLevel": 0.0},
+		{"startGMT": "2025-03-01T05:40:00.0", "endGMT": "2025-03-01T06:00:00.0", "activityLevel": 1.0},
+		{"startGMT": "2025-03-01T06:00:00.0", "endGMT": "2025-03-01T06:20:00.0", "activityLevel": 2.0},
+		{"startGMT": "2025-03-01T06:20:00.0", "endGMT": "2025-03-01T06:23:00.0", "activityLevel": 3.0},
+		{"startGMT": "2025-03-01T06:23:00.0", "endGMT": "2025-03-01T06:30:00.0", "activityLevel": 2.0},
+		{"startGMT": "2025-03-01T06:30:00.0", "endGMT": "2025-03-01T07:10:00.0", "activityLevel": 1.0},
+		{"startGMT": "2025-03-01T07:10:00.0", "endGMT": "2025-03-01T07:30:00.0", "activityLevel": 1.0},
+		{"startGMT": "2025-03-01T07:30:00.0", "endGMT": "2025-03-01T08:00:00.0", "activityLevel": 2.0},
+		{"startGMT": "2025-03-01T08:00:00.0", "endGMT": "2025-03-01T08:10:00.0", "activityLevel": 3.0},
+		{"startGMT": "2025-03-01T08:10:00.0", "endGMT": "2025-03-01T09:00:00.0", "activityLevel": 1.0},
+		{"startGMT": "2025-03-01T09:00:00.0", "endGMT": "2025-03-01T09:15:00.0", "activityLevel": 3.0}],
+	"sleepRestlessMoments": [{"value": 1, "startGMT": 1740808200000}],
+	"restlessMomentsCount": 1}`
+
+func TestGetSleepHypnogram(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wellness-service/wellness/dailySleepData/testuser" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "2025-03-01", r.URL.Query().Get("date"))
+		w.Write([]byte(sleepBody))
+	}))
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	sleep, err := c.GetSleepData(date)
+	require.NoError(t, err)
+	assert.Equal(t, 82.0, sleep.SleepScores.Overall.Value)
+	assert.Equal(t, "FAIR", sleep.SleepScores.RemPercentage.QualifierKey)
+	assert.Equal(t, 21.0, sleep.SleepScores.RemPercentage.OptimalStart)
+	assert.Equal(t, 1, sleep.RestlessCount)
+
+	h, err := c.GetSleepHypnogram(date)
+	require.NoError(t, err)
+	_, offset := h.SleepStart.Zone()
+	assert.Equal(t, -5*3600, offset)
+	assert.Equal(t, 23, h.SleepStart.Hour(), "times are local to the sleeper")
+	assert.Equal(t, 28, h.Onset().Day())
+
+	require.Len(t, h.Segments, 12, "adjacent segments of one stage are merged")
+	assert.Equal(t, garmin.SleepStageLight, h.Segments[7].Stage)
+	assert.Equal(t, time.Hour, h.Segments[7].Duration())
+	assert.Equal(t, 11, h.Transitions())
+	assert.Equal(t, 20*time.Minute, h.Latency())
+	assert.Equal(t, 2, h.Awakenings())
+	assert.Equal(t, 13*time.Minute, h.WASO())
+	assert.Equal(t, 9, h.FinalAwakening().UTC().Hour())
+	assert.Equal(t, 57*time.Minute, h.StageDurations()[garmin.SleepStageREM])
+
+	cycles := h.Cycles()
+	require.Len(t, cycles, 2, "a short awakening does not split a REM episode")
+	assert.Equal(t, h.Onset(), cycles[0].Start)
+	assert.Equal(t, 130*time.Minute, cycles[0].Duration())
+	assert.True(t, cycles[1].Start.Equal(cycles[0].End))
+
+	require.Len(t, h.RestlessMoments, 1)
+	assert.Equal(t, 0, h.RestlessMoments[0].Hour())
+	assert.Equal(t, 50, h.RestlessMoments[0].Minute())
+}
@@ -0,0 +1,87 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DailySpO2 is the all-day blood oxygen saturation of a day, in percent
+type DailySpO2 struct {
+	Date            string // YYYY-MM-DD
+	Average         float64
+	Lowest          float64
+	Highest         float64 // Highest hourly average or reading
+	Latest          float64
+	LatestTime      time.Time
+	SleepAverage    float64
+	SevenDayAverage float64
+	Acclimation     bool         // Readings were taken for altitude acclimation
+	HourlyAverages  []TimedValue // Oldest first
+	SingleValues    []TimedValue // On-demand spot checks
+	Continuous      []SpO2Epoch  // All-day or sleep readings, when recorded
+}
+
+// dailySpO2DTO mirrors the wellness-service daily SpO2 response
+type dailySpO2DTO struct {
+	CalendarDate             string       `json:"calendarDate"`
+	AverageSpO2              *float64     `json:"averageSpO2"`
+	LowestSpO2               *float64     `json:"lowestSpO2"`
+	LatestSpO2               *float64     `json:"latestSpO2"`
+	LatestSpO2TimestampGMT   GarminTime   `json:"latestSpO2TimestampGMT"`
+	AvgSleepSpO2             *float64     `json:"avgSleepSpO2"`
+	LastSevenDaysAvgSpO2     *float64     `json:"lastSevenDaysAvgSpO2"`
+	AcclimationFlag          *bool        `json:"acclimationFlag"`
+	SpO2HourlyAverages       [][]*float64 `json:"spO2HourlyAverages"`
+	SpO2SingleValues         [][]*float64 `json:"spO2SingleValues"`
+	ContinuousReadingDTOList []SpO2Epoch  `json:"continuousReadingDTOList"`
+}
+
+// GetDailySpO2 retrieves the all-day blood oxygen saturation of a day. It
+// returns nil when the device recorded no readings.
+func (c *Client) GetDailySpO2(ctx context.Context, date time.Time) (*DailySpO2, error) {
+	path := fmt.Sprintf("/wellness-service/wellness/daily/spo2/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SpO2 data: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var dto dailySpO2DTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to parse SpO2 response: %w", err)
+	}
+	if dto.AverageSpO2 == nil && len(dto.SpO2HourlyAverages) == 0 && len(dto.ContinuousReadingDTOList) == 0 {
+		return nil, nil
+	}
+
+	spo2 := &DailySpO2{
+		Date:            dto.CalendarDate,
+		Average:         valueOf(dto.AverageSpO2),
+		Lowest:          valueOf(dto.LowestSpO2),
+		Latest:          valueOf(dto.LatestSpO2),
+		LatestTime:      dto.LatestSpO2TimestampGMT.Time,
+		SleepAverage:    valueOf(dto.AvgSleepSpO2),
+		SevenDayAverage: valueOf(dto.LastSevenDaysAvgSpO2),
+		Acclimation:     dto.AcclimationFlag != nil && *dto.AcclimationFlag,
+		HourlyAverages:  parseSeries(dto.SpO2HourlyAverages),
+		SingleValues:    parseSeries(dto.SpO2SingleValues),
+		Continuous:      dto.ContinuousReadingDTOList,
+	}
+
+	// Garmin reports no daily maximum; derive it, and the minimum when
+	// missing, from the readings
+	readings := append(append([]TimedValue{}, spo2.HourlyAverages...), spo2.SingleValues...)
+	for _, e := range spo2.Continuous {
+		readings = append(readings, TimedValue{Time: e.EpochTimestamp.Time, Value: float64(e.SpO2Reading)})
+	}
+	low, high := seriesRange(readings)
+	spo2.Highest = high
+	if spo2.Lowest == 0 {
+		spo2.Lowest = low
+	}
+	return spo2, nil
+}
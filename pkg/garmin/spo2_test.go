@@ -0,0 +1,80 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDailySpO2(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wellness-service/wellness/daily/spo2/2025-03-01":
+			w.Write([]byte(`{"calendarDate": "2025-03-01", "averageSpO2": 95.0, "lowestSpO2": 88,
+				"latestSpO2": 96, "latestSpO2TimestampGMT": "2025-03-01T21:00:00.0",
+				"avgSleepSpO2": 94.0, "lastSevenDaysAvgSpO2": 94.7, "acclimationFlag": true,
+				"spO2HourlyAverages": [[1740808800000, 94], [1740812400000, 97], [1740816000000, null]],
+				"spO2SingleValues": [[1740834000000, 98]],
+				"continuousReadingDTOList": [{"epochTimestamp": "2025-03-01T06:00:00.0", "epochDuration": 60, "spo2Reading": 93, "readingConfidence": 2}]}`))
+		case "/wellness-service/wellness/daily/spo2/2025-03-02":
+			w.Write([]byte(`{"calendarDate": "2025-03-02", "averageSpO2": null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	spo2, err := c.GetDailySpO2(ctx, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 95.0, spo2.Average)
+	assert.Equal(t, 88.0, spo2.Lowest)
+	assert.Equal(t, 98.0, spo2.Highest)
+	assert.Equal(t, 94.7, spo2.SevenDayAverage)
+	assert.True(t, spo2.Acclimation)
+	assert.Equal(t, 21, spo2.LatestTime.Hour())
+	require.Len(t, spo2.HourlyAverages, 2, "missing hourly values are skipped")
+	assert.Equal(t, time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC), spo2.HourlyAverages[1].Time)
+	require.Len(t, spo2.Continuous, 1)
+	assert.Equal(t, 93, spo2.Continuous[0].SpO2Reading)
+
+	spo2, err = c.GetDailySpO2(ctx, time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Nil(t, spo2)
+}
+
+func TestGetDetailedSleepData_SpO2AndRespiration(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wellness-service/wellness/dailySleepData/testuser" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "2025-03-01", r.URL.Query().Get("date"))
+		w.Write([]byte(`{
+			"dailySleepDTO": {"userProfilePk": 1, "deepSleepSeconds": 3600},
+			"wellnessSpO2SleepSummaryDTO": {"userProfilePk": 1, "averageSPO2": 94.0, "lowestSPO2": 89,
+				"sleepMeasurementStartGMT": "2025-02-28T22:30:00.0", "sleepMeasurementEndGMT": "2025-03-01T06:10:00.0"},
+			"wellnessEpochSPO2DataDTOList": [
+				{"epochTimestamp": "2025-02-28T23:00:00.0", "epochDuration": 60, "spo2Reading": 95, "readingConfidence": 3}],
+			"wellnessEpochRespirationDataDTOList": [
+				{"startTimeGMT": 1740783600000, "respirationValue": 13.5}],
+			"sleepStress": [{"startGMT": 1740783600000, "value": 12}]}`))
+	}))
+
+	sleep, err := c.GetDetailedSleepData(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NotNil(t, sleep.SpO2Summary)
+	assert.Equal(t, 94.0, *sleep.SpO2Summary.AverageSpO2)
+	assert.Equal(t, 89, *sleep.SpO2Summary.LowestSpO2)
+	assert.Equal(t, 6, sleep.SpO2Summary.SleepMeasurementEndGMT.Hour())
+	require.Len(t, sleep.SpO2Epochs, 1)
+	assert.Equal(t, 95, sleep.SpO2Epochs[0].SpO2Reading)
+	require.Len(t, sleep.RespirationEpochs, 1)
+	assert.Equal(t, 13.5, sleep.RespirationEpochs[0].RespirationValue)
+	assert.Equal(t, time.Date(2025, 2, 28, 23, 0, 0, 0, time.UTC), sleep.RespirationEpochs[0].StartTimeGMT.Time)
+	require.Len(t, sleep.StressEpochs, 1)
+	assert.Equal(t, 12, sleep.StressEpochs[0].Value)
+}
@@ -0,0 +1,200 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+)
+
+// Canonical stream column names. Metric descriptors returned by the details
+// endpoint are mapped onto these names; unknown metrics keep their Garmin key.
+const (
+	StreamElapsedDuration = streams.ElapsedDuration
+	StreamMovingDuration  = streams.MovingDuration
+	StreamDistance        = streams.Distance
+	StreamHeartRate       = streams.HeartRate
+	StreamSpeed           = streams.Speed
+	StreamPower           = streams.Power
+	StreamCadence         = streams.Cadence
+	StreamAltitude        = streams.Altitude
+	StreamLatitude        = streams.Latitude
+	StreamLongitude       = streams.Longitude
+	StreamTemperature     = streams.Temperature
+	StreamVerticalSpeed   = streams.VerticalSpeed
+	StreamRespiration     = streams.Respiration
+)
+
+// streamMetricNames maps Garmin metric descriptor keys to canonical column names
+var streamMetricNames = map[string]string{
+	"sumElapsedDuration":    StreamElapsedDuration,
+	"sumMovingDuration":     StreamMovingDuration,
+	"sumDistance":           StreamDistance,
+	"directHeartRate":       StreamHeartRate,
+	"directSpeed":           StreamSpeed,
+	"directPower":           StreamPower,
+	"directRunCadence":      StreamCadence,
+	"directBikeCadence":     StreamCadence,
+	"directDoubleCadence":   "double_cadence",
+	"directElevation":       StreamAltitude,
+	"directLatitude":        StreamLatitude,
+	"directLongitude":       StreamLongitude,
+	"directAirTemperature":  StreamTemperature,
+	"directVerticalSpeed":   StreamVerticalSpeed,
+	"directRespirationRate": StreamRespiration,
+}
+
+// timestampMetricKey is the descriptor carrying the sample time in epoch milliseconds
+const timestampMetricKey = "directTimestamp"
+
+// StreamsOptions controls the resolution of activity stream requests
+type StreamsOptions struct {
+	MaxChartSize    int // Maximum samples per metric, defaults to 2000
+	MaxPolylineSize int // Maximum polyline points, defaults to 4000
+}
+
+// MetricUnit describes the unit of a metric descriptor
+type MetricUnit struct {
+	ID     int     `json:"id"`
+	Key    string  `json:"key"`
+	Factor float64 `json:"factor"`
+}
+
+// MetricDescriptor describes one column of the activity detail metrics
+type MetricDescriptor struct {
+	MetricsIndex int        `json:"metricsIndex"`
+	Key          string     `json:"key"`
+	Unit         MetricUnit `json:"unit"`
+}
+
+// PolylinePoint represents a single point of the activity GPS track
+type PolylinePoint = streams.PolylinePoint
+
+// GeoBounds represents the geographic bounding box of an activity
+type GeoBounds = streams.GeoBounds
+
+// StreamColumn is a single named, unit-aware series of an ActivityStreams
+type StreamColumn = streams.StreamColumn
+
+// ActivityStreams is a columnar representation of an activity's time series
+type ActivityStreams = streams.ActivityStreams
+
+// NewActivityStreams creates an empty stream set for the given activity
+func NewActivityStreams(activityID int64) *ActivityStreams {
+	return streams.New(activityID)
+}
+
+// activityDetailsResponse mirrors the activity-service details payload
+type activityDetailsResponse struct {
+	ActivityID        int64              `json:"activityId"`
+	MetricDescriptors []MetricDescriptor `json:"metricDescriptors"`
+	DetailMetrics     []struct {
+		Metrics []*float64 `json:"metrics"`
+	} `json:"activityDetailMetrics"`
+	GeoPolyline *struct {
+		MinLat   float64         `json:"minLat"`
+		MaxLat   float64         `json:"maxLat"`
+		MinLon   float64         `json:"minLon"`
+		MaxLon   float64         `json:"maxLon"`
+		Polyline []PolylinePoint `json:"polyline"`
+	} `json:"geoPolylineDTO"`
+}
+
+// GetActivityStreams retrieves the per-sample time series of an activity and
+// maps its metric descriptors to named, unit-aware columns
+func (c *Client) GetActivityStreams(ctx context.Context, activityID int, opts StreamsOptions) (*ActivityStreams, error) {
+	if opts.MaxChartSize <= 0 {
+		opts.MaxChartSize = 2000
+	}
+	if opts.MaxPolylineSize <= 0 {
+		opts.MaxPolylineSize = 4000
+	}
+
+	params := url.Values{}
+	params.Add("maxChartSize", strconv.Itoa(opts.MaxChartSize))
+	params.Add("maxPolylineSize", strconv.Itoa(opts.MaxPolylineSize))
+
+	path := fmt.Sprintf("/activity-service/activity/%d/details", activityID)
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get activity details: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("activity details not found")
+	}
+
+	var response activityDetailsResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse activity details response: %w", err)
+	}
+	if response.ActivityID == 0 {
+		response.ActivityID = int64(activityID)
+	}
+
+	return parseActivityStreams(&response), nil
+}
+
+// parseActivityStreams converts the descriptor/metrics matrix into columns
+func parseActivityStreams(response *activityDetailsResponse) *ActivityStreams {
+	result := NewActivityStreams(response.ActivityID)
+
+	timestampIndex := -1
+	for _, d := range response.MetricDescriptors {
+		if d.Key == timestampMetricKey {
+			timestampIndex = d.MetricsIndex
+		}
+	}
+
+	for _, row := range response.DetailMetrics {
+		if timestampIndex < 0 || timestampIndex >= len(row.Metrics) || row.Metrics[timestampIndex] == nil {
+			continue
+		}
+		ts := int64(*row.Metrics[timestampIndex])
+		result.Timestamps = append(result.Timestamps, time.UnixMilli(ts).UTC())
+	}
+
+	for _, d := range response.MetricDescriptors {
+		if d.Key == timestampMetricKey {
+			continue
+		}
+		name, ok := streamMetricNames[d.Key]
+		if !ok {
+			name = d.Key
+		}
+		// Several keys can map to one name (run and bike cadence); keep the first
+		if result.Has(name) {
+			continue
+		}
+
+		values := make([]float64, 0, len(result.Timestamps))
+		for _, row := range response.DetailMetrics {
+			if timestampIndex < 0 || timestampIndex >= len(row.Metrics) || row.Metrics[timestampIndex] == nil {
+				continue
+			}
+			if d.MetricsIndex < len(row.Metrics) && row.Metrics[d.MetricsIndex] != nil {
+				values = append(values, *row.Metrics[d.MetricsIndex])
+			} else {
+				values = append(values, math.NaN())
+			}
+		}
+		result.Columns[name] = &StreamColumn{Name: name, Key: d.Key, Unit: d.Unit.Key, Values: values}
+	}
+
+	if response.GeoPolyline != nil {
+		result.Polyline = response.GeoPolyline.Polyline
+		result.Bounds = &GeoBounds{
+			MinLat: response.GeoPolyline.MinLat,
+			MaxLat: response.GeoPolyline.MaxLat,
+			MinLon: response.GeoPolyline.MinLon,
+			MaxLon: response.GeoPolyline.MaxLon,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,88 @@
+package streams
+
+import "math"
+
+// AddColumn builds a column by calling value for each timestamp index. A nil
+// value is stored as NaN, and the column is only added when at least one
+// sample was recorded. The timestamps must be set first.
+func (s *ActivityStreams) AddColumn(name, key, unit string, value func(i int) *float64) {
+	values := make([]float64, len(s.Timestamps))
+	recorded := false
+	for i := range values {
+		if v := value(i); v != nil {
+			values[i] = *v
+			recorded = true
+		} else {
+			values[i] = math.NaN()
+		}
+	}
+	if recorded {
+		s.SetColumn(StreamColumn{Name: name, Key: key, Unit: unit, Values: values})
+	}
+}
+
+// Float returns sample i of the named column, or nil when the column is not
+// present or the sample was not recorded
+func (s *ActivityStreams) Float(name string, i int) *float64 {
+	values := s.Values(name)
+	if i >= len(values) || math.IsNaN(values[i]) {
+		return nil
+	}
+	v := values[i]
+	return &v
+}
+
+// Int returns sample i of the named column rounded to the nearest integer, or
+// nil when the column is not present or the sample was not recorded
+func (s *ActivityStreams) Int(name string, i int) *int {
+	if v := s.Float(name, i); v != nil {
+		n := int(math.Round(*v))
+		return &n
+	}
+	return nil
+}
+
+// IntToFloat converts an optional integer sample to an optional float sample
+func IntToFloat(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+// Stat accumulates the average and maximum of optional integer samples, as
+// used for lap summaries
+type Stat struct {
+	sum, count, max int
+}
+
+// Add records a sample; nil samples are ignored
+func (s *Stat) Add(v *int) {
+	if v == nil {
+		return
+	}
+	s.sum += *v
+	s.count++
+	if s.count == 1 || *v > s.max {
+		s.max = *v
+	}
+}
+
+// Avg returns the rounded average of the samples, or nil when there are none
+func (s *Stat) Avg() *int {
+	if s.count == 0 {
+		return nil
+	}
+	v := int(math.Round(float64(s.sum) / float64(s.count)))
+	return &v
+}
+
+// Max returns the largest sample, or nil when there are none
+func (s *Stat) Max() *int {
+	if s.count == 0 {
+		return nil
+	}
+	v := s.max
+	return &v
+}
@@ -0,0 +1,328 @@
+// Package streams holds the columnar activity time series shared by the
+// garmin client and the fit, gpx and tcx file packages. It has no dependency
+// on either side, so each file format converts to and from ActivityStreams
+// without importing the API client.
+package streams
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// Canonical column names. Metric descriptors returned by the activity details
+// endpoint are mapped onto these names; unknown metrics keep their Garmin key.
+const (
+	ElapsedDuration = "elapsed_duration"
+	MovingDuration  = "moving_duration"
+	Distance        = "distance"
+	HeartRate       = "heart_rate"
+	Speed           = "speed"
+	Power           = "power"
+	Cadence         = "cadence"
+	Altitude        = "altitude"
+	Latitude        = "latitude"
+	Longitude       = "longitude"
+	Temperature     = "temperature"
+	VerticalSpeed   = "vertical_speed"
+	Respiration     = "respiration_rate"
+)
+
+// PolylinePoint represents a single point of the activity GPS track
+type PolylinePoint struct {
+	Lat      float64 `json:"lat"`
+	Lon      float64 `json:"lon"`
+	Altitude float64 `json:"altitude"`
+	Time     int64   `json:"time"`
+	Speed    float64 `json:"speed"`
+	Distance float64 `json:"distanceInMeters"`
+	Valid    bool    `json:"valid"`
+}
+
+// Timestamp converts the point time to time.Time
+func (p PolylinePoint) Timestamp() time.Time {
+	return time.UnixMilli(p.Time).UTC()
+}
+
+// GeoBounds represents the geographic bounding box of an activity
+type GeoBounds struct {
+	MinLat float64 `json:"minLat"`
+	MaxLat float64 `json:"maxLat"`
+	MinLon float64 `json:"minLon"`
+	MaxLon float64 `json:"maxLon"`
+}
+
+// Contains reports whether the coordinate lies inside the bounding box
+func (b GeoBounds) Contains(lat, lon float64) bool {
+	return lat >= b.MinLat && lat <= b.MaxLat && lon >= b.MinLon && lon <= b.MaxLon
+}
+
+// StreamColumn is a single named, unit-aware series of an ActivityStreams.
+// Samples that were not recorded are stored as NaN.
+type StreamColumn struct {
+	Name   string    `json:"name"`
+	Key    string    `json:"key"`
+	Unit   string    `json:"unit"`
+	Values []float64 `json:"values"`
+}
+
+// ActivityStreams is a columnar representation of an activity's time series.
+// Every column holds exactly one value per entry in Timestamps.
+type ActivityStreams struct {
+	ActivityID int64                    `json:"activityId"`
+	Timestamps []time.Time              `json:"timestamps"`
+	Columns    map[string]*StreamColumn `json:"columns"`
+	Polyline   []PolylinePoint          `json:"polyline,omitempty"`
+	Bounds     *GeoBounds               `json:"bounds,omitempty"`
+}
+
+// New creates an empty stream set for the given activity
+func New(activityID int64) *ActivityStreams {
+	return &ActivityStreams{
+		ActivityID: activityID,
+		Columns:    make(map[string]*StreamColumn),
+	}
+}
+
+// Len returns the number of samples
+func (s *ActivityStreams) Len() int {
+	return len(s.Timestamps)
+}
+
+// Names returns the sorted names of all available columns
+func (s *ActivityStreams) Names() []string {
+	names := make([]string, 0, len(s.Columns))
+	for name := range s.Columns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Has reports whether a column with the given name exists
+func (s *ActivityStreams) Has(name string) bool {
+	_, ok := s.Columns[name]
+	return ok
+}
+
+// Column returns the named column, or nil if it is not present
+func (s *ActivityStreams) Column(name string) *StreamColumn {
+	return s.Columns[name]
+}
+
+// Values returns the samples of the named column, or nil if it is not present
+func (s *ActivityStreams) Values(name string) []float64 {
+	if col, ok := s.Columns[name]; ok {
+		return col.Values
+	}
+	return nil
+}
+
+// SetColumn adds or replaces a column. The column must have one value per timestamp.
+func (s *ActivityStreams) SetColumn(col StreamColumn) error {
+	if len(col.Values) != len(s.Timestamps) {
+		return &errors.ValidationError{
+			GarthError: errors.GarthError{
+				Message: fmt.Sprintf("column has %d values, expected %d", len(col.Values), len(s.Timestamps)),
+			},
+			Field: col.Name,
+		}
+	}
+	if s.Columns == nil {
+		s.Columns = make(map[string]*StreamColumn)
+	}
+	s.Columns[col.Name] = &col
+	return nil
+}
+
+// StartTime returns the time of the first sample
+func (s *ActivityStreams) StartTime() time.Time {
+	if len(s.Timestamps) == 0 {
+		return time.Time{}
+	}
+	return s.Timestamps[0]
+}
+
+// Duration returns the time between the first and last sample
+func (s *ActivityStreams) Duration() time.Duration {
+	if len(s.Timestamps) == 0 {
+		return 0
+	}
+	return s.Timestamps[len(s.Timestamps)-1].Sub(s.Timestamps[0])
+}
+
+// Resample returns a copy of the streams sampled at a fixed interval, starting
+// at the first sample. Values are linearly interpolated between neighbouring
+// samples; if either neighbour is missing the result is NaN.
+func (s *ActivityStreams) Resample(interval time.Duration) (*ActivityStreams, error) {
+	if interval <= 0 {
+		return nil, &errors.ValidationError{
+			GarthError: errors.GarthError{Message: "interval must be positive"},
+			Field:      "interval",
+		}
+	}
+
+	out := s.emptyCopy()
+	out.Polyline = s.Polyline
+	out.Bounds = s.Bounds
+	if len(s.Timestamps) == 0 {
+		return out, nil
+	}
+
+	start := s.Timestamps[0]
+	end := s.Timestamps[len(s.Timestamps)-1]
+	for t := start; !t.After(end); t = t.Add(interval) {
+		out.Timestamps = append(out.Timestamps, t)
+	}
+
+	for name, col := range s.Columns {
+		values := make([]float64, len(out.Timestamps))
+		j := 0
+		for i, t := range out.Timestamps {
+			for j < len(s.Timestamps)-1 && s.Timestamps[j+1].Before(t) {
+				j++
+			}
+			values[i] = interpolate(s.Timestamps, col.Values, j, t)
+		}
+		out.Columns[name] = &StreamColumn{Name: col.Name, Key: col.Key, Unit: col.Unit, Values: values}
+	}
+
+	return out, nil
+}
+
+// interpolate returns the value at t given that t lies at or after times[j]
+func interpolate(times []time.Time, values []float64, j int, t time.Time) float64 {
+	if times[j].Equal(t) || j == len(times)-1 {
+		return values[j]
+	}
+	if times[j+1].Equal(t) {
+		return values[j+1]
+	}
+	span := times[j+1].Sub(times[j])
+	if span <= 0 {
+		return values[j]
+	}
+	frac := float64(t.Sub(times[j])) / float64(span)
+	return values[j] + (values[j+1]-values[j])*frac
+}
+
+// SliceByTime returns the samples whose offset from the first sample lies
+// within [from, to]
+func (s *ActivityStreams) SliceByTime(from, to time.Duration) *ActivityStreams {
+	if len(s.Timestamps) == 0 {
+		return s.emptyCopy()
+	}
+	start := s.Timestamps[0]
+	return s.sliceWhere(func(i int) bool {
+		offset := s.Timestamps[i].Sub(start)
+		return offset >= from && offset <= to
+	})
+}
+
+// SliceByDistance returns the samples whose cumulative distance in meters lies
+// within [from, to]. It requires a distance column.
+func (s *ActivityStreams) SliceByDistance(from, to float64) (*ActivityStreams, error) {
+	distance := s.Values(Distance)
+	if distance == nil {
+		return nil, &errors.ValidationError{
+			GarthError: errors.GarthError{Message: "streams have no distance column"},
+			Field:      Distance,
+		}
+	}
+	return s.sliceWhere(func(i int) bool {
+		return !math.IsNaN(distance[i]) && distance[i] >= from && distance[i] <= to
+	}), nil
+}
+
+// sliceWhere copies every sample for which keep returns true. The polyline is
+// trimmed to the time span of the kept samples.
+func (s *ActivityStreams) sliceWhere(keep func(i int) bool) *ActivityStreams {
+	out := s.emptyCopy()
+	var indexes []int
+	for i := range s.Timestamps {
+		if keep(i) {
+			indexes = append(indexes, i)
+			out.Timestamps = append(out.Timestamps, s.Timestamps[i])
+		}
+	}
+
+	for name, col := range s.Columns {
+		values := make([]float64, len(indexes))
+		for k, i := range indexes {
+			values[k] = col.Values[i]
+		}
+		out.Columns[name] = &StreamColumn{Name: col.Name, Key: col.Key, Unit: col.Unit, Values: values}
+	}
+
+	if len(out.Timestamps) > 0 {
+		first := out.Timestamps[0].UnixMilli()
+		last := out.Timestamps[len(out.Timestamps)-1].UnixMilli()
+		for _, p := range s.Polyline {
+			if p.Time >= first && p.Time <= last {
+				out.Polyline = append(out.Polyline, p)
+			}
+		}
+		out.Bounds = polylineBounds(out.Polyline)
+	}
+
+	return out
+}
+
+// emptyCopy returns a stream set with the same identity and no samples
+func (s *ActivityStreams) emptyCopy() *ActivityStreams {
+	return New(s.ActivityID)
+}
+
+// BuildPolyline replaces the polyline and bounding box with the samples that
+// carry both a latitude and a longitude
+func (s *ActivityStreams) BuildPolyline() {
+	lat, lon := s.Values(Latitude), s.Values(Longitude)
+	altitude, speed, distance := s.Values(Altitude), s.Values(Speed), s.Values(Distance)
+	sample := func(values []float64, i int) float64 {
+		if values == nil || math.IsNaN(values[i]) {
+			return 0
+		}
+		return values[i]
+	}
+
+	s.Polyline = nil
+	if lat != nil && lon != nil {
+		for i, t := range s.Timestamps {
+			if math.IsNaN(lat[i]) || math.IsNaN(lon[i]) {
+				continue
+			}
+			s.Polyline = append(s.Polyline, PolylinePoint{
+				Lat:      lat[i],
+				Lon:      lon[i],
+				Altitude: sample(altitude, i),
+				Time:     t.UnixMilli(),
+				Speed:    sample(speed, i),
+				Distance: sample(distance, i),
+				Valid:    true,
+			})
+		}
+	}
+	s.Bounds = polylineBounds(s.Polyline)
+}
+
+// polylineBounds computes the bounding box of the valid polyline points
+func polylineBounds(points []PolylinePoint) *GeoBounds {
+	var bounds *GeoBounds
+	for _, p := range points {
+		if !p.Valid {
+			continue
+		}
+		if bounds == nil {
+			bounds = &GeoBounds{MinLat: p.Lat, MaxLat: p.Lat, MinLon: p.Lon, MaxLon: p.Lon}
+			continue
+		}
+		bounds.MinLat = math.Min(bounds.MinLat, p.Lat)
+		bounds.MaxLat = math.Max(bounds.MaxLat, p.Lat)
+		bounds.MinLon = math.Min(bounds.MinLon, p.Lon)
+		bounds.MaxLon = math.Max(bounds.MaxLon, p.Lon)
+	}
+	return bounds
+}
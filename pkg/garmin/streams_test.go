@@ -0,0 +1,120 @@
+package garmin_test
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient points a garmin.Client at a local test server
+func newTestClient(t *testing.T, handler http.Handler) *garmin.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, _ := url.Parse(server.URL)
+	c, err := garmin.NewClient(u.Host)
+	require.NoError(t, err)
+	c.Client.HTTPClient = server.Client()
+	c.Client.AuthToken = "Bearer testtoken"
+	c.Client.Username = "testuser"
+	return c
+}
+
+const activityDetailsBody = `{
+	"activityId": 42,
+	"metricDescriptors": [
+		{"metricsIndex": 0, "key": "directTimestamp", "unit": {"id": 120, "key": "gmt", "factor": 0}},
+		{"metricsIndex": 1, "key": "directHeartRate", "unit": {"id": 100, "key": "bpm", "factor": 1}},
+		{"metricsIndex": 2, "key": "sumDistance", "unit": {"id": 1, "key": "meter", "factor": 100}},
+		{"metricsIndex": 3, "key": "directSpeed", "unit": {"id": 20, "key": "mps", "factor": 0.1}}
+	],
+	"activityDetailMetrics": [
+		{"metrics": [1700000000000, 100, 0, 2.0]},
+		{"metrics": [1700000010000, 120, 20, null]},
+		{"metrics": [1700000020000, 140, 40, 4.0]}
+	],
+	"geoPolylineDTO": {
+		"minLat": 1.0, "maxLat": 2.0, "minLon": 3.0, "maxLon": 4.0,
+		"polyline": [
+			{"lat": 1.0, "lon": 3.0, "time": 1700000000000, "valid": true},
+			{"lat": 2.0, "lon": 4.0, "time": 1700000020000, "valid": true}
+		]
+	}
+}`
+
+func TestGetActivityStreams(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/activity-service/activity/42/details", r.URL.Path)
+		assert.Equal(t, "2000", r.URL.Query().Get("maxChartSize"))
+		w.Write([]byte(activityDetailsBody))
+	}))
+
+	streams, err := c.GetActivityStreams(context.Background(), 42, garmin.StreamsOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(42), streams.ActivityID)
+	assert.Equal(t, 3, streams.Len())
+	assert.Equal(t, []string{garmin.StreamDistance, garmin.StreamHeartRate, garmin.StreamSpeed}, streams.Names())
+	assert.Equal(t, []float64{100, 120, 140}, streams.Values(garmin.StreamHeartRate))
+	assert.Equal(t, "bpm", streams.Column(garmin.StreamHeartRate).Unit)
+	assert.True(t, math.IsNaN(streams.Values(garmin.StreamSpeed)[1]))
+	assert.Equal(t, 20*time.Second, streams.Duration())
+	require.NotNil(t, streams.Bounds)
+	assert.True(t, streams.Bounds.Contains(1.5, 3.5))
+	assert.Len(t, streams.Polyline, 2)
+}
+
+func TestActivityStreams_ResampleAndSlice(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(activityDetailsBody))
+	}))
+
+	streams, err := c.GetActivityStreams(context.Background(), 42, garmin.StreamsOptions{})
+	require.NoError(t, err)
+
+	resampled, err := streams.Resample(5 * time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, 5, resampled.Len())
+	assert.Equal(t, []float64{100, 110, 120, 130, 140}, resampled.Values(garmin.StreamHeartRate))
+	assert.True(t, math.IsNaN(resampled.Values(garmin.StreamSpeed)[1]))
+
+	_, err = streams.Resample(0)
+	assert.Error(t, err)
+
+	byTime := streams.SliceByTime(5*time.Second, 20*time.Second)
+	assert.Equal(t, []float64{120, 140}, byTime.Values(garmin.StreamHeartRate))
+	assert.Len(t, byTime.Polyline, 1)
+
+	byDistance, err := streams.SliceByDistance(0, 25)
+	require.NoError(t, err)
+	assert.Equal(t, []float64{100, 120}, byDistance.Values(garmin.StreamHeartRate))
+}
+
+func TestActivityStreams_AddColumn(t *testing.T) {
+	s := garmin.NewActivityStreams(42)
+	start := time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC)
+	s.Timestamps = []time.Time{start, start.Add(time.Second), start.Add(2 * time.Second)}
+
+	s.AddColumn(garmin.StreamHeartRate, "hr", "bpm", func(i int) *float64 { return nil })
+	assert.False(t, s.Has(garmin.StreamHeartRate), "columns without samples are left out")
+
+	watts := 250.6
+	power := []*float64{nil, &watts, nil}
+	s.AddColumn(garmin.StreamPower, "watts", "watt", func(i int) *float64 { return power[i] })
+	require.True(t, s.Has(garmin.StreamPower))
+	assert.True(t, math.IsNaN(s.Values(garmin.StreamPower)[0]))
+	assert.Nil(t, s.Float(garmin.StreamPower, 0))
+	assert.Equal(t, 250.6, *s.Float(garmin.StreamPower, 1))
+	assert.Equal(t, 251, *s.Int(garmin.StreamPower, 1))
+	assert.Nil(t, s.Int(garmin.StreamSpeed, 1))
+}
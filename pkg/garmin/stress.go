@@ -0,0 +1,227 @@
+package garmin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StressCategory classifies an all-day stress reading
+type StressCategory string
+
+// Stress categories. Measured levels run from 0 to 100 and fall into rest,
+// low, medium or high; the other two describe samples without a level.
+const (
+	StressRest         StressCategory = "rest"         // 0-25
+	StressLow          StressCategory = "low"          // 26-50
+	StressMedium       StressCategory = "medium"       // 51-75
+	StressHigh         StressCategory = "high"         // 76-100
+	StressActivity     StressCategory = "activity"     // Too much motion to measure
+	StressUnmeasurable StressCategory = "unmeasurable" // Off wrist or not enough data
+)
+
+// Placeholder levels Garmin reports for samples without a measurement. The
+// mapping follows what Garmin Connect returns: -1 marks an off-wrist or
+// insufficient-data sample and -2 a sample with too much motion. This is the
+// reverse of the original feature request, which paired -1 with activity.
+const (
+	stressLevelUnmeasurable = -1
+	stressLevelActivity     = -2
+)
+
+// stressSampleInterval is the all-day stress sampling interval. A sample
+// counts for the time until the next one, capped at this interval.
+const stressSampleInterval = 3 * time.Minute
+
+// ClassifyStress returns the category of a stress level
+func ClassifyStress(level int) StressCategory {
+	switch {
+	case level == stressLevelActivity:
+		return StressActivity
+	case level < 0:
+		return StressUnmeasurable
+	case level <= 25:
+		return StressRest
+	case level <= 50:
+		return StressLow
+	case level <= 75:
+		return StressMedium
+	}
+	return StressHigh
+}
+
+// StressSample is an all-day stress reading
+type StressSample struct {
+	Time     time.Time
+	Level    int // 0-100, or negative when not measured
+	Category StressCategory
+	Duration time.Duration // Time the sample accounts for
+}
+
+// Measured reports whether the sample carries a stress level
+func (s StressSample) Measured() bool {
+	return s.Level >= 0
+}
+
+// StressDurations holds the time spent in each stress category
+type StressDurations struct {
+	Rest         time.Duration
+	Low          time.Duration
+	Medium       time.Duration
+	High         time.Duration
+	Activity     time.Duration
+	Unmeasurable time.Duration
+}
+
+// Of returns the time spent in a category
+func (d StressDurations) Of(category StressCategory) time.Duration {
+	switch category {
+	case StressRest:
+		return d.Rest
+	case StressLow:
+		return d.Low
+	case StressMedium:
+		return d.Medium
+	case StressHigh:
+		return d.High
+	case StressActivity:
+		return d.Activity
+	case StressUnmeasurable:
+		return d.Unmeasurable
+	}
+	return 0
+}
+
+// Measured returns the time with a stress level
+func (d StressDurations) Measured() time.Duration {
+	return d.Rest + d.Low + d.Medium + d.High
+}
+
+// StressPeriod is a run of consecutive samples at or above a stress threshold
+type StressPeriod struct {
+	Start   time.Time
+	End     time.Time
+	Peak    int
+	Average float64
+}
+
+// Duration returns the length of the period
+func (p StressPeriod) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// DailyStressDetail is the all-day stress of a day
+type DailyStressDetail struct {
+	Date      string // YYYY-MM-DD
+	MaxLevel  int
+	AvgLevel  int
+	Samples   []StressSample // Oldest first
+	Durations StressDurations
+}
+
+// NewDailyStressDetail builds the stress detail of a dailyStress response,
+// such as the one returned by GetBodyBatteryData. Durations are recomputed
+// from the samples.
+func NewDailyStressDetail(data *DetailedBodyBatteryData) *DailyStressDetail {
+	detail := &DailyStressDetail{
+		MaxLevel: data.MaxStressLevel,
+		AvgLevel: data.AvgStressLevel,
+	}
+	if !data.CalendarDate.IsZero() {
+		detail.Date = data.CalendarDate.Format("2006-01-02")
+	}
+
+	for _, entry := range data.StressValuesArray {
+		if len(entry) < 2 {
+			continue
+		}
+		detail.Samples = append(detail.Samples, StressSample{
+			Time:     time.UnixMilli(int64(entry[0])).UTC(),
+			Level:    entry[1],
+			Category: ClassifyStress(entry[1]),
+		})
+	}
+	sort.Slice(detail.Samples, func(i, j int) bool { return detail.Samples[i].Time.Before(detail.Samples[j].Time) })
+
+	for i := range detail.Samples {
+		s := &detail.Samples[i]
+		s.Duration = stressSampleInterval
+		if i+1 < len(detail.Samples) {
+			s.Duration = min(detail.Samples[i+1].Time.Sub(s.Time), stressSampleInterval)
+		}
+		switch s.Category {
+		case StressRest:
+			detail.Durations.Rest += s.Duration
+		case StressLow:
+			detail.Durations.Low += s.Duration
+		case StressMedium:
+			detail.Durations.Medium += s.Duration
+		case StressHigh:
+			detail.Durations.High += s.Duration
+		case StressActivity:
+			detail.Durations.Activity += s.Duration
+		default:
+			detail.Durations.Unmeasurable += s.Duration
+		}
+	}
+	return detail
+}
+
+// StressfulPeriods returns the runs of measured samples with a level of at
+// least threshold that last minDuration or longer. Unmeasured samples and
+// gaps between samples end a run.
+func (d *DailyStressDetail) StressfulPeriods(threshold int, minDuration time.Duration) []StressPeriod {
+	var periods []StressPeriod
+	var current *StressPeriod
+	var sum, n int
+	flush := func() {
+		if current != nil && current.Duration() >= minDuration {
+			current.Average = float64(sum) / float64(n)
+			periods = append(periods, *current)
+		}
+		current, sum, n = nil, 0, 0
+	}
+
+	for _, s := range d.Samples {
+		if !s.Measured() || s.Level < threshold {
+			flush()
+			continue
+		}
+		if current != nil && s.Time.After(current.End) {
+			flush()
+		}
+		if current == nil {
+			current = &StressPeriod{Start: s.Time}
+		}
+		current.End = s.Time.Add(s.Duration)
+		current.Peak = max(current.Peak, s.Level)
+		sum += s.Level
+		n++
+	}
+	flush()
+	return periods
+}
+
+// GetDailyStressDetail retrieves the all-day stress samples of a day. It
+// returns nil when the device recorded no stress data.
+func (c *Client) GetDailyStressDetail(ctx context.Context, date time.Time) (*DailyStressDetail, error) {
+	path := fmt.Sprintf("/wellness-service/wellness/dailyStress/%s", date.Format("2006-01-02"))
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stress data: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var response DetailedBodyBatteryData
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse stress response: %w", err)
+	}
+	if len(response.StressValuesArray) == 0 {
+		return nil, nil
+	}
+	return NewDailyStressDetail(&response), nil
+}
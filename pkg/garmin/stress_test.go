@@ -0,0 +1,65 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyStress(t *testing.T) {
+	assert.Equal(t, garmin.StressRest, garmin.ClassifyStress(25))
+	assert.Equal(t, garmin.StressLow, garmin.ClassifyStress(26))
+	assert.Equal(t, garmin.StressMedium, garmin.ClassifyStress(75))
+	assert.Equal(t, garmin.StressHigh, garmin.ClassifyStress(76))
+	// Garmin reports -1 for off-wrist or missing data and -2 for motion; the
+	// original request had these reversed
+	assert.Equal(t, garmin.StressUnmeasurable, garmin.ClassifyStress(-1), "-1 is off wrist or not enough data, not activity")
+	assert.Equal(t, garmin.StressActivity, garmin.ClassifyStress(-2), "-2 is too much motion, not unmeasurable")
+}
+
+func TestGetDailyStressDetail(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wellness-service/wellness/dailyStress/2025-03-01" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		// Samples every 3 minutes from 08:00 UTC, with a 30 minute gap before the last
+		w.Write([]byte(`{"calendarDate": "2025-03-01", "startTimestampGMT": "2025-03-01T05:00:00.0",
+			"maxStressLevel": 88, "avgStressLevel": 41,
+			"stressValuesArray": [
+				[1740816000000, 20], [1740816180000, 60], [1740816360000, 80], [1740816540000, 88],
+				[1740816720000, -2], [1740816900000, -1], [1740817080000, 70], [1740818880000, 77]]}`))
+	}))
+
+	detail, err := c.GetDailyStressDetail(context.Background(), time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-01", detail.Date)
+	assert.Equal(t, 88, detail.MaxLevel)
+	require.Len(t, detail.Samples, 8)
+	assert.Equal(t, time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC), detail.Samples[0].Time)
+	assert.Equal(t, garmin.StressActivity, detail.Samples[4].Category)
+	assert.False(t, detail.Samples[5].Measured())
+
+	d := detail.Durations
+	assert.Equal(t, 3*time.Minute, d.Rest)
+	assert.Equal(t, 6*time.Minute, d.Medium, "gaps count for one sample interval at most")
+	assert.Equal(t, 9*time.Minute, d.Of(garmin.StressHigh))
+	assert.Equal(t, 3*time.Minute, d.Activity)
+	assert.Equal(t, 3*time.Minute, d.Unmeasurable)
+	assert.Equal(t, 18*time.Minute, d.Measured())
+
+	periods := detail.StressfulPeriods(60, 0)
+	require.Len(t, periods, 3)
+	assert.Equal(t, time.Date(2025, 3, 1, 8, 3, 0, 0, time.UTC), periods[0].Start)
+	assert.Equal(t, 9*time.Minute, periods[0].Duration())
+	assert.Equal(t, 88, periods[0].Peak)
+	assert.InDelta(t, 76.0, periods[0].Average, 1e-9)
+	assert.Len(t, detail.StressfulPeriods(60, 5*time.Minute), 1)
+	assert.Empty(t, detail.StressfulPeriods(90, 0))
+}
@@ -0,0 +1,6 @@
+// Package tcx reads and writes Garmin Training Center (TCX) files: activities,
+// laps and trackpoints together with the ActivityExtension speed, power and run
+// cadence values. Activities convert to and from streams.ActivityStreams, which
+// is the bridge to the gpx and fit packages; written files can be passed to
+// garmin.Client.Upload.
+package tcx
@@ -0,0 +1,142 @@
+package tcx
+
+import (
+	"math"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+)
+
+// Trackpoints returns the trackpoints of every activity and lap in order
+func (f *File) Trackpoints() []Trackpoint {
+	var points []Trackpoint
+	for _, a := range f.Activities {
+		for _, lap := range a.Laps {
+			points = append(points, lap.Track...)
+		}
+	}
+	return points
+}
+
+// ActivityStreams converts the trackpoints into the columnar shape returned by
+// garmin.Client.GetActivityStreams. Cadence falls back to the RunCadence
+// extension; speed and power come from the TPX extension.
+func (f *File) ActivityStreams() *streams.ActivityStreams {
+	result := streams.New(0)
+	points := f.Trackpoints()
+	for _, p := range points {
+		result.Timestamps = append(result.Timestamps, p.Time)
+	}
+
+	ext := func(i int) TrackpointExtension {
+		if points[i].Extensions != nil {
+			return *points[i].Extensions
+		}
+		return TrackpointExtension{}
+	}
+	result.AddColumn(streams.Latitude, "LatitudeDegrees", "dd", func(i int) *float64 {
+		if points[i].Position == nil {
+			return nil
+		}
+		return &points[i].Position.Lat
+	})
+	result.AddColumn(streams.Longitude, "LongitudeDegrees", "dd", func(i int) *float64 {
+		if points[i].Position == nil {
+			return nil
+		}
+		return &points[i].Position.Lon
+	})
+	result.AddColumn(streams.Altitude, "AltitudeMeters", "meter", func(i int) *float64 { return points[i].AltitudeMeters })
+	result.AddColumn(streams.Distance, "DistanceMeters", "meter", func(i int) *float64 { return points[i].DistanceMeters })
+	result.AddColumn(streams.HeartRate, "HeartRateBpm", "bpm", func(i int) *float64 {
+		if points[i].HeartRate == nil {
+			return nil
+		}
+		return streams.IntToFloat(&points[i].HeartRate.Value)
+	})
+	result.AddColumn(streams.Cadence, "Cadence", "rpm", func(i int) *float64 {
+		if points[i].Cadence != nil {
+			return streams.IntToFloat(points[i].Cadence)
+		}
+		return streams.IntToFloat(ext(i).RunCadence)
+	})
+	result.AddColumn(streams.Speed, "Speed", "mps", func(i int) *float64 { return ext(i).Speed })
+	result.AddColumn(streams.Power, "Watts", "watt", func(i int) *float64 { return streams.IntToFloat(ext(i).Watts) })
+
+	result.BuildPolyline()
+	return result
+}
+
+// FromActivityStreams builds a single-lap activity from activity streams. For
+// running, cadence is written to the RunCadence extension as Garmin devices do.
+func FromActivityStreams(s *streams.ActivityStreams, sport string) *File {
+	lap := Lap{
+		StartTime:        s.StartTime(),
+		TotalTimeSeconds: s.Duration().Seconds(),
+		Intensity:        "Active",
+		TriggerMethod:    "Manual",
+	}
+	var hr, cadence, power streams.Stat
+	var maxSpeed *float64
+	for i, t := range s.Timestamps {
+		tp := Trackpoint{
+			Time:           t,
+			AltitudeMeters: s.Float(streams.Altitude, i),
+			DistanceMeters: s.Float(streams.Distance, i),
+		}
+		lat, lon := s.Float(streams.Latitude, i), s.Float(streams.Longitude, i)
+		if lat != nil && lon != nil {
+			tp.Position = &Position{Lat: *lat, Lon: *lon}
+		}
+		if v := s.Int(streams.HeartRate, i); v != nil {
+			tp.HeartRate = &HeartRate{Value: *v}
+			hr.Add(v)
+		}
+		ext := TrackpointExtension{Speed: s.Float(streams.Speed, i), Watts: s.Int(streams.Power, i)}
+		if v := s.Int(streams.Cadence, i); v != nil {
+			if sport == SportRunning {
+				ext.RunCadence = v
+			} else {
+				tp.Cadence = v
+			}
+			cadence.Add(v)
+		}
+		if ext != (TrackpointExtension{}) {
+			tp.Extensions = &ext
+		}
+		power.Add(ext.Watts)
+		if ext.Speed != nil && (maxSpeed == nil || *ext.Speed > *maxSpeed) {
+			maxSpeed = ext.Speed
+		}
+		if tp.DistanceMeters != nil {
+			lap.DistanceMeters = math.Max(lap.DistanceMeters, *tp.DistanceMeters)
+		}
+		lap.Track = append(lap.Track, tp)
+	}
+
+	lap.MaximumSpeed = maxSpeed
+	if avg := hr.Avg(); avg != nil {
+		lap.AvgHeartRate = &HeartRate{Value: *avg}
+		lap.MaxHeartRate = &HeartRate{Value: *hr.Max()}
+	}
+	lx := LapExtension{}
+	if lap.TotalTimeSeconds > 0 && lap.DistanceMeters > 0 {
+		avg := lap.DistanceMeters / lap.TotalTimeSeconds
+		lx.AvgSpeed = &avg
+	}
+	if sport == SportRunning {
+		lx.AvgRunCadence, lx.MaxRunCadence = cadence.Avg(), cadence.Max()
+	} else {
+		lap.Cadence = cadence.Avg()
+	}
+	lx.AvgWatts, lx.MaxWatts = power.Avg(), power.Max()
+	if lx != (LapExtension{}) {
+		lap.Extensions = &lx
+	}
+
+	return &File{Activities: []Activity{{
+		Sport: sport,
+		ID:    s.StartTime().UTC().Format(time.RFC3339),
+		Laps:  []Lap{lap},
+	}}}
+}
@@ -0,0 +1,213 @@
+package tcx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// XML namespaces written by the encoder
+const (
+	Namespace                  = "http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+	ActivityExtensionNamespace = "http://www.garmin.com/xmlschemas/ActivityExtension/v2"
+)
+
+// Sport values of the Activity element
+const (
+	SportRunning = "Running"
+	SportBiking  = "Biking"
+	SportOther   = "Other"
+)
+
+// File is a TrainingCenterDatabase document
+type File struct {
+	XMLName    xml.Name   `xml:"TrainingCenterDatabase"`
+	Xmlns      string     `xml:"xmlns,attr,omitempty"`
+	Activities []Activity `xml:"Activities>Activity"`
+}
+
+// Activity is a recorded activity made of one or more laps
+type Activity struct {
+	Sport   string   `xml:"Sport,attr"`
+	ID      string   `xml:"Id"` // the start time, by convention
+	Laps    []Lap    `xml:"Lap"`
+	Notes   string   `xml:"Notes,omitempty"`
+	Creator *Creator `xml:"Creator,omitempty"`
+}
+
+// Creator names the device that recorded the activity
+type Creator struct {
+	Type      string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr,omitempty"`
+	Name      string `xml:"Name"`
+	UnitID    uint32 `xml:"UnitId,omitempty"`
+	ProductID int    `xml:"ProductID,omitempty"`
+}
+
+// Lap is a lap summary and its track
+type Lap struct {
+	StartTime        time.Time     `xml:"StartTime,attr"`
+	TotalTimeSeconds float64       `xml:"TotalTimeSeconds"`
+	DistanceMeters   float64       `xml:"DistanceMeters"`
+	MaximumSpeed     *float64      `xml:"MaximumSpeed,omitempty"` // m/s
+	Calories         int           `xml:"Calories"`
+	AvgHeartRate     *HeartRate    `xml:"AverageHeartRateBpm,omitempty"`
+	MaxHeartRate     *HeartRate    `xml:"MaximumHeartRateBpm,omitempty"`
+	Intensity        string        `xml:"Intensity"`
+	Cadence          *int          `xml:"Cadence,omitempty"`
+	TriggerMethod    string        `xml:"TriggerMethod"`
+	Track            []Trackpoint  `xml:"Track>Trackpoint"`
+	Notes            string        `xml:"Notes,omitempty"`
+	Extensions       *LapExtension `xml:"Extensions>LX,omitempty"`
+}
+
+// HeartRate wraps a heart rate value in bpm
+type HeartRate struct {
+	Value int `xml:"Value"`
+}
+
+// LapExtension is the ActivityExtension LX element
+type LapExtension struct {
+	Xmlns         string   `xml:"xmlns,attr,omitempty"`
+	AvgSpeed      *float64 `xml:"AvgSpeed,omitempty"` // m/s
+	AvgRunCadence *int     `xml:"AvgRunCadence,omitempty"`
+	MaxRunCadence *int     `xml:"MaxRunCadence,omitempty"`
+	AvgWatts      *int     `xml:"AvgWatts,omitempty"`
+	MaxWatts      *int     `xml:"MaxWatts,omitempty"`
+}
+
+// Trackpoint is a single sample of a lap track
+type Trackpoint struct {
+	Time           time.Time            `xml:"Time"`
+	Position       *Position            `xml:"Position,omitempty"`
+	AltitudeMeters *float64             `xml:"AltitudeMeters,omitempty"`
+	DistanceMeters *float64             `xml:"DistanceMeters,omitempty"`
+	HeartRate      *HeartRate           `xml:"HeartRateBpm,omitempty"`
+	Cadence        *int                 `xml:"Cadence,omitempty"`
+	Extensions     *TrackpointExtension `xml:"Extensions>TPX,omitempty"`
+}
+
+// Position is a coordinate in decimal degrees
+type Position struct {
+	Lat float64 `xml:"LatitudeDegrees"`
+	Lon float64 `xml:"LongitudeDegrees"`
+}
+
+// TrackpointExtension is the ActivityExtension TPX element
+type TrackpointExtension struct {
+	Xmlns      string   `xml:"xmlns,attr,omitempty"`
+	Speed      *float64 `xml:"Speed,omitempty"` // m/s
+	RunCadence *int     `xml:"RunCadence,omitempty"`
+	Watts      *int     `xml:"Watts,omitempty"`
+}
+
+// Parse reads a TCX document. Extension elements are matched by local name.
+func Parse(r io.Reader) (*File, error) {
+	var f File
+	if err := xml.NewDecoder(r).Decode(&f); err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to parse TCX",
+				Cause:   err,
+			},
+		}
+	}
+	return &f, nil
+}
+
+// ParseBytes reads a TCX document from memory
+func ParseBytes(data []byte) (*File, error) {
+	return Parse(bytes.NewReader(data))
+}
+
+// ParseFile reads a TCX document from disk
+func ParseFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to read TCX file",
+				Cause:   err,
+			},
+		}
+	}
+	return ParseBytes(data)
+}
+
+// Write encodes the document as indented TCX
+func (f *File) Write(w io.Writer) error {
+	out := *f
+	out.Xmlns = Namespace
+	out.Activities = make([]Activity, len(f.Activities))
+	for i, a := range f.Activities {
+		out.Activities[i] = a
+		out.Activities[i].Laps = withNamespace(a.Laps)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return writeError(err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return writeError(err)
+	}
+	return nil
+}
+
+// Bytes returns the encoded document
+func (f *File) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteFile encodes the document to path, ready for Client.Upload
+func (f *File) WriteFile(path string) error {
+	data, err := f.Bytes()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return writeError(err)
+	}
+	return nil
+}
+
+// withNamespace copies laps, declaring the ActivityExtension namespace on each
+// LX and TPX element so the unprefixed elements resolve correctly
+func withNamespace(laps []Lap) []Lap {
+	out := make([]Lap, len(laps))
+	for i, lap := range laps {
+		out[i] = lap
+		if lap.Extensions != nil {
+			ext := *lap.Extensions
+			ext.Xmlns = ActivityExtensionNamespace
+			out[i].Extensions = &ext
+		}
+		out[i].Track = make([]Trackpoint, len(lap.Track))
+		for j, tp := range lap.Track {
+			out[i].Track[j] = tp
+			if tp.Extensions != nil {
+				ext := *tp.Extensions
+				ext.Xmlns = ActivityExtensionNamespace
+				out[i].Track[j].Extensions = &ext
+			}
+		}
+	}
+	return out
+}
+
+func writeError(err error) error {
+	return &errors.IOError{
+		GarthError: errors.GarthError{
+			Message: "Failed to write TCX",
+			Cause:   err,
+		},
+	}
+}
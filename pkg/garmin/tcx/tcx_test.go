@@ -0,0 +1,95 @@
+package tcx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/streams"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const garminExport = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2"
+  xmlns:ns3="http://www.garmin.com/xmlschemas/ActivityExtension/v2">
+  <Activities>
+    <Activity Sport="Running">
+      <Id>2025-03-02T07:00:00.000Z</Id>
+      <Lap StartTime="2025-03-02T07:00:00.000Z">
+        <TotalTimeSeconds>10.0</TotalTimeSeconds>
+        <DistanceMeters>30.0</DistanceMeters>
+        <Calories>2</Calories>
+        <AverageHeartRateBpm><Value>125</Value></AverageHeartRateBpm>
+        <Intensity>Active</Intensity>
+        <TriggerMethod>Manual</TriggerMethod>
+        <Track>
+          <Trackpoint>
+            <Time>2025-03-02T07:00:00.000Z</Time>
+            <Position><LatitudeDegrees>45.5</LatitudeDegrees><LongitudeDegrees>-122.25</LongitudeDegrees></Position>
+            <AltitudeMeters>100.0</AltitudeMeters>
+            <DistanceMeters>0.0</DistanceMeters>
+            <HeartRateBpm><Value>120</Value></HeartRateBpm>
+            <Extensions><ns3:TPX><ns3:Speed>3.0</ns3:Speed><ns3:RunCadence>84</ns3:RunCadence></ns3:TPX></Extensions>
+          </Trackpoint>
+          <Trackpoint>
+            <Time>2025-03-02T07:00:10.000Z</Time>
+            <DistanceMeters>30.0</DistanceMeters>
+            <HeartRateBpm><Value>130</Value></HeartRateBpm>
+          </Trackpoint>
+        </Track>
+        <Extensions><ns3:LX><ns3:AvgSpeed>3.0</ns3:AvgSpeed></ns3:LX></Extensions>
+      </Lap>
+    </Activity>
+  </Activities>
+</TrainingCenterDatabase>`
+
+func TestParse_GarminExport(t *testing.T) {
+	f, err := ParseBytes([]byte(garminExport))
+	require.NoError(t, err)
+	require.Len(t, f.Activities, 1)
+	activity := f.Activities[0]
+	assert.Equal(t, SportRunning, activity.Sport)
+	require.Len(t, activity.Laps, 1)
+	lap := activity.Laps[0]
+	assert.Equal(t, time.Date(2025, 3, 2, 7, 0, 0, 0, time.UTC), lap.StartTime)
+	assert.Equal(t, 125, lap.AvgHeartRate.Value)
+	require.NotNil(t, lap.Extensions)
+	assert.Equal(t, 3.0, *lap.Extensions.AvgSpeed)
+	require.Len(t, lap.Track, 2)
+	assert.Equal(t, 84, *lap.Track[0].Extensions.RunCadence)
+	assert.Nil(t, lap.Track[1].Position)
+
+	got := f.ActivityStreams()
+	assert.Equal(t, 2, got.Len())
+	assert.Equal(t, []float64{0, 30}, got.Values(streams.Distance))
+	assert.Equal(t, 84.0, got.Values(streams.Cadence)[0])
+	assert.True(t, got.Has(streams.Speed))
+	assert.Len(t, got.Polyline, 1)
+}
+
+func TestFromActivityStreams(t *testing.T) {
+	src, err := ParseBytes([]byte(garminExport))
+	require.NoError(t, err)
+
+	data, err := FromActivityStreams(src.ActivityStreams(), SportRunning).Bytes()
+	require.NoError(t, err)
+	assert.Contains(t, string(data), ActivityExtensionNamespace)
+
+	f, err := ParseBytes(data)
+	require.NoError(t, err)
+	require.Len(t, f.Activities, 1)
+	assert.Equal(t, "2025-03-02T07:00:00Z", f.Activities[0].ID)
+	lap := f.Activities[0].Laps[0]
+	assert.Equal(t, 10.0, lap.TotalTimeSeconds)
+	assert.Equal(t, 30.0, lap.DistanceMeters)
+	assert.Equal(t, 125, lap.AvgHeartRate.Value)
+	assert.Equal(t, 130, lap.MaxHeartRate.Value)
+	require.NotNil(t, lap.Extensions)
+	assert.Equal(t, 84, *lap.Extensions.MaxRunCadence)
+	assert.Equal(t, 3.0, *lap.Extensions.AvgSpeed)
+	require.Len(t, lap.Track, 2)
+	assert.Nil(t, lap.Track[0].Cadence)
+	assert.Equal(t, 84, *lap.Track[0].Extensions.RunCadence)
+	assert.Nil(t, lap.Track[1].Extensions)
+}
@@ -0,0 +1,58 @@
+package garmin
+
+import (
+	"time"
+)
+
+// TimedValue is a reading of a daily wellness time series
+type TimedValue struct {
+	Time  time.Time
+	Value float64
+}
+
+// parseSeries converts Garmin's [[epochMillis, value, ...], ...] arrays into
+// readings. Entries without a value, and the negative placeholders Garmin
+// uses for missing readings, are skipped.
+func parseSeries(raw [][]*float64) []TimedValue {
+	series := make([]TimedValue, 0, len(raw))
+	for _, entry := range raw {
+		if len(entry) < 2 || entry[0] == nil || entry[1] == nil || *entry[1] < 0 {
+			continue
+		}
+		series = append(series, TimedValue{
+			Time:  time.UnixMilli(int64(*entry[0])).UTC(),
+			Value: *entry[1],
+		})
+	}
+	return series
+}
+
+// seriesRange returns the lowest and highest values of a series, or zeros
+// when it is empty
+func seriesRange(series []TimedValue) (low, high float64) {
+	for i, v := range series {
+		if i == 0 || v.Value < low {
+			low = v.Value
+		}
+		if i == 0 || v.Value > high {
+			high = v.Value
+		}
+	}
+	return low, high
+}
+
+// valueOf returns the value of an optional reading, or zero
+func valueOf(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// intOf returns the value of an optional count, or zero
+func intOf(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
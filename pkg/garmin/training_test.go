@@ -0,0 +1,117 @@
+package garmin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTrainingStatus(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics-service/metrics/trainingstatus/aggregated/2025-03-01":
+			w.Write([]byte(`{
+				"mostRecentVO2Max": {
+					"generic": {"calendarDate": "2025-02-28", "vo2MaxValue": 52.0, "vo2MaxPreciseValue": 52.4, "fitnessAge": 31},
+					"cycling": null},
+				"mostRecentTrainingLoadBalance": {"metricsTrainingLoadBalanceDTOMap": {
+					"111": {"monthlyLoadAnaerobic": 40, "monthlyLoadAnaerobicTargetMin": 90, "monthlyLoadAnaerobicTargetMax": 270,
+						"primaryTrainingDevice": false},
+					"222": {"monthlyLoadAerobicLow": 520, "monthlyLoadAerobicLowTargetMin": 310, "monthlyLoadAerobicLowTargetMax": 620,
+						"monthlyLoadAerobicHigh": 480, "monthlyLoadAerobicHighTargetMin": 320, "monthlyLoadAerobicHighTargetMax": 640,
+						"monthlyLoadAnaerobic": 150, "monthlyLoadAnaerobicTargetMin": 90, "monthlyLoadAnaerobicTargetMax": 270,
+						"trainingBalanceFeedbackPhrase": "BALANCED", "primaryTrainingDevice": true}}},
+				"mostRecentTrainingStatus": {"latestTrainingStatusData": {
+					"222": {"calendarDate": "2025-03-01", "sinceDate": "2025-02-25", "trainingStatus": 7,
+						"trainingStatusFeedbackPhrase": "PRODUCTIVE_2", "sport": "RUNNING", "trainingPaused": false,
+						"primaryTrainingDevice": true,
+						"acuteTrainingLoadDTO": {"acwrPercent": 45, "acwrStatus": "OPTIMAL", "dailyTrainingLoadAcute": 512,
+							"dailyTrainingLoadChronic": 520, "minTrainingLoadChronic": 416, "maxTrainingLoadChronic": 780,
+							"dailyAcuteChronicWorkloadRatio": 1.0}}}}}`))
+		case "/metrics-service/metrics/trainingreadiness/2025-03-01":
+			w.Write([]byte(`[{"calendarDate": "2025-03-01", "timestamp": "2025-03-01T06:00:00.0", "score": 76, "recoveryTime": 720}]`))
+		case "/metrics-service/metrics/trainingstatus/aggregated/2025-03-02":
+			w.Write([]byte(`{"mostRecentTrainingStatus": null}`))
+		case "/metrics-service/metrics/trainingstatus/aggregated/2025-03-03":
+			w.Write([]byte(`{"mostRecentTrainingStatus": {"latestTrainingStatusData": {
+				"222": {"calendarDate": "2025-03-03", "trainingStatus": 4, "primaryTrainingDevice": true}}}}`))
+		case "/metrics-service/metrics/trainingreadiness/2025-03-03":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	status, err := c.GetTrainingStatus(day)
+	require.NoError(t, err)
+	assert.Equal(t, garmin.TrainingStatusProductive, status.TrainingStatusKey)
+	assert.NotEmpty(t, status.TrainingStatusKey.Description())
+	assert.Equal(t, "PRODUCTIVE_2", status.TrainingStatusTypeKey)
+	assert.Equal(t, 7, status.TrainingStatusValue)
+	assert.Equal(t, "RUNNING", status.Sport)
+	assert.Equal(t, "2025-02-25", status.SinceDate.Format("2006-01-02"))
+	assert.Equal(t, 12*time.Hour, status.RecoveryTime)
+	assert.Equal(t, 1.0, status.LoadRatio)
+	assert.Equal(t, garmin.AcuteTrainingLoad{
+		Acute: 512, Chronic: 520, OptimalMin: 416, OptimalMax: 780,
+		Ratio: 1.0, RatioPercent: 45, RatioStatus: "OPTIMAL",
+	}, status.AcuteLoad)
+
+	require.NotNil(t, status.LoadFocus)
+	assert.Equal(t, "BALANCED", status.LoadFocus.Feedback)
+	assert.Equal(t, garmin.LoadFocusRange{Load: 150, TargetMin: 90, TargetMax: 270}, status.LoadFocus.Anaerobic)
+	assert.True(t, status.LoadFocus.HighAerobic.InRange())
+	assert.Equal(t, 520.0, status.LoadFocus.LowAerobic.Load)
+
+	require.NotNil(t, status.VO2MaxRunning)
+	assert.Equal(t, 52.4, status.VO2MaxRunning.PreciseValue)
+	assert.Equal(t, 31, *status.VO2MaxRunning.FitnessAge)
+	assert.Nil(t, status.VO2MaxCycling)
+
+	missing, err := c.GetTrainingStatus(day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	withoutReadiness, err := c.GetTrainingStatus(day.AddDate(0, 0, 2))
+	require.NoError(t, err, "a readiness failure does not fail the status")
+	require.NotNil(t, withoutReadiness)
+	assert.Equal(t, time.Duration(0), withoutReadiness.RecoveryTime)
+}
+
+func TestGetTrainingLoadRange(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics-service/metrics/trainingLoad/2025-03-01/2025-03-03":
+			w.Write([]byte(`[{"calendarDate": "2025-03-03", "acuteTrainingLoad": 530},
+				{"calendarDate": "2025-03-01", "acuteTrainingLoad": 500},
+				{"calendarDate": "2025-03-02", "acuteTrainingLoad": 515}]`))
+		case "/metrics-service/metrics/trainingLoad/2025-03-02/2025-03-02":
+			w.Write([]byte(`[{"calendarDate": "2025-03-02", "acuteTrainingLoad": 515, "chronicTrainingLoad": 490}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	loads, err := c.GetTrainingLoadRange(ctx, day, day.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Len(t, loads, 3, "every day is returned")
+	assert.Equal(t, 500.0, loads[0].AcuteTrainingLoad)
+	assert.Equal(t, 530.0, loads[2].AcuteTrainingLoad)
+
+	load, err := c.GetTrainingLoad(day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 490.0, load.ChronicTrainingLoad)
+
+	var invalid *garmin.ValidationError
+	_, err = c.GetTrainingLoadRange(ctx, day, day.AddDate(0, 0, -1))
+	assert.ErrorAs(t, err, &invalid)
+}
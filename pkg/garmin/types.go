@@ -35,18 +35,36 @@ type SleepLevel = garth.SleepLevel
 // SleepMovement represents movement during sleep
 type SleepMovement = garth.SleepMovement
 
+// SleepRestlessMoment represents a restless moment during sleep
+type SleepRestlessMoment = garth.SleepRestlessMoment
+
 // SleepScore represents detailed sleep scoring
 type SleepScore = garth.SleepScore
 
 // SleepScoreBreakdown represents breakdown of sleep score
 type SleepScoreBreakdown = garth.SleepScoreBreakdown
 
+// SpO2SleepSummary represents blood oxygen saturation during sleep
+type SpO2SleepSummary = garth.SpO2SleepSummary
+
+// SpO2Epoch represents a blood oxygen saturation reading
+type SpO2Epoch = garth.SpO2Epoch
+
+// RespirationEpoch represents a respiration rate reading
+type RespirationEpoch = garth.RespirationEpoch
+
+// SleepStressEpoch represents a stress reading taken during sleep
+type SleepStressEpoch = garth.SleepStressEpoch
+
 // HRVBaseline represents HRV baseline data
 type HRVBaseline = garth.HRVBaseline
 
 // DailyHRVData represents comprehensive daily HRV data
 type DailyHRVData = garth.DailyHRVData
 
+// HRVReading represents an individual HRV reading
+type HRVReading = garth.HRVReading
+
 // BodyBatteryEvent represents events that impact Body Battery
 type BodyBatteryEvent = garth.BodyBatteryEvent
 
@@ -56,6 +74,35 @@ type DetailedBodyBatteryData = garth.DetailedBodyBatteryData
 // TrainingStatus represents current training status
 type TrainingStatus = garth.TrainingStatus
 
+// TrainingStatusKey is Garmin's training status
+type TrainingStatusKey = garth.TrainingStatusKey
+
+// Training statuses
+const (
+	TrainingStatusNone         = garth.TrainingStatusNone
+	TrainingStatusDetraining   = garth.TrainingStatusDetraining
+	TrainingStatusRecovery     = garth.TrainingStatusRecovery
+	TrainingStatusMaintaining  = garth.TrainingStatusMaintaining
+	TrainingStatusProductive   = garth.TrainingStatusProductive
+	TrainingStatusPeaking      = garth.TrainingStatusPeaking
+	TrainingStatusOverreaching = garth.TrainingStatusOverreaching
+	TrainingStatusUnproductive = garth.TrainingStatusUnproductive
+	TrainingStatusStrained     = garth.TrainingStatusStrained
+	TrainingStatusPaused       = garth.TrainingStatusPaused
+)
+
+// AcuteTrainingLoad is the acute load compared with the chronic load
+type AcuteTrainingLoad = garth.AcuteTrainingLoad
+
+// LoadFocus is the balance of four weeks of load between training types
+type LoadFocus = garth.LoadFocus
+
+// LoadFocusRange is the load in one focus with its target range
+type LoadFocusRange = garth.LoadFocusRange
+
+// TrainingVO2Max is the VO2 max behind a training status
+type TrainingVO2Max = garth.TrainingVO2Max
+
 // TrainingLoad represents training load data
 type TrainingLoad = garth.TrainingLoad
 
@@ -68,6 +115,15 @@ type VO2MaxData = garth.VO2MaxData
 // VO2MaxEntry represents a single VO2 max entry
 type VO2MaxEntry = garth.VO2MaxEntry
 
+// VO2MaxProfile represents the latest VO2 max values
+type VO2MaxProfile = garth.VO2MaxProfile
+
+// MaxMetEstimate represents a VO2 max estimate from the maxmet history
+type MaxMetEstimate = garth.MaxMetEstimate
+
+// HeatAltitudeAcclimation represents heat and altitude acclimation
+type HeatAltitudeAcclimation = garth.HeatAltitudeAcclimation
+
 // HeartRateZones represents heart rate zone data
 type HeartRateZones = garth.HeartRateZones
 
@@ -0,0 +1,84 @@
+package garmin_test
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin/fit"
+	"github.com/sstent/go-garth/pkg/garmin/gpx"
+	"github.com/sstent/go-garth/pkg/garmin/tcx"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpload_EncodedFIT(t *testing.T) {
+	var path, filename string
+	var data []byte
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		file, header, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		filename = header.Filename
+		data, _ = io.ReadAll(file)
+		w.Write([]byte(`{"detailedImportResult": {"successes": []}}`))
+	}))
+
+	start := time.Date(2025, 3, 2, 18, 0, 0, 0, time.UTC)
+	var records []fit.Record
+	for i := 0; i < 60; i++ {
+		power, hr := 180+i, 130
+		records = append(records, fit.Record{Timestamp: start.Add(time.Duration(i) * time.Second), Power: &power, HeartRate: &hr})
+	}
+	file := filepath.Join(t.TempDir(), "indoor.fit")
+	require.NoError(t, fit.WriteFile(file, fit.NewActivityFile(fit.SportCycling, 6, records)))
+
+	require.NoError(t, c.Upload(file))
+	assert.Equal(t, "/upload-service/upload", path)
+	assert.Equal(t, "indoor.fit", filename)
+	uploaded, err := fit.DecodeBytes(data)
+	require.NoError(t, err)
+	assert.Len(t, uploaded.Records, 60)
+	require.Len(t, uploaded.Sessions, 1)
+	assert.Equal(t, 6, uploaded.Sessions[0].SubSport)
+	assert.Equal(t, 239, *uploaded.Sessions[0].MaxPower)
+}
+
+func TestUpload_ConvertedGPX(t *testing.T) {
+	var filename string
+	var data []byte
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if !assert.NoError(t, err) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		filename = header.Filename
+		data, _ = io.ReadAll(file)
+		w.Write([]byte(`{}`))
+	}))
+
+	start := time.Date(2025, 3, 2, 18, 0, 0, 0, time.UTC)
+	route := &gpx.File{Tracks: []gpx.Track{{Segments: []gpx.Segment{{Points: []gpx.Waypoint{
+		{Lat: 45.5, Lon: -122.25},
+		{Lat: 45.51, Lon: -122.25},
+	}}}}}}
+	route.AssignTimes(start, 8)
+
+	path := filepath.Join(t.TempDir(), "ride.tcx")
+	require.NoError(t, tcx.FromActivityStreams(route.ActivityStreams(), tcx.SportBiking).WriteFile(path))
+	require.NoError(t, c.Upload(path))
+
+	assert.Equal(t, "ride.tcx", filename)
+	uploaded, err := tcx.ParseBytes(data)
+	require.NoError(t, err)
+	require.Len(t, uploaded.Activities, 1)
+	assert.Equal(t, tcx.SportBiking, uploaded.Activities[0].Sport)
+	assert.InDelta(t, 1112, uploaded.Activities[0].Laps[0].DistanceMeters, 1)
+}
@@ -0,0 +1,47 @@
+package garmin_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVO2MaxHistory(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/metrics-service/metrics/maxmet/daily/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[
+			{"userId": 7, "generic": {"calendarDate": "2025-02-10", "vo2MaxPreciseValue": 50.2, "fitnessAge": 33},
+			 "cycling": {"calendarDate": "2025-02-10", "vo2MaxPreciseValue": 54.0}},
+			{"userId": 7, "generic": {"calendarDate": "2025-01-20", "vo2MaxPreciseValue": 49.8, "fitnessAge": 34}},
+			{"userId": 7, "generic": {"calendarDate": "2025-02-20", "vo2MaxPreciseValue": 50.9, "fitnessAge": 32}}]`))
+	}))
+	start, end := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	history, err := c.GetVO2MaxData(start, end)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, 49.8, *history[0].VO2MaxRunning)
+	assert.Nil(t, history[0].VO2MaxCycling)
+	assert.Equal(t, 54.0, *history[1].VO2MaxCycling)
+
+	ages, err := c.GetFitnessAgeHistory(start, end)
+	require.NoError(t, err)
+	require.Len(t, ages, 3)
+	assert.Equal(t, []int{34, 33, 32}, []int{ages[0].FitnessAge, ages[1].FitnessAge, ages[2].FitnessAge})
+	assert.Equal(t, time.February, ages[2].LastUpdated.Month())
+
+	profile, err := c.GetCurrentVO2Max()
+	require.NoError(t, err)
+	assert.Equal(t, 50.9, profile.Running.Value)
+	assert.Equal(t, 20, profile.Running.Date.Day())
+	assert.Equal(t, 54.0, profile.Cycling.Value)
+	assert.Equal(t, 10, profile.Cycling.Date.Day())
+	assert.Equal(t, 32, *profile.FitnessAge)
+}
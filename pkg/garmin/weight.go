@@ -0,0 +1,351 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/sstent/go-garth/internal/data"
+	"github.com/sstent/go-garth/pkg/garmin/fit"
+)
+
+// WeightUnit is a unit of body mass
+type WeightUnit string
+
+// Weight units accepted by the weight service
+const (
+	Kilograms WeightUnit = "kg"
+	Pounds    WeightUnit = "lbs"
+)
+
+// poundsPerKilogram converts between the metric and statute units
+const poundsPerKilogram = 2.20462262185
+
+// WeightUnitFor returns the weight unit of a UserSettings.MeasurementSystem
+// value such as "metric" or "statute_us"
+func WeightUnitFor(measurementSystem string) WeightUnit {
+	switch measurementSystem {
+	case "statute_us", "statute_uk":
+		return Pounds
+	}
+	return Kilograms
+}
+
+// ConvertWeight converts a mass in kilograms to the given unit
+func ConvertWeight(kg float64, unit WeightUnit) float64 {
+	if unit == Pounds {
+		return kg * poundsPerKilogram
+	}
+	return kg
+}
+
+// toKilograms converts a mass in the given unit to kilograms
+func toKilograms(value float64, unit WeightUnit) float64 {
+	if unit == Pounds {
+		return value / poundsPerKilogram
+	}
+	return value
+}
+
+// WeighIn is a single weight measurement. Masses are in kilograms and
+// percentages in percent; body-composition fields are nil when not measured.
+type WeighIn struct {
+	SamplePK       int64
+	Timestamp      time.Time // Measurement time, UTC
+	CalendarDate   string    // Local date, YYYY-MM-DD
+	Weight         float64
+	BMI            *float64
+	BodyFat        *float64
+	BodyWater      *float64
+	BoneMass       *float64
+	MuscleMass     *float64
+	PhysiqueRating *float64
+	VisceralFat    *float64
+	MetabolicAge   *float64
+	SourceType     string // e.g. "MANUAL", "INDEX_SCALE"
+}
+
+// WeightIn returns the weight in the given unit
+func (w WeighIn) WeightIn(unit WeightUnit) float64 {
+	return ConvertWeight(w.Weight, unit)
+}
+
+// WeightAverage averages the weigh-ins of a day or period. Composition
+// averages only count weigh-ins that measured them and are zero when none did.
+type WeightAverage struct {
+	From       string // YYYY-MM-DD
+	To         string // YYYY-MM-DD
+	Count      int
+	Weight     float64
+	BMI        float64
+	BodyFat    float64
+	BodyWater  float64
+	BoneMass   float64
+	MuscleMass float64
+}
+
+// WeighIns holds the weigh-ins of a date range with daily and period averages
+type WeighIns struct {
+	Entries []WeighIn       // Oldest first
+	Daily   []WeightAverage // One per day with weigh-ins, oldest first
+	Average *WeightAverage  // Whole range; nil when there are no weigh-ins
+}
+
+// newWeighIn converts a weight-service weigh-in from grams to kilograms
+func newWeighIn(d *data.WeighInDTO) WeighIn {
+	grams := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		kg := *v / 1000
+		return &kg
+	}
+	return WeighIn{
+		SamplePK:       d.SamplePK,
+		Timestamp:      d.Time(),
+		CalendarDate:   d.CalendarDate,
+		Weight:         d.Weight / 1000,
+		BMI:            d.BMI,
+		BodyFat:        d.BodyFat,
+		BodyWater:      d.BodyWater,
+		BoneMass:       grams(d.BoneMass),
+		MuscleMass:     grams(d.MuscleMass),
+		PhysiqueRating: d.PhysiqueRating,
+		VisceralFat:    d.VisceralFat,
+		MetabolicAge:   d.MetabolicAge,
+		SourceType:     d.SourceType,
+	}
+}
+
+// GetWeighIns retrieves every weigh-in between two dates, inclusive
+func (c *Client) GetWeighIns(ctx context.Context, startDate, endDate time.Time) (*WeighIns, error) {
+	path := fmt.Sprintf("/weight-service/weight/range/%s/%s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	params := url.Values{}
+	params.Set("includeAll", "true")
+
+	body, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weigh-ins: %w", err)
+	}
+
+	dtos, err := data.ParseWeighIns(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse weigh-ins response: %w", err)
+	}
+
+	result := &WeighIns{}
+	for i := range dtos {
+		result.Entries = append(result.Entries, newWeighIn(&dtos[i]))
+	}
+
+	byDate := make(map[string][]WeighIn)
+	var dates []string
+	for _, w := range result.Entries {
+		if _, ok := byDate[w.CalendarDate]; !ok {
+			dates = append(dates, w.CalendarDate)
+		}
+		byDate[w.CalendarDate] = append(byDate[w.CalendarDate], w)
+	}
+	sort.Strings(dates)
+	for _, date := range dates {
+		result.Daily = append(result.Daily, averageWeighIns(byDate[date]))
+	}
+	if len(result.Entries) > 0 {
+		avg := averageWeighIns(result.Entries)
+		result.Average = &avg
+	}
+	return result, nil
+}
+
+// averageWeighIns averages a non-empty set of weigh-ins
+func averageWeighIns(entries []WeighIn) WeightAverage {
+	avg := WeightAverage{From: entries[0].CalendarDate, To: entries[0].CalendarDate, Count: len(entries)}
+	var bmi, fat, water, bone, muscle stat
+	for _, w := range entries {
+		if w.CalendarDate < avg.From {
+			avg.From = w.CalendarDate
+		}
+		if w.CalendarDate > avg.To {
+			avg.To = w.CalendarDate
+		}
+		avg.Weight += w.Weight
+		bmi.add(w.BMI)
+		fat.add(w.BodyFat)
+		water.add(w.BodyWater)
+		bone.add(w.BoneMass)
+		muscle.add(w.MuscleMass)
+	}
+	avg.Weight /= float64(len(entries))
+	avg.BMI, avg.BodyFat, avg.BodyWater = bmi.mean(), fat.mean(), water.mean()
+	avg.BoneMass, avg.MuscleMass = bone.mean(), muscle.mean()
+	return avg
+}
+
+// stat accumulates the mean of optional values
+type stat struct {
+	sum float64
+	n   int
+}
+
+func (s *stat) add(v *float64) {
+	if v != nil {
+		s.sum += *v
+		s.n++
+	}
+}
+
+func (s *stat) mean() float64 {
+	if s.n == 0 {
+		return 0
+	}
+	return s.sum / float64(s.n)
+}
+
+// NewWeighIn describes a weigh-in to record. Weight, BoneMass and MuscleMass
+// are in Unit, which defaults to the user's measurement system; BodyFat and
+// BodyWater are percentages.
+type NewWeighIn struct {
+	Time       time.Time // Defaults to now
+	Weight     float64
+	Unit       WeightUnit
+	BodyFat    *float64
+	BodyWater  *float64
+	BoneMass   *float64
+	MuscleMass *float64
+}
+
+// hasComposition reports whether any body-composition field is set
+func (w *NewWeighIn) hasComposition() bool {
+	return w.BodyFat != nil || w.BodyWater != nil || w.BoneMass != nil || w.MuscleMass != nil
+}
+
+// maxWeighInKilograms bounds weigh-in masses. FIT stores them as uint16
+// hundredths of a kilogram, so larger values cannot be uploaded.
+const maxWeighInKilograms = 655.34
+
+// validate checks the weigh-in before any API call is made. The mass limit is
+// only checked once the unit is known.
+func (w *NewWeighIn) validate() error {
+	if w.Weight <= 0 {
+		return validationError("Weight", "weight must be positive")
+	}
+	if w.Unit != "" && w.Unit != Kilograms && w.Unit != Pounds {
+		return validationError("Unit", fmt.Sprintf("unknown weight unit %q", w.Unit))
+	}
+	if w.Unit != "" {
+		tooHeavy := fmt.Sprintf("mass must be below %.2f kg", maxWeighInKilograms)
+		if toKilograms(w.Weight, w.Unit) >= maxWeighInKilograms {
+			return validationError("Weight", tooHeavy)
+		}
+		if w.BoneMass != nil && toKilograms(*w.BoneMass, w.Unit) >= maxWeighInKilograms {
+			return validationError("BoneMass", tooHeavy)
+		}
+		if w.MuscleMass != nil && toKilograms(*w.MuscleMass, w.Unit) >= maxWeighInKilograms {
+			return validationError("MuscleMass", tooHeavy)
+		}
+	}
+	if w.BodyFat != nil && (*w.BodyFat < 0 || *w.BodyFat > 100) {
+		return validationError("BodyFat", "percentage must be between 0 and 100")
+	}
+	if w.BodyWater != nil && (*w.BodyWater < 0 || *w.BodyWater > 100) {
+		return validationError("BodyWater", "percentage must be between 0 and 100")
+	}
+	if w.BoneMass != nil && (*w.BoneMass < 0 || *w.BoneMass > w.Weight) {
+		return validationError("BoneMass", "mass must be between 0 and the body weight")
+	}
+	if w.MuscleMass != nil && (*w.MuscleMass < 0 || *w.MuscleMass > w.Weight) {
+		return validationError("MuscleMass", "mass must be between 0 and the body weight")
+	}
+	return nil
+}
+
+// PreferredWeightUnit returns the weight unit of the user's measurement system
+func (c *Client) PreferredWeightUnit() (WeightUnit, error) {
+	settings, err := c.Client.GetUserSettings()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user settings: %w", err)
+	}
+	return WeightUnitFor(settings.UserData.MeasurementSystem), nil
+}
+
+// AddWeighIn records a weigh-in, for example from a third-party scale.
+// Weight-only readings use the weight service; readings with body composition
+// are uploaded as a FIT weight file, which is how Garmin Connect imports them.
+func (c *Client) AddWeighIn(ctx context.Context, weighIn NewWeighIn) error {
+	if err := weighIn.validate(); err != nil {
+		return err
+	}
+	if weighIn.Unit == "" {
+		unit, err := c.PreferredWeightUnit()
+		if err != nil {
+			return err
+		}
+		weighIn.Unit = unit
+		if err := weighIn.validate(); err != nil {
+			return err
+		}
+	}
+	if weighIn.Time.IsZero() {
+		weighIn.Time = time.Now()
+	}
+
+	if weighIn.hasComposition() {
+		return c.uploadWeighIn(ctx, weighIn)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"dateTimestamp": weighIn.Time.Format("2006-01-02T15:04:05.00"),
+		"gmtTimestamp":  weighIn.Time.UTC().Format("2006-01-02T15:04:05.00"),
+		"unitKey":       weighIn.Unit,
+		"sourceType":    "MANUAL",
+		"value":         weighIn.Weight,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode weigh-in: %w", err)
+	}
+	if _, err := c.Client.ConnectAPIWithContext(ctx, "/weight-service/user-weight", "POST", nil, bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("failed to add weigh-in: %w", err)
+	}
+	return nil
+}
+
+// uploadWeighIn uploads a weigh-in with body composition as a FIT file
+func (c *Client) uploadWeighIn(ctx context.Context, weighIn NewWeighIn) error {
+	kg := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		converted := toKilograms(*v, weighIn.Unit)
+		return &converted
+	}
+	data, err := fit.EncodeBytes(fit.NewWeightFile(fit.WeightScale{
+		Timestamp:        weighIn.Time,
+		Weight:           kg(&weighIn.Weight),
+		PercentFat:       weighIn.BodyFat,
+		PercentHydration: weighIn.BodyWater,
+		BoneMass:         kg(weighIn.BoneMass),
+		MuscleMass:       kg(weighIn.MuscleMass),
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to encode weigh-in: %w", err)
+	}
+	filename := fmt.Sprintf("weight_%s.fit", weighIn.Time.UTC().Format("20060102T150405"))
+	if err := c.Client.UploadReader(ctx, filename, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("failed to upload weigh-in: %w", err)
+	}
+	return nil
+}
+
+// DeleteWeighIn deletes a weigh-in, identified by its local calendar date and sample ID
+func (c *Client) DeleteWeighIn(ctx context.Context, calendarDate time.Time, samplePK int64) error {
+	path := fmt.Sprintf("/weight-service/weight/%s/byversion/%d", calendarDate.Format("2006-01-02"), samplePK)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "DELETE", nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to delete weigh-in: %w", err), "weigh-in", fmt.Sprint(samplePK))
+	}
+	return nil
+}
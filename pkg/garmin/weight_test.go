@@ -0,0 +1,124 @@
+package garmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+	"github.com/sstent/go-garth/pkg/garmin/fit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const weighInsBody = `{"dailyWeightSummaries": [
+	{"summaryDate": "2025-03-02", "allWeightMetrics": [
+		{"samplePk": 3, "calendarDate": "2025-03-02", "timestampGMT": 1740898800000, "weight": 71000,
+		 "bmi": 22.1, "bodyFat": null, "sourceType": "MANUAL"}]},
+	{"summaryDate": "2025-03-01", "allWeightMetrics": [
+		{"samplePk": 2, "calendarDate": "2025-03-01", "timestampGMT": 1740855600000, "weight": 70600,
+		 "bmi": 22.0, "bodyFat": 18.0, "boneMass": 3100, "muscleMass": 33000, "sourceType": "INDEX_SCALE"},
+		{"samplePk": 1, "calendarDate": "2025-03-01", "timestampGMT": 1740812400000, "weight": 70000,
+		 "bmi": 21.8, "bodyFat": 19.0, "boneMass": 3100, "muscleMass": 32800, "sourceType": "INDEX_SCALE"}]}]}`
+
+func TestGetWeighIns(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/weight-service/weight/range/2025-03-01/2025-03-07":
+			assert.Equal(t, "true", r.URL.Query().Get("includeAll"))
+			w.Write([]byte(weighInsBody))
+		case "/weight-service/weight/2025-03-01/byversion/2":
+			assert.Equal(t, "DELETE", r.Method)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	weighIns, err := c.GetWeighIns(ctx, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 7, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, weighIns.Entries, 3)
+	first := weighIns.Entries[0]
+	assert.Equal(t, int64(1), first.SamplePK)
+	assert.Equal(t, 70.0, first.Weight)
+	assert.Equal(t, 3.1, *first.BoneMass)
+	assert.Equal(t, "INDEX_SCALE", first.SourceType)
+	assert.Equal(t, time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC), first.Timestamp)
+	assert.InDelta(t, 154.32, first.WeightIn(garmin.Pounds), 0.01)
+	assert.Nil(t, weighIns.Entries[2].BodyFat)
+
+	require.Len(t, weighIns.Daily, 2)
+	assert.Equal(t, "2025-03-01", weighIns.Daily[0].From)
+	assert.Equal(t, 2, weighIns.Daily[0].Count)
+	assert.InDelta(t, 70.3, weighIns.Daily[0].Weight, 1e-9)
+	assert.InDelta(t, 18.5, weighIns.Daily[0].BodyFat, 1e-9)
+	assert.Equal(t, 3, weighIns.Average.Count)
+	assert.Equal(t, "2025-03-02", weighIns.Average.To)
+	assert.InDelta(t, 18.5, weighIns.Average.BodyFat, 1e-9, "composition averages skip weigh-ins without it")
+
+	require.NoError(t, c.DeleteWeighIn(ctx, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 2))
+}
+
+func TestAddWeighIn(t *testing.T) {
+	var postedBody, uploadedData []byte
+	var uploadedName string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/userprofile-service/userprofile/user-settings":
+			w.Write([]byte(`{"id": 1, "userData": {"measurementSystem": "statute_us"}}`))
+		case "/weight-service/user-weight":
+			postedBody, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		case "/upload-service/upload":
+			file, header, err := r.FormFile("file")
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			uploadedName = header.Filename
+			uploadedData, _ = io.ReadAll(file)
+			w.Write([]byte(`{}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+	at := time.Date(2025, 3, 1, 7, 0, 0, 0, time.UTC)
+
+	require.NoError(t, c.AddWeighIn(ctx, garmin.NewWeighIn{Time: at, Weight: 155}))
+	var posted map[string]interface{}
+	require.NoError(t, json.Unmarshal(postedBody, &posted))
+	assert.Equal(t, "lbs", posted["unitKey"], "unit follows the user's measurement system")
+	assert.Equal(t, 155.0, posted["value"])
+	assert.Equal(t, "2025-03-01T07:00:00.00", posted["gmtTimestamp"])
+
+	fat, water, muscle := 18.4, 55.2, 33.0
+	require.NoError(t, c.AddWeighIn(ctx, garmin.NewWeighIn{
+		Time: at, Weight: 70.5, Unit: garmin.Kilograms,
+		BodyFat: &fat, BodyWater: &water, MuscleMass: &muscle,
+	}))
+	assert.Equal(t, "weight_20250301T070000.fit", uploadedName)
+	uploaded, err := fit.DecodeBytes(uploadedData)
+	require.NoError(t, err)
+	assert.Equal(t, fit.FileTypeWeight, uploaded.FileID.Type)
+	require.Len(t, uploaded.WeightScales, 1)
+	scale := uploaded.WeightScales[0]
+	assert.InDelta(t, 70.5, *scale.Weight, 1e-9)
+	assert.InDelta(t, 18.4, *scale.PercentFat, 1e-9)
+	assert.InDelta(t, 33.0, *scale.MuscleMass, 1e-9)
+	assert.Nil(t, scale.BoneMass, "unset bone mass is encoded as invalid")
+	assert.True(t, at.Equal(scale.Timestamp))
+
+	var invalid *garmin.ValidationError
+	assert.ErrorAs(t, c.AddWeighIn(ctx, garmin.NewWeighIn{Weight: 0}), &invalid)
+	tooFat := 120.0
+	assert.ErrorAs(t, c.AddWeighIn(ctx, garmin.NewWeighIn{Weight: 70, BodyFat: &tooFat}), &invalid)
+	assert.ErrorAs(t, c.AddWeighIn(ctx, garmin.NewWeighIn{Weight: 655.34, Unit: garmin.Kilograms}), &invalid)
+	assert.ErrorAs(t, c.AddWeighIn(ctx, garmin.NewWeighIn{Weight: 1500}), &invalid, "limit applies in the preferred unit")
+	require.NoError(t, c.AddWeighIn(ctx, garmin.NewWeighIn{Weight: 1000}), "1000 lbs is below the limit")
+}
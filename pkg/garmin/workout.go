@@ -0,0 +1,524 @@
+package garmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Workout sports
+const (
+	WorkoutSportRunning  = "running"
+	WorkoutSportCycling  = "cycling"
+	WorkoutSportOther    = "other"
+	WorkoutSportSwimming = "swimming"
+	WorkoutSportStrength = "strength_training"
+	WorkoutSportCardio   = "cardio_training"
+	WorkoutSportYoga     = "yoga"
+	WorkoutSportPilates  = "pilates"
+	WorkoutSportHIIT     = "hiit"
+	WorkoutSportMulti    = "multi_sport"
+)
+
+// Workout step types
+const (
+	StepWarmup   = "warmup"
+	StepCooldown = "cooldown"
+	StepInterval = "interval"
+	StepRecovery = "recovery"
+	StepRest     = "rest"
+	StepRepeat   = "repeat"
+	StepOther    = "other"
+)
+
+// Step duration (end condition) types
+const (
+	DurationLapButton = "lap.button"
+	DurationTime      = "time"
+	DurationDistance  = "distance"
+	DurationCalories  = "calories"
+)
+
+// Step target types
+const (
+	TargetNone          = "no.target"
+	TargetPowerZone     = "power.zone"
+	TargetCadence       = "cadence"
+	TargetHeartRateZone = "heart.rate.zone"
+	TargetSpeedZone     = "speed.zone"
+	TargetPaceZone      = "pace.zone"
+)
+
+// Workout-service type IDs, keyed by type key
+var (
+	workoutSportIDs = map[string]int{
+		WorkoutSportRunning:  1,
+		WorkoutSportCycling:  2,
+		WorkoutSportOther:    3,
+		WorkoutSportSwimming: 4,
+		WorkoutSportStrength: 5,
+		WorkoutSportCardio:   6,
+		WorkoutSportYoga:     7,
+		WorkoutSportPilates:  8,
+		WorkoutSportHIIT:     9,
+		WorkoutSportMulti:    10,
+	}
+	stepTypeIDs = map[string]int{
+		StepWarmup:   1,
+		StepCooldown: 2,
+		StepInterval: 3,
+		StepRecovery: 4,
+		StepRest:     5,
+		StepRepeat:   6,
+		StepOther:    7,
+	}
+	durationTypeIDs = map[string]int{
+		DurationLapButton: 1,
+		DurationTime:      2,
+		DurationDistance:  3,
+		DurationCalories:  4,
+		"iterations":      7,
+	}
+	targetTypeIDs = map[string]int{
+		TargetNone:          1,
+		TargetPowerZone:     2,
+		TargetCadence:       3,
+		TargetHeartRateZone: 4,
+		TargetSpeedZone:     5,
+		TargetPaceZone:      6,
+	}
+)
+
+// Workout is a structured workout. Most workouts have a single segment;
+// multisport workouts have one segment per sport.
+type Workout struct {
+	WorkoutID   int64
+	Name        string
+	Description string
+	Sport       string // One of the WorkoutSport* constants
+	Segments    []WorkoutSegment
+	CreatedDate GarminTime
+	UpdatedDate GarminTime
+}
+
+// WorkoutSegment is the sequence of steps for one sport
+type WorkoutSegment struct {
+	Sport string
+	Steps []WorkoutStep
+}
+
+// WorkoutStep is a single step or, when Type is StepRepeat, a repeat group
+// whose Steps are performed Iterations times
+type WorkoutStep struct {
+	Type        string // One of the Step* constants
+	Description string
+	Duration    StepDuration
+	Target      StepTarget
+
+	Iterations int
+	Steps      []WorkoutStep
+}
+
+// StepDuration is the condition that ends a step. Value is in seconds, meters
+// or kilocalories depending on the type and unused for the lap button.
+type StepDuration struct {
+	Type  string // One of the Duration* constants
+	Value float64
+}
+
+// StepTarget is the intensity target of a step. Either Zone is set, for zone
+// targets, or Low and High give a custom range in bpm, watts, rpm or m/s.
+type StepTarget struct {
+	Type string // One of the Target* constants
+	Zone int
+	Low  float64
+	High float64
+}
+
+// LapButton ends a step when the lap button is pressed
+func LapButton() StepDuration {
+	return StepDuration{Type: DurationLapButton}
+}
+
+// ForTime ends a step after a duration
+func ForTime(d time.Duration) StepDuration {
+	return StepDuration{Type: DurationTime, Value: d.Seconds()}
+}
+
+// ForDistance ends a step after a distance in meters
+func ForDistance(meters float64) StepDuration {
+	return StepDuration{Type: DurationDistance, Value: meters}
+}
+
+// ForCalories ends a step after burning the given kilocalories
+func ForCalories(kcal float64) StepDuration {
+	return StepDuration{Type: DurationCalories, Value: kcal}
+}
+
+// NoTarget leaves a step without an intensity target
+func NoTarget() StepTarget {
+	return StepTarget{Type: TargetNone}
+}
+
+// HeartRateZone targets a heart rate zone (1-5)
+func HeartRateZone(zone int) StepTarget {
+	return StepTarget{Type: TargetHeartRateZone, Zone: zone}
+}
+
+// HeartRateRange targets a heart rate range in bpm
+func HeartRateRange(low, high float64) StepTarget {
+	return StepTarget{Type: TargetHeartRateZone, Low: low, High: high}
+}
+
+// PowerZone targets a power zone (1-7)
+func PowerZone(zone int) StepTarget {
+	return StepTarget{Type: TargetPowerZone, Zone: zone}
+}
+
+// PowerRange targets a power range in watts
+func PowerRange(low, high float64) StepTarget {
+	return StepTarget{Type: TargetPowerZone, Low: low, High: high}
+}
+
+// CadenceRange targets a cadence range in rpm or steps per minute
+func CadenceRange(low, high float64) StepTarget {
+	return StepTarget{Type: TargetCadence, Low: low, High: high}
+}
+
+// SpeedRange targets a speed range in m/s
+func SpeedRange(low, high float64) StepTarget {
+	return StepTarget{Type: TargetSpeedZone, Low: low, High: high}
+}
+
+// PaceRange targets a pace range given as time per kilometer, e.g.
+// PaceRange(5*time.Minute, 4*time.Minute+30*time.Second)
+func PaceRange(slowest, fastest time.Duration) StepTarget {
+	return StepTarget{Type: TargetPaceZone, Low: paceToSpeed(slowest), High: paceToSpeed(fastest)}
+}
+
+func paceToSpeed(perKm time.Duration) float64 {
+	if perKm <= 0 {
+		return 0
+	}
+	return 1000 / perKm.Seconds()
+}
+
+// IsRepeat reports whether the step is a repeat group
+func (s WorkoutStep) IsRepeat() bool {
+	return s.Type == StepRepeat
+}
+
+// EstimatedDuration sums the time-based steps of a workout, counting repeat
+// groups once per iteration. Steps ending on distance, calories or the lap
+// button are not included.
+func (w *Workout) EstimatedDuration() time.Duration {
+	var total time.Duration
+	for _, seg := range w.Segments {
+		total += stepsDuration(seg.Steps)
+	}
+	return total
+}
+
+func stepsDuration(steps []WorkoutStep) time.Duration {
+	var total time.Duration
+	for _, s := range steps {
+		if s.IsRepeat() {
+			total += time.Duration(s.Iterations) * stepsDuration(s.Steps)
+		} else if s.Duration.Type == DurationTime {
+			total += time.Duration(s.Duration.Value * float64(time.Second))
+		}
+	}
+	return total
+}
+
+// Validate checks the workout can be created on Garmin Connect
+func (w *Workout) Validate() error {
+	if w.Name == "" {
+		return validationError("Name", "workout name cannot be empty")
+	}
+	if _, ok := workoutSportIDs[w.Sport]; !ok {
+		return validationError("Sport", fmt.Sprintf("unknown workout sport %q", w.Sport))
+	}
+	if len(w.Segments) == 0 {
+		return validationError("Segments", "workout has no steps")
+	}
+	for i, seg := range w.Segments {
+		field := fmt.Sprintf("Segments[%d]", i)
+		if seg.Sport != "" {
+			if _, ok := workoutSportIDs[seg.Sport]; !ok {
+				return validationError(field+".Sport", fmt.Sprintf("unknown workout sport %q", seg.Sport))
+			}
+		}
+		if len(seg.Steps) == 0 {
+			return validationError(field+".Steps", "segment has no steps")
+		}
+		if err := validateSteps(seg.Steps, field+".Steps", false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateSteps(steps []WorkoutStep, field string, nested bool) error {
+	for i, s := range steps {
+		field := fmt.Sprintf("%s[%d]", field, i)
+		if _, ok := stepTypeIDs[s.Type]; !ok {
+			return validationError(field+".Type", fmt.Sprintf("unknown step type %q", s.Type))
+		}
+		if s.IsRepeat() {
+			if nested {
+				return validationError(field, "repeat groups cannot be nested")
+			}
+			if s.Iterations < 1 {
+				return validationError(field+".Iterations", "repeat group needs at least one iteration")
+			}
+			if len(s.Steps) == 0 {
+				return validationError(field+".Steps", "repeat group has no steps")
+			}
+			if err := validateSteps(s.Steps, field+".Steps", true); err != nil {
+				return err
+			}
+			continue
+		}
+		if problem := s.Duration.validate(); problem != "" {
+			return validationError(field+".Duration", problem)
+		}
+		if problem := s.Target.validate(); problem != "" {
+			return validationError(field+".Target", problem)
+		}
+	}
+	return nil
+}
+
+// validate returns a description of the problem with a duration, or ""
+func (d StepDuration) validate() string {
+	if _, ok := durationTypeIDs[d.Type]; !ok || d.Type == "iterations" {
+		return fmt.Sprintf("unknown duration type %q", d.Type)
+	}
+	if d.Type != DurationLapButton && d.Value <= 0 {
+		return fmt.Sprintf("%s duration must be positive", d.Type)
+	}
+	return ""
+}
+
+// validate returns a description of the problem with a target, or ""
+func (t StepTarget) validate() string {
+	if t.Type == "" || t.Type == TargetNone {
+		return ""
+	}
+	if _, ok := targetTypeIDs[t.Type]; !ok {
+		return fmt.Sprintf("unknown target type %q", t.Type)
+	}
+	if t.Zone != 0 {
+		if t.Type != TargetHeartRateZone && t.Type != TargetPowerZone {
+			return fmt.Sprintf("%s targets take a range, not a zone", t.Type)
+		}
+		maxZone := 5
+		if t.Type == TargetPowerZone {
+			maxZone = 7
+		}
+		if t.Zone < 1 || t.Zone > maxZone {
+			return fmt.Sprintf("%s must be between 1 and %d", t.Type, maxZone)
+		}
+		return ""
+	}
+	if t.Low <= 0 || t.High <= 0 || t.Low > t.High {
+		return fmt.Sprintf("%s range must be positive with low <= high", t.Type)
+	}
+	return ""
+}
+
+// workoutDTO mirrors the workout-service workout payload
+type workoutDTO struct {
+	WorkoutID               int64               `json:"workoutId,omitempty"`
+	WorkoutName             string              `json:"workoutName"`
+	Description             string              `json:"description,omitempty"`
+	SportType               sportTypeDTO        `json:"sportType"`
+	WorkoutSegments         []workoutSegmentDTO `json:"workoutSegments"`
+	EstimatedDurationInSecs int                 `json:"estimatedDurationInSecs,omitempty"`
+	CreatedDate             *GarminTime         `json:"createdDate,omitempty"`
+	UpdatedDate             *GarminTime         `json:"updatedDate,omitempty"`
+}
+
+type sportTypeDTO struct {
+	SportTypeID  int    `json:"sportTypeId"`
+	SportTypeKey string `json:"sportTypeKey"`
+}
+
+type workoutSegmentDTO struct {
+	SegmentOrder int              `json:"segmentOrder"`
+	SportType    sportTypeDTO     `json:"sportType"`
+	WorkoutSteps []workoutStepDTO `json:"workoutSteps"`
+}
+
+type workoutStepDTO struct {
+	Type        string `json:"type"` // ExecutableStepDTO or RepeatGroupDTO
+	StepOrder   int    `json:"stepOrder"`
+	ChildStepID *int   `json:"childStepId,omitempty"`
+	StepType    struct {
+		StepTypeID  int    `json:"stepTypeId"`
+		StepTypeKey string `json:"stepTypeKey"`
+	} `json:"stepType"`
+	Description  string `json:"description,omitempty"`
+	EndCondition struct {
+		ConditionTypeID  int    `json:"conditionTypeId"`
+		ConditionTypeKey string `json:"conditionTypeKey"`
+	} `json:"endCondition"`
+	EndConditionValue *float64 `json:"endConditionValue,omitempty"`
+	TargetType        *struct {
+		WorkoutTargetTypeID  int    `json:"workoutTargetTypeId"`
+		WorkoutTargetTypeKey string `json:"workoutTargetTypeKey"`
+	} `json:"targetType,omitempty"`
+	TargetValueOne     *float64         `json:"targetValueOne,omitempty"`
+	TargetValueTwo     *float64         `json:"targetValueTwo,omitempty"`
+	ZoneNumber         *int             `json:"zoneNumber,omitempty"`
+	NumberOfIterations int              `json:"numberOfIterations,omitempty"`
+	SmartRepeat        bool             `json:"smartRepeat,omitempty"`
+	WorkoutSteps       []workoutStepDTO `json:"workoutSteps,omitempty"`
+}
+
+// MarshalJSON encodes the workout as a workout-service payload
+func (w Workout) MarshalJSON() ([]byte, error) {
+	dto := workoutDTO{
+		WorkoutID:               w.WorkoutID,
+		WorkoutName:             w.Name,
+		Description:             w.Description,
+		SportType:               sportType(w.Sport),
+		EstimatedDurationInSecs: int(w.EstimatedDuration().Seconds()),
+	}
+	order, group := 0, 0
+	for i, seg := range w.Segments {
+		sport := seg.Sport
+		if sport == "" {
+			sport = w.Sport
+		}
+		dto.WorkoutSegments = append(dto.WorkoutSegments, workoutSegmentDTO{
+			SegmentOrder: i + 1,
+			SportType:    sportType(sport),
+			WorkoutSteps: encodeSteps(seg.Steps, &order, &group, nil),
+		})
+	}
+	return json.Marshal(dto)
+}
+
+func sportType(key string) sportTypeDTO {
+	return sportTypeDTO{SportTypeID: workoutSportIDs[key], SportTypeKey: key}
+}
+
+// encodeSteps numbers steps in the order Garmin Connect does: stepOrder counts
+// every step including repeat groups and their children, and children carry
+// the number of their repeat group as childStepId
+func encodeSteps(steps []WorkoutStep, order, group *int, childStepID *int) []workoutStepDTO {
+	out := make([]workoutStepDTO, 0, len(steps))
+	for _, s := range steps {
+		*order++
+		var dto workoutStepDTO
+		dto.StepOrder = *order
+		dto.ChildStepID = childStepID
+		dto.StepType.StepTypeID = stepTypeIDs[s.Type]
+		dto.StepType.StepTypeKey = s.Type
+		dto.Description = s.Description
+
+		if s.IsRepeat() {
+			*group++
+			id := *group
+			iterations := float64(s.Iterations)
+			dto.Type = "RepeatGroupDTO"
+			dto.NumberOfIterations = s.Iterations
+			dto.EndCondition.ConditionTypeID = durationTypeIDs["iterations"]
+			dto.EndCondition.ConditionTypeKey = "iterations"
+			dto.EndConditionValue = &iterations
+			dto.ChildStepID = &id
+			dto.WorkoutSteps = encodeSteps(s.Steps, order, group, &id)
+			out = append(out, dto)
+			continue
+		}
+
+		dto.Type = "ExecutableStepDTO"
+		dto.EndCondition.ConditionTypeID = durationTypeIDs[s.Duration.Type]
+		dto.EndCondition.ConditionTypeKey = s.Duration.Type
+		if s.Duration.Type != DurationLapButton {
+			value := s.Duration.Value
+			dto.EndConditionValue = &value
+		}
+
+		target := s.Target
+		if target.Type == "" {
+			target = NoTarget()
+		}
+		dto.TargetType = &struct {
+			WorkoutTargetTypeID  int    `json:"workoutTargetTypeId"`
+			WorkoutTargetTypeKey string `json:"workoutTargetTypeKey"`
+		}{targetTypeIDs[target.Type], target.Type}
+		if target.Zone != 0 {
+			zone := target.Zone
+			dto.ZoneNumber = &zone
+		} else if target.Type != TargetNone {
+			low, high := target.Low, target.High
+			dto.TargetValueOne, dto.TargetValueTwo = &low, &high
+		}
+		out = append(out, dto)
+	}
+	return out
+}
+
+// UnmarshalJSON decodes a workout-service payload
+func (w *Workout) UnmarshalJSON(data []byte) error {
+	var dto workoutDTO
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	*w = Workout{
+		WorkoutID:   dto.WorkoutID,
+		Name:        dto.WorkoutName,
+		Description: dto.Description,
+		Sport:       dto.SportType.SportTypeKey,
+	}
+	if dto.CreatedDate != nil {
+		w.CreatedDate = *dto.CreatedDate
+	}
+	if dto.UpdatedDate != nil {
+		w.UpdatedDate = *dto.UpdatedDate
+	}
+	for _, seg := range dto.WorkoutSegments {
+		w.Segments = append(w.Segments, WorkoutSegment{
+			Sport: seg.SportType.SportTypeKey,
+			Steps: decodeSteps(seg.WorkoutSteps),
+		})
+	}
+	return nil
+}
+
+func decodeSteps(steps []workoutStepDTO) []WorkoutStep {
+	out := make([]WorkoutStep, 0, len(steps))
+	for _, dto := range steps {
+		s := WorkoutStep{Type: dto.StepType.StepTypeKey, Description: dto.Description}
+		if dto.Type == "RepeatGroupDTO" || s.Type == StepRepeat {
+			s.Type = StepRepeat
+			s.Iterations = dto.NumberOfIterations
+			s.Steps = decodeSteps(dto.WorkoutSteps)
+			out = append(out, s)
+			continue
+		}
+
+		s.Duration.Type = dto.EndCondition.ConditionTypeKey
+		if dto.EndConditionValue != nil {
+			s.Duration.Value = *dto.EndConditionValue
+		}
+		s.Target.Type = TargetNone
+		if dto.TargetType != nil {
+			s.Target.Type = dto.TargetType.WorkoutTargetTypeKey
+		}
+		if dto.ZoneNumber != nil {
+			s.Target.Zone = *dto.ZoneNumber
+		}
+		if dto.TargetValueOne != nil {
+			s.Target.Low = *dto.TargetValueOne
+		}
+		if dto.TargetValueTwo != nil {
+			s.Target.High = *dto.TargetValueTwo
+		}
+		out = append(out, s)
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package garmin
+
+// WorkoutBuilder assembles a Workout step by step:
+//
+//	workout, err := garmin.NewWorkout("5x1k", garmin.WorkoutSportRunning).
+//		Warmup(garmin.ForTime(10*time.Minute), garmin.HeartRateZone(2)).
+//		Repeat(5, func(r *garmin.WorkoutBuilder) {
+//			r.Interval(garmin.ForDistance(1000), garmin.PaceRange(4*time.Minute, 3*time.Minute+50*time.Second))
+//			r.Recovery(garmin.ForTime(2*time.Minute), garmin.NoTarget())
+//		}).
+//		Cooldown(garmin.LapButton(), garmin.NoTarget()).
+//		Build()
+type WorkoutBuilder struct {
+	workout Workout
+	sport   string // Sport of the segment being built
+	steps   []WorkoutStep
+}
+
+// NewWorkout starts a workout for the given sport (one of the WorkoutSport* constants)
+func NewWorkout(name, sport string) *WorkoutBuilder {
+	return &WorkoutBuilder{workout: Workout{Name: name, Sport: sport}, sport: sport}
+}
+
+// Description sets the workout description
+func (b *WorkoutBuilder) Description(description string) *WorkoutBuilder {
+	b.workout.Description = description
+	return b
+}
+
+// Step appends a step of the given type (one of the Step* constants)
+func (b *WorkoutBuilder) Step(stepType string, duration StepDuration, target StepTarget) *WorkoutBuilder {
+	b.steps = append(b.steps, WorkoutStep{Type: stepType, Duration: duration, Target: target})
+	return b
+}
+
+// Warmup appends a warm-up step
+func (b *WorkoutBuilder) Warmup(duration StepDuration, target StepTarget) *WorkoutBuilder {
+	return b.Step(StepWarmup, duration, target)
+}
+
+// Interval appends a work interval
+func (b *WorkoutBuilder) Interval(duration StepDuration, target StepTarget) *WorkoutBuilder {
+	return b.Step(StepInterval, duration, target)
+}
+
+// Recovery appends a recovery step
+func (b *WorkoutBuilder) Recovery(duration StepDuration, target StepTarget) *WorkoutBuilder {
+	return b.Step(StepRecovery, duration, target)
+}
+
+// Rest appends a rest step
+func (b *WorkoutBuilder) Rest(duration StepDuration) *WorkoutBuilder {
+	return b.Step(StepRest, duration, NoTarget())
+}
+
+// Cooldown appends a cool-down step
+func (b *WorkoutBuilder) Cooldown(duration StepDuration, target StepTarget) *WorkoutBuilder {
+	return b.Step(StepCooldown, duration, target)
+}
+
+// Note sets the description of the most recently added step
+func (b *WorkoutBuilder) Note(text string) *WorkoutBuilder {
+	if len(b.steps) > 0 {
+		b.steps[len(b.steps)-1].Description = text
+	}
+	return b
+}
+
+// Repeat appends a repeat group; steps added to r inside fn are performed
+// the given number of times
+func (b *WorkoutBuilder) Repeat(iterations int, fn func(r *WorkoutBuilder)) *WorkoutBuilder {
+	group := &WorkoutBuilder{}
+	fn(group)
+	b.steps = append(b.steps, WorkoutStep{Type: StepRepeat, Iterations: iterations, Steps: group.steps})
+	return b
+}
+
+// Segment starts a new segment for another sport, as used by multisport
+// workouts. Steps added before the first call belong to the workout sport.
+func (b *WorkoutBuilder) Segment(sport string) *WorkoutBuilder {
+	b.flush()
+	b.sport = sport
+	return b
+}
+
+// flush closes the segment being built
+func (b *WorkoutBuilder) flush() {
+	if len(b.steps) == 0 {
+		return
+	}
+	b.workout.Segments = append(b.workout.Segments, WorkoutSegment{Sport: b.sport, Steps: b.steps})
+	b.steps = nil
+}
+
+// Build validates and returns the workout. The builder should not be reused.
+func (b *WorkoutBuilder) Build() (*Workout, error) {
+	b.flush()
+	workout := b.workout
+	if err := workout.Validate(); err != nil {
+		return nil, err
+	}
+	return &workout, nil
+}
@@ -0,0 +1,147 @@
+package garmin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sstent/go-garth/pkg/garmin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func intervalWorkout(t *testing.T) *garmin.Workout {
+	t.Helper()
+	workout, err := garmin.NewWorkout("5x1k", garmin.WorkoutSportRunning).
+		Description("Threshold intervals").
+		Warmup(garmin.ForTime(10*time.Minute), garmin.HeartRateZone(2)).
+		Repeat(5, func(r *garmin.WorkoutBuilder) {
+			r.Interval(garmin.ForDistance(1000), garmin.PaceRange(4*time.Minute, 3*time.Minute+50*time.Second))
+			r.Recovery(garmin.ForTime(2*time.Minute), garmin.NoTarget()).Note("Jog")
+		}).
+		Cooldown(garmin.LapButton(), garmin.CadenceRange(160, 175)).
+		Build()
+	require.NoError(t, err)
+	return workout
+}
+
+func TestWorkout_JSON(t *testing.T) {
+	workout := intervalWorkout(t)
+	assert.Equal(t, 20*time.Minute, workout.EstimatedDuration())
+
+	data, err := json.Marshal(workout)
+	require.NoError(t, err)
+
+	var raw map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, "5x1k", raw["workoutName"])
+	assert.Equal(t, float64(1200), raw["estimatedDurationInSecs"])
+	steps := raw["workoutSegments"].([]interface{})[0].(map[string]interface{})["workoutSteps"].([]interface{})
+	require.Len(t, steps, 3)
+	repeat := steps[1].(map[string]interface{})
+	assert.Equal(t, "RepeatGroupDTO", repeat["type"])
+	assert.Equal(t, float64(5), repeat["numberOfIterations"])
+	interval := repeat["workoutSteps"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(3), interval["stepOrder"])
+	assert.Equal(t, float64(1), interval["childStepId"])
+	assert.Equal(t, "pace.zone", interval["targetType"].(map[string]interface{})["workoutTargetTypeKey"])
+	assert.InDelta(t, 4.1667, interval["targetValueOne"], 0.001)
+
+	var decoded garmin.Workout
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, *workout, decoded)
+}
+
+func TestWorkout_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *garmin.WorkoutBuilder
+		field   string
+	}{
+		{"empty", garmin.NewWorkout("Empty", garmin.WorkoutSportRunning), "Segments"},
+		{"sport", garmin.NewWorkout("Swim", "underwater_hockey").Rest(garmin.LapButton()), "Sport"},
+		{"zero duration", garmin.NewWorkout("Run", garmin.WorkoutSportRunning).
+			Interval(garmin.ForTime(0), garmin.NoTarget()), "Segments[0].Steps[0].Duration"},
+		{"zone", garmin.NewWorkout("Run", garmin.WorkoutSportRunning).
+			Interval(garmin.LapButton(), garmin.HeartRateZone(6)), "Segments[0].Steps[0].Target"},
+		{"range", garmin.NewWorkout("Ride", garmin.WorkoutSportCycling).
+			Interval(garmin.LapButton(), garmin.PowerRange(300, 250)), "Segments[0].Steps[0].Target"},
+		{"empty repeat", garmin.NewWorkout("Run", garmin.WorkoutSportRunning).
+			Repeat(3, func(*garmin.WorkoutBuilder) {}), "Segments[0].Steps[0].Steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			var invalid *garmin.ValidationError
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, tt.field, invalid.Field)
+		})
+	}
+}
+
+func TestWorkouts(t *testing.T) {
+	var calls []string
+	var created, schedule map[string]interface{}
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /workout-service/workout":
+			if !assert.NoError(t, json.Unmarshal(body, &created)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			created["workoutId"] = 77
+			json.NewEncoder(w).Encode(created)
+		case "GET /workout-service/workouts":
+			assert.Equal(t, "true", r.URL.Query().Get("myWorkoutsOnly"))
+			w.Write([]byte(`[{"workoutId": 77, "workoutName": "5x1k", "sportType": {"sportTypeId": 1, "sportTypeKey": "running"},
+				"updatedDate": "2025-03-01T10:00:00.0"}]`))
+		case "POST /workout-service/schedule/77":
+			if !assert.NoError(t, json.Unmarshal(body, &schedule)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"workoutScheduleId": 900, "calendarDate": "2025-03-04", "workout": {"workoutId": 77, "workoutName": "5x1k"}}`))
+		case "PUT /workout-service/workout/77", "DELETE /workout-service/workout/77", "DELETE /workout-service/schedule/900":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	ctx := context.Background()
+
+	_, err := c.CreateWorkout(ctx, &garmin.Workout{Name: "Nothing", Sport: garmin.WorkoutSportRunning})
+	var invalid *garmin.ValidationError
+	require.ErrorAs(t, err, &invalid)
+	assert.Empty(t, calls, "invalid workouts must not reach the API")
+
+	workout, err := c.CreateWorkout(ctx, intervalWorkout(t))
+	require.NoError(t, err)
+	assert.Equal(t, int64(77), workout.WorkoutID)
+	assert.Equal(t, "Threshold intervals", created["description"])
+
+	list, err := c.ListWorkouts(ctx, 0, 20)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, 2025, list[0].UpdatedDate.Year())
+
+	_, err = c.GetWorkout(ctx, 5)
+	var notFound *garmin.NotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	scheduled, err := c.ScheduleWorkout(ctx, 77, time.Date(2025, 3, 4, 18, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-04", schedule["date"])
+	assert.Equal(t, int64(900), scheduled.ScheduleID)
+	assert.Equal(t, "5x1k", scheduled.Workout.Name)
+
+	require.NoError(t, c.UpdateWorkout(ctx, workout))
+	require.NoError(t, c.UnscheduleWorkout(ctx, 900))
+	require.NoError(t, c.DeleteWorkout(ctx, 77))
+	assert.ErrorAs(t, c.UpdateWorkout(ctx, intervalWorkout(t)), &invalid)
+}
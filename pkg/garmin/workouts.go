@@ -0,0 +1,163 @@
+package garmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sstent/go-garth/internal/errors"
+)
+
+// ScheduledWorkout is a workout placed on the training calendar
+type ScheduledWorkout struct {
+	ScheduleID int64      `json:"workoutScheduleId"`
+	Date       GarminTime `json:"calendarDate"`
+	Workout    *Workout   `json:"workout"`
+}
+
+// ListWorkouts retrieves the current user's workouts, most recently updated
+// first. Listed workouts carry no steps; use GetWorkout for the full workout.
+func (c *Client) ListWorkouts(ctx context.Context, start, limit int) ([]Workout, error) {
+	params := url.Values{}
+	params.Set("start", strconv.Itoa(start))
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("myWorkoutsOnly", "true")
+	params.Set("orderBy", "UPDATE_DATE")
+	params.Set("orderSeq", "DESC")
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/workout-service/workouts", "GET", params, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workouts: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var workouts []Workout
+	if err := json.Unmarshal(data, &workouts); err != nil {
+		return nil, fmt.Errorf("failed to parse workouts response: %w", err)
+	}
+	return workouts, nil
+}
+
+// GetWorkout retrieves a workout with its steps
+func (c *Client) GetWorkout(ctx context.Context, workoutID int64) (*Workout, error) {
+	path := fmt.Sprintf("/workout-service/workout/%d", workoutID)
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
+	if err != nil {
+		return nil, resourceError(fmt.Errorf("failed to get workout: %w", err), "workout", strconv.FormatInt(workoutID, 10))
+	}
+
+	if len(data) == 0 {
+		return nil, &errors.NotFoundError{Resource: "workout", ID: strconv.FormatInt(workoutID, 10)}
+	}
+
+	var workout Workout
+	if err := json.Unmarshal(data, &workout); err != nil {
+		return nil, fmt.Errorf("failed to parse workout response: %w", err)
+	}
+	return &workout, nil
+}
+
+// CreateWorkout validates a workout and saves it to the workout library,
+// returning the created workout with its ID
+func (c *Client) CreateWorkout(ctx context.Context, workout *Workout) (*Workout, error) {
+	if err := workout.Validate(); err != nil {
+		return nil, err
+	}
+
+	created := *workout
+	created.WorkoutID = 0
+	body, err := json.Marshal(created)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workout: %w", err)
+	}
+
+	data, err := c.Client.ConnectAPIWithContext(ctx, "/workout-service/workout", "POST", nil, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workout: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("failed to create workout: empty response")
+	}
+
+	var result Workout
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse workout response: %w", err)
+	}
+	return &result, nil
+}
+
+// UpdateWorkout validates a workout and replaces the saved workout with the same ID
+func (c *Client) UpdateWorkout(ctx context.Context, workout *Workout) error {
+	if workout.WorkoutID == 0 {
+		return &errors.ValidationError{
+			GarthError: errors.GarthError{Message: "workout ID is required to update a workout"},
+			Field:      "WorkoutID",
+		}
+	}
+	if err := workout.Validate(); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(workout)
+	if err != nil {
+		return fmt.Errorf("failed to encode workout: %w", err)
+	}
+
+	id := strconv.FormatInt(workout.WorkoutID, 10)
+	path := fmt.Sprintf("/workout-service/workout/%d", workout.WorkoutID)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "PUT", nil, bytes.NewReader(body)); err != nil {
+		return resourceError(fmt.Errorf("failed to update workout: %w", err), "workout", id)
+	}
+	return nil
+}
+
+// DeleteWorkout deletes a workout from the workout library
+func (c *Client) DeleteWorkout(ctx context.Context, workoutID int64) error {
+	path := fmt.Sprintf("/workout-service/workout/%d", workoutID)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "DELETE", nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to delete workout: %w", err), "workout", strconv.FormatInt(workoutID, 10))
+	}
+	return nil
+}
+
+// ScheduleWorkout places a saved workout on the training calendar, from where
+// it is synced to the user's devices
+func (c *Client) ScheduleWorkout(ctx context.Context, workoutID int64, date time.Time) (*ScheduledWorkout, error) {
+	body, err := json.Marshal(map[string]string{"date": date.Format("2006-01-02")})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workout schedule: %w", err)
+	}
+
+	path := fmt.Sprintf("/workout-service/schedule/%d", workoutID)
+	data, err := c.Client.ConnectAPIWithContext(ctx, path, "POST", nil, bytes.NewReader(body))
+	if err != nil {
+		return nil, resourceError(fmt.Errorf("failed to schedule workout: %w", err), "workout", strconv.FormatInt(workoutID, 10))
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var scheduled ScheduledWorkout
+	if err := json.Unmarshal(data, &scheduled); err != nil {
+		return nil, fmt.Errorf("failed to parse workout schedule response: %w", err)
+	}
+	return &scheduled, nil
+}
+
+// UnscheduleWorkout removes a scheduled workout from the training calendar
+func (c *Client) UnscheduleWorkout(ctx context.Context, scheduleID int64) error {
+	path := fmt.Sprintf("/workout-service/schedule/%d", scheduleID)
+	if _, err := c.Client.ConnectAPIWithContext(ctx, path, "DELETE", nil, nil); err != nil {
+		return resourceError(fmt.Errorf("failed to unschedule workout: %w", err), "workout schedule", strconv.FormatInt(scheduleID, 10))
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -284,6 +286,25 @@ func (c *Client) GetUserProfile() (*garth.UserProfile, error) {
 
 // ConnectAPI makes a raw API request to the Garmin Connect API
 func (c *Client) ConnectAPI(path string, method string, params url.Values, body io.Reader) ([]byte, error) {
+	return c.ConnectAPIWithContext(context.Background(), path, method, params, body)
+}
+
+// ConnectAPIWithContext makes a raw API request bound to ctx, so callers can
+// cancel long-running requests or apply deadlines.
+func (c *Client) ConnectAPIWithContext(ctx context.Context, path string, method string, params url.Values, body io.Reader) ([]byte, error) {
+	data, _, err := c.doRequest(ctx, path, method, params, body, "application/json", "")
+	return data, err
+}
+
+// DownloadWithContext retrieves a raw file from the Garmin Connect API and
+// returns its content together with the response Content-Type
+func (c *Client) DownloadWithContext(ctx context.Context, path string, params url.Values) ([]byte, string, error) {
+	return c.doRequest(ctx, path, "GET", params, nil, "*/*", "")
+}
+
+// doRequest performs an API request and returns the response body and Content-Type.
+// An empty contentType defaults to JSON when a body is sent.
+func (c *Client) doRequest(ctx context.Context, path string, method string, params url.Values, body io.Reader, accept string, contentType string) ([]byte, string, error) {
 	scheme := "https"
 	if strings.HasPrefix(c.Domain, "127.0.0.1") {
 		scheme = "http"
@@ -295,9 +316,9 @@ func (c *Client) ConnectAPI(path string, method string, params url.Values, body
 		RawQuery: params.Encode(),
 	}
 
-	req, err := http.NewRequest(method, u.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
 	if err != nil {
-		return nil, &errors.APIError{
+		return nil, "", &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
 				GarthError: errors.GarthError{
 					Message: "Failed to create request",
@@ -309,15 +330,18 @@ func (c *Client) ConnectAPI(path string, method string, params url.Values, body
 
 	req.Header.Set("Authorization", c.AuthToken)
 	req.Header.Set("User-Agent", "garth-go-client/1.0")
-	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept", accept)
 
-	if body != nil && req.Header.Get("Content-Type") == "" {
-		req.Header.Set("Content-Type", "application/json")
+	if body != nil {
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, &errors.APIError{
+		return nil, "", &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
 				GarthError: errors.GarthError{
 					Message: "Request failed",
@@ -330,7 +354,7 @@ func (c *Client) ConnectAPI(path string, method string, params url.Values, body
 
 	if resp.StatusCode >= 400 {
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, &errors.APIError{
+		return nil, "", &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
 				StatusCode: resp.StatusCode,
 				Response:   string(bodyBytes),
@@ -342,7 +366,17 @@ func (c *Client) ConnectAPI(path string, method string, params url.Values, body
 		}
 	}
 
-	return io.ReadAll(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", &errors.IOError{
+			GarthError: errors.GarthError{
+				Message: "Failed to read response body",
+				Cause:   err,
+			},
+		}
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
 }
 
 func tryReadErrorBody(r io.Reader) string {
@@ -366,9 +400,15 @@ func (c *Client) Upload(filePath string) error {
 	}
 	defer file.Close()
 
+	return c.UploadReader(context.Background(), filepath.Base(filePath), file)
+}
+
+// UploadReader sends file content to Garmin Connect. The filename extension
+// tells Garmin Connect the format (.fit, .gpx or .tcx).
+func (c *Client) UploadReader(ctx context.Context, filename string, r io.Reader) error {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", filepath.Base(filePath))
+	part, err := writer.CreateFormFile("file", filename)
 	if err != nil {
 		return &errors.IOError{
 			GarthError: errors.GarthError{
@@ -378,7 +418,7 @@ func (c *Client) Upload(filePath string) error {
 		}
 	}
 
-	if _, err := io.Copy(part, file); err != nil {
+	if _, err := io.Copy(part, r); err != nil {
 		return &errors.IOError{
 			GarthError: errors.GarthError{
 				Message: "Failed to copy file content",
@@ -396,7 +436,9 @@ func (c *Client) Upload(filePath string) error {
 		}
 	}
 
-	_, err = c.ConnectAPI("/upload-service/upload", "POST", nil, body)
+	// The multipart boundary must reach the server, so the JSON content type
+	// ConnectAPI sends is not usable here
+	_, _, err = c.doRequest(ctx, "/upload-service/upload", "POST", nil, body, "application/json", writer.FormDataContentType())
 	if err != nil {
 		return &errors.APIError{
 			GarthHTTPError: errors.GarthHTTPError{
@@ -411,16 +453,11 @@ func (c *Client) Upload(filePath string) error {
 	return nil
 }
 
-// Download retrieves a file from Garmin Connect
+// Download retrieves a file from Garmin Connect. Export formats (gpx, tcx, kml,
+// csv) use their format-specific route; an empty format or "fit" fetches the
+// original upload, which Garmin serves as a zip archive.
 func (c *Client) Download(activityID string, format string, filePath string) error {
-	params := url.Values{}
-	params.Add("activityId", activityID)
-	// Add format parameter if provided and not empty
-	if format != "" {
-		params.Add("format", format)
-	}
-
-	resp, err := c.ConnectAPI("/download-service/export", "GET", params, nil)
+	resp, _, err := c.DownloadWithContext(context.Background(), ActivityDownloadPath(activityID, format), nil)
 	if err != nil {
 		return err
 	}
@@ -437,6 +474,15 @@ func (c *Client) Download(activityID string, format string, filePath string) err
 	return nil
 }
 
+// ActivityDownloadPath returns the download-service route for an activity
+// export format, or the original-file route for "" and "fit"
+func ActivityDownloadPath(activityID string, format string) string {
+	if format == "" || format == "fit" {
+		return fmt.Sprintf("/download-service/files/activity/%s", activityID)
+	}
+	return fmt.Sprintf("/download-service/export/%s/activity/%s", format, activityID)
+}
+
 // GetActivities retrieves recent activities
 func (c *Client) GetActivities(limit int) ([]garth.Activity, error) {
 	if limit <= 0 {
@@ -733,40 +779,63 @@ func (c *Client) GetCaloriesData(startDate, endDate time.Time) ([]garth.Calories
 	return result, nil
 }
 
-// GetVO2MaxData retrieves VO2 max data using the modern approach via user settings
+// GetVO2MaxData retrieves the VO2 max history between two dates from the
+// maxmet endpoint. Only days with a new running or cycling estimate are
+// returned, oldest first.
 func (c *Client) GetVO2MaxData(startDate, endDate time.Time) ([]garth.VO2MaxData, error) {
-	// Get user settings which contains current VO2 max values
-	settings, err := c.GetUserSettings()
+	return GetVO2MaxHistory(c, startDate, endDate)
+}
+
+// GetVO2MaxHistory retrieves the VO2 max history between two dates from the
+// maxmet endpoint through any API client. It backs GetVO2MaxData and the
+// data package's VO2MaxData.
+func GetVO2MaxHistory(c shared.APIClient, startDate, endDate time.Time) ([]garth.VO2MaxData, error) {
+	path := fmt.Sprintf("/metrics-service/metrics/maxmet/daily/%s/%s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	data, err := c.ConnectAPI(path, "GET", nil, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user settings: %w", err)
+		return nil, fmt.Errorf("failed to get VO2 max history: %w", err)
 	}
 
-	// Create VO2MaxData for the date range
-	var results []garth.VO2MaxData
-	current := startDate
-	for !current.After(endDate) {
-		vo2Data := garth.VO2MaxData{
-			Date:          current,
-			UserProfilePK: settings.ID,
-		}
+	if len(data) == 0 {
+		return nil, nil
+	}
 
-		// Set VO2 max values if available
-		if settings.UserData.VO2MaxRunning != nil {
-			vo2Data.VO2MaxRunning = settings.UserData.VO2MaxRunning
-		}
-		if settings.UserData.VO2MaxCycling != nil {
-			vo2Data.VO2MaxCycling = settings.UserData.VO2MaxCycling
-		}
+	var days []garth.MaxMetDaily
+	if err := json.Unmarshal(data, &days); err != nil {
+		return nil, fmt.Errorf("failed to parse VO2 max history: %w", err)
+	}
 
-		results = append(results, vo2Data)
-		current = current.AddDate(0, 0, 1)
+	var results []garth.VO2MaxData
+	for i := range days {
+		if v, ok := days[i].VO2MaxData(); ok {
+			results = append(results, v)
+		}
 	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Date.Before(results[j].Date)
+	})
 
 	return results, nil
 }
 
-// GetCurrentVO2Max retrieves the current VO2 max values from user profile
+// VO2MaxLookback is how many days of maxmet history are searched for the
+// current VO2 max
+const VO2MaxLookback = 365
+
+// GetCurrentVO2Max retrieves the latest VO2 max values from the past year of
+// maxmet history, falling back to the values stored in user settings
 func (c *Client) GetCurrentVO2Max() (*garth.VO2MaxProfile, error) {
+	now := time.Now()
+	history, err := c.GetVO2MaxData(now.AddDate(0, 0, -VO2MaxLookback), now)
+	if err != nil {
+		return nil, err
+	}
+	if len(history) > 0 {
+		return garth.NewVO2MaxProfile(history), nil
+	}
+
 	settings, err := c.GetUserSettings()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user settings: %w", err)
@@ -962,11 +1031,12 @@ func (c *Client) SaveSession(filename string) error {
 
 // GetDetailedSleepData retrieves comprehensive sleep data for a date
 func (c *Client) GetDetailedSleepData(date time.Time) (*garth.DetailedSleepData, error) {
-	dateStr := date.Format("2006-01-02")
-	path := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s?date=%s&nonSleepBufferMinutes=60",
-		c.Username, dateStr)
+	path := fmt.Sprintf("/wellness-service/wellness/dailySleepData/%s", c.Username)
+	params := url.Values{}
+	params.Set("date", date.Format("2006-01-02"))
+	params.Set("nonSleepBufferMinutes", "60")
 
-	data, err := c.ConnectAPI(path, "GET", nil, nil)
+	data, err := c.ConnectAPI(path, "GET", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get detailed sleep data: %w", err)
 	}
@@ -976,16 +1046,16 @@ func (c *Client) GetDetailedSleepData(date time.Time) (*garth.DetailedSleepData,
 	}
 
 	var response struct {
-		DailySleepDTO                       *garth.DetailedSleepData `json:"dailySleepDTO"`
-		SleepMovement                       []garth.SleepMovement    `json:"sleepMovement"`
-		RemSleepData                        bool                     `json:"remSleepData"`
-		SleepLevels                         []garth.SleepLevel       `json:"sleepLevels"`
-		SleepRestlessMoments                []interface{}            `json:"sleepRestlessMoments"`
-		RestlessMomentsCount                int                      `json:"restlessMomentsCount"`
-		WellnessSpO2SleepSummaryDTO         interface{}              `json:"wellnessSpO2SleepSummaryDTO"`
-		WellnessEpochSPO2DataDTOList        []interface{}            `json:"wellnessEpochSPO2DataDTOList"`
-		WellnessEpochRespirationDataDTOList []interface{}            `json:"wellnessEpochRespirationDataDTOList"`
-		SleepStress                         interface{}              `json:"sleepStress"`
+		DailySleepDTO                       *garth.DetailedSleepData    `json:"dailySleepDTO"`
+		SleepMovement                       []garth.SleepMovement       `json:"sleepMovement"`
+		RemSleepData                        bool                        `json:"remSleepData"`
+		SleepLevels                         []garth.SleepLevel          `json:"sleepLevels"`
+		SleepRestlessMoments                []garth.SleepRestlessMoment `json:"sleepRestlessMoments"`
+		RestlessMomentsCount                int                         `json:"restlessMomentsCount"`
+		WellnessSpO2SleepSummaryDTO         *garth.SpO2SleepSummary     `json:"wellnessSpO2SleepSummaryDTO"`
+		WellnessEpochSPO2DataDTOList        []garth.SpO2Epoch           `json:"wellnessEpochSPO2DataDTOList"`
+		WellnessEpochRespirationDataDTOList []garth.RespirationEpoch    `json:"wellnessEpochRespirationDataDTOList"`
+		SleepStress                         []garth.SleepStressEpoch    `json:"sleepStress"`
 	}
 
 	if err := json.Unmarshal(data, &response); err != nil {
@@ -999,17 +1069,23 @@ func (c *Client) GetDetailedSleepData(date time.Time) (*garth.DetailedSleepData,
 	// Populate additional data
 	response.DailySleepDTO.SleepMovement = response.SleepMovement
 	response.DailySleepDTO.SleepLevels = response.SleepLevels
+	response.DailySleepDTO.SpO2Summary = response.WellnessSpO2SleepSummaryDTO
+	response.DailySleepDTO.SpO2Epochs = response.WellnessEpochSPO2DataDTOList
+	response.DailySleepDTO.RespirationEpochs = response.WellnessEpochRespirationDataDTOList
+	response.DailySleepDTO.StressEpochs = response.SleepStress
+	response.DailySleepDTO.RestlessMoments = response.SleepRestlessMoments
+	response.DailySleepDTO.RestlessCount = response.RestlessMomentsCount
 
 	return response.DailySleepDTO, nil
 }
 
 // GetDailyHRVData retrieves comprehensive daily HRV data for a date
 func (c *Client) GetDailyHRVData(date time.Time) (*garth.DailyHRVData, error) {
-	dateStr := date.Format("2006-01-02")
-	path := fmt.Sprintf("/wellness-service/wellness/dailyHrvData/%s?date=%s",
-		c.Username, dateStr)
+	path := fmt.Sprintf("/wellness-service/wellness/dailyHrvData/%s", c.Username)
+	params := url.Values{}
+	params.Set("date", date.Format("2006-01-02"))
 
-	data, err := c.ConnectAPI(path, "GET", nil, nil)
+	data, err := c.ConnectAPI(path, "GET", params, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get HRV data: %w", err)
 	}
@@ -1034,18 +1110,24 @@ func (c *Client) GetDailyHRVData(date time.Time) (*garth.DailyHRVData, error) {
 
 // GetDetailedBodyBatteryData retrieves comprehensive Body Battery data for a date
 func (c *Client) GetDetailedBodyBatteryData(date time.Time) (*garth.DetailedBodyBatteryData, error) {
+	return c.GetDetailedBodyBatteryDataWithContext(context.Background(), date)
+}
+
+// GetDetailedBodyBatteryDataWithContext retrieves comprehensive Body Battery
+// data for a date, bound to ctx
+func (c *Client) GetDetailedBodyBatteryDataWithContext(ctx context.Context, date time.Time) (*garth.DetailedBodyBatteryData, error) {
 	dateStr := date.Format("2006-01-02")
 
 	// Get main Body Battery data
 	path1 := fmt.Sprintf("/wellness-service/wellness/dailyStress/%s", dateStr)
-	data1, err := c.ConnectAPI(path1, "GET", nil, nil)
+	data1, err := c.ConnectAPIWithContext(ctx, path1, "GET", nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Body Battery stress data: %w", err)
 	}
 
 	// Get Body Battery events
-	path2 := fmt.Sprintf("/wellness-service/wellness/bodyBattery/%s", dateStr)
-	data2, err := c.ConnectAPI(path2, "GET", nil, nil)
+	path2 := fmt.Sprintf("/wellness-service/wellness/bodyBattery/events/%s", dateStr)
+	data2, err := c.ConnectAPIWithContext(ctx, path2, "GET", nil, nil)
 	if err != nil {
 		// Events might not be available, continue without them
 		data2 = []byte("[]")
@@ -1058,9 +1140,8 @@ func (c *Client) GetDetailedBodyBatteryData(date time.Time) (*garth.DetailedBody
 		}
 	}
 
-	var events []garth.BodyBatteryEvent
 	if len(data2) > 0 {
-		if err := json.Unmarshal(data2, &events); err == nil {
+		if events, err := garth.ParseBodyBatteryEvents(data2); err == nil {
 			result.Events = events
 		}
 	}
@@ -1068,12 +1149,18 @@ func (c *Client) GetDetailedBodyBatteryData(date time.Time) (*garth.DetailedBody
 	return &result, nil
 }
 
-// GetTrainingStatus retrieves current training status
+// GetTrainingStatus retrieves the training status of a day with its acute
+// load, load focus and VO2 max. It returns nil when there is no status.
 func (c *Client) GetTrainingStatus(date time.Time) (*garth.TrainingStatus, error) {
+	return c.GetTrainingStatusWithContext(context.Background(), date)
+}
+
+// GetTrainingStatusWithContext is GetTrainingStatus with a context
+func (c *Client) GetTrainingStatusWithContext(ctx context.Context, date time.Time) (*garth.TrainingStatus, error) {
 	dateStr := date.Format("2006-01-02")
-	path := fmt.Sprintf("/metrics-service/metrics/trainingStatus/%s", dateStr)
+	path := fmt.Sprintf("/metrics-service/metrics/trainingstatus/aggregated/%s", dateStr)
 
-	data, err := c.ConnectAPI(path, "GET", nil, nil)
+	data, err := c.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get training status: %w", err)
 	}
@@ -1082,21 +1169,38 @@ func (c *Client) GetTrainingStatus(date time.Time) (*garth.TrainingStatus, error
 		return nil, nil
 	}
 
-	var result garth.TrainingStatus
-	if err := json.Unmarshal(data, &result); err != nil {
+	status, err := garth.ParseTrainingStatus(data)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse training status: %w", err)
 	}
 
-	return &result, nil
+	return status, nil
 }
 
-// GetTrainingLoad retrieves training load data
+// GetTrainingLoad retrieves the training load of a day. It returns nil when
+// there is none.
 func (c *Client) GetTrainingLoad(date time.Time) (*garth.TrainingLoad, error) {
+	results, err := c.GetTrainingLoadRange(context.Background(), date, date)
+	if err != nil {
+		return nil, err
+	}
+
 	dateStr := date.Format("2006-01-02")
-	endDate := date.AddDate(0, 0, 6).Format("2006-01-02") // Get week of data
-	path := fmt.Sprintf("/metrics-service/metrics/trainingLoad/%s/%s", dateStr, endDate)
+	for i := range results {
+		if results[i].CalendarDate.Format("2006-01-02") == dateStr {
+			return &results[i], nil
+		}
+	}
+	return nil, nil
+}
 
-	data, err := c.ConnectAPI(path, "GET", nil, nil)
+// GetTrainingLoadRange retrieves the daily training load between two dates,
+// inclusive, oldest first
+func (c *Client) GetTrainingLoadRange(ctx context.Context, startDate, endDate time.Time) ([]garth.TrainingLoad, error) {
+	path := fmt.Sprintf("/metrics-service/metrics/trainingLoad/%s/%s",
+		startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+
+	data, err := c.ConnectAPIWithContext(ctx, path, "GET", nil, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get training load: %w", err)
 	}
@@ -1110,11 +1214,10 @@ func (c *Client) GetTrainingLoad(date time.Time) (*garth.TrainingLoad, error) {
 		return nil, fmt.Errorf("failed to parse training load: %w", err)
 	}
 
-	if len(results) == 0 {
-		return nil, nil
-	}
-
-	return &results[0], nil
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].CalendarDate.Before(results[j].CalendarDate.Time)
+	})
+	return results, nil
 }
 
 // LoadSession loads a session from a file